@@ -179,3 +179,40 @@ export namespace time {
 
 }
 
+export namespace wails {
+	
+	export class CompleteTaskResult {
+	    blocked: boolean;
+	    blockers?: domain.Task[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CompleteTaskResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.blocked = source["blocked"];
+	        this.blockers = this.convertValues(source["blockers"], domain.Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
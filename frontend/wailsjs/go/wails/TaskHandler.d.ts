@@ -2,13 +2,16 @@
 // This file is automatically generated. DO NOT EDIT
 import {time} from '../models';
 import {app} from '../models';
+import {wails} from '../models';
 
-export function CompleteTask(arg1:string):Promise<void>;
+export function CompleteTask(arg1:string):Promise<wails.CompleteTaskResult>;
 
 export function CreateTask(arg1:string,arg2:string,arg3:time.Time):Promise<app.CreateTaskOutput>;
 
 export function DeleteTask(arg1:string):Promise<void>;
 
+export function ForceCompleteTask(arg1:string):Promise<void>;
+
 export function GetDashboard():Promise<app.GetDashboardOutput>;
 
 export function GetTask(arg1:string):Promise<app.GetTaskOutput>;
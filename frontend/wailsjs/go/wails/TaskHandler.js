@@ -14,6 +14,10 @@ export function DeleteTask(arg1) {
   return window['go']['wails']['TaskHandler']['DeleteTask'](arg1);
 }
 
+export function ForceCompleteTask(arg1) {
+  return window['go']['wails']['TaskHandler']['ForceCompleteTask'](arg1);
+}
+
 export function GetDashboard() {
   return window['go']['wails']['TaskHandler']['GetDashboard']();
 }
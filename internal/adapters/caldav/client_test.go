@@ -0,0 +1,130 @@
+package caldav
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+	gocaldav "github.com/emersion/go-webdav/caldav"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func newVTODO(uid, summary string, completed bool) *ical.Calendar {
+	todo := ical.NewComponent(ical.CompToDo)
+	todo.Props.SetText(ical.PropUID, uid)
+	todo.Props.SetText(ical.PropSummary, summary)
+	todo.Props.SetDateTime(ical.PropDateTimeStamp, time.Now().UTC())
+	if completed {
+		todo.Props.SetText(ical.PropStatus, "COMPLETED")
+		todo.Props.SetDateTime(ical.PropCompleted, time.Now().UTC())
+	}
+
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "-//test//caldav client//EN")
+	cal.Children = append(cal.Children, todo)
+	return cal
+}
+
+func todoQuery() *gocaldav.CalendarQuery {
+	return &gocaldav.CalendarQuery{
+		CompRequest: gocaldav.CalendarCompRequest{Name: "VCALENDAR", AllProps: true, AllComps: true},
+		CompFilter: gocaldav.CompFilter{
+			Name:  "VCALENDAR",
+			Comps: []gocaldav.CompFilter{{Name: "VTODO"}},
+		},
+	}
+}
+
+// TestClientRoundTrip - путь настоящего клиента: обнаружение календарей через
+// PROPFIND, выборка REPORT, создание, закрытие и удаление VTODO
+func TestClientRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	srv := httptest.NewServer(newTestServer(repo))
+	defer srv.Close()
+
+	client, err := gocaldav.NewClient(srv.Client(), srv.URL+rootPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	principal, err := client.FindCurrentUserPrincipal(ctx)
+	if err != nil || principal != principalPath {
+		t.Fatalf("principal = %q, %v", principal, err)
+	}
+	home, err := client.FindCalendarHomeSet(ctx, principal)
+	if err != nil || home != homePath {
+		t.Fatalf("home set = %q, %v", home, err)
+	}
+
+	objectPath := homePath + "work/t1.ics"
+	put, err := client.PutCalendarObject(ctx, objectPath, newVTODO("t1", "Write report", false))
+	if err != nil {
+		t.Fatalf("PUT: %v", err)
+	}
+	task, err := repo.GetByID(ctx, "t1")
+	if err != nil || task.Title != "Write report" || task.Project != "work" {
+		t.Fatalf("created task = %+v, %v", task, err)
+	}
+	if `"`+put.ETag+`"` != etagOf(task) {
+		t.Fatalf("PUT ETag = %q, want %s", put.ETag, etagOf(task))
+	}
+
+	calendars, err := client.FindCalendars(ctx, home)
+	if err != nil {
+		t.Fatalf("PROPFIND calendars: %v", err)
+	}
+	var work *gocaldav.Calendar
+	for i := range calendars {
+		if calendars[i].Path == calendarHref("work") {
+			work = &calendars[i]
+		}
+	}
+	if len(calendars) != 2 || work == nil || work.Name != "work" || len(work.SupportedComponentSet) != 1 || work.SupportedComponentSet[0] != "VTODO" {
+		t.Fatalf("calendars = %+v, want inbox and work with VTODO", calendars)
+	}
+
+	objects, err := client.QueryCalendar(ctx, work.Path, todoQuery())
+	if err != nil {
+		t.Fatalf("REPORT calendar-query: %v", err)
+	}
+	if len(objects) != 1 || objects[0].Path != objectPath {
+		t.Fatalf("query objects = %+v", objects)
+	}
+	todos := objects[0].Data.Children
+	if len(todos) != 1 || todos[0].Props.Get(ical.PropSummary).Value != "Write report" {
+		t.Fatalf("query data = %+v", todos)
+	}
+
+	if _, err := client.PutCalendarObject(ctx, objectPath, newVTODO("t1", "Write report", true)); err != nil {
+		t.Fatalf("PUT completed: %v", err)
+	}
+	got, err := client.MultiGetCalendar(ctx, work.Path, &gocaldav.CalendarMultiGet{
+		Paths:       []string{objectPath},
+		CompRequest: gocaldav.CalendarCompRequest{Name: "VCALENDAR", AllProps: true, AllComps: true},
+	})
+	if err != nil || len(got) != 1 {
+		t.Fatalf("REPORT calendar-multiget = %+v, %v", got, err)
+	}
+	if status := got[0].Data.Children[0].Props.Get(ical.PropStatus); status == nil || status.Value != "COMPLETED" {
+		t.Fatalf("status after completing = %+v", status)
+	}
+	if task, _ := repo.GetByID(ctx, "t1"); !task.IsClosed(domain.DefaultWorkflow()) {
+		t.Fatalf("task status = %q, want closed", task.Status)
+	}
+
+	if err := client.RemoveAll(ctx, objectPath); err != nil {
+		t.Fatalf("DELETE: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "t1"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("after DELETE: err = %v, want not found", err)
+	}
+	if objects, err := client.QueryCalendar(ctx, work.Path, todoQuery()); err == nil && len(objects) != 0 {
+		t.Fatalf("objects after DELETE = %+v", objects)
+	}
+}
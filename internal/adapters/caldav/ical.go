@@ -0,0 +1,343 @@
+package caldav
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const (
+	icalUTCLayout      = "20060102T150405Z"
+	icalLocalLayout    = "20060102T150405"
+	icalDateLayout     = "20060102"
+	icalMaxLineOctets  = 75
+	icalProductID      = "-//dekstop-todo-app//CalDAV//EN"
+	icalContentTypeHdr = "text/calendar; charset=utf-8"
+)
+
+var errNoVTODO = errors.New("no VTODO component")
+
+// vtodo - поля VTODO, которые мы умеем сопоставить с задачей
+type vtodo struct {
+	UID        string
+	Summary    string
+	Completed  bool
+	Priority   domain.Priority
+	Due        *time.Time
+	Categories []string
+	RelatedTo  string
+}
+
+// encodeVTODO сериализует задачу в VCALENDAR с одним VTODO (RFC 5545)
+func encodeVTODO(task *domain.Task, completed bool) string {
+	var b strings.Builder
+
+	line := func(name, value string) {
+		writeFolded(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", icalProductID)
+	line("BEGIN", "VTODO")
+	line("UID", escapeText(task.ID))
+	line("DTSTAMP", stampOf(task).UTC().Format(icalUTCLayout))
+	line("CREATED", task.CreatedAt.UTC().Format(icalUTCLayout))
+	line("LAST-MODIFIED", stampOf(task).UTC().Format(icalUTCLayout))
+	line("SUMMARY", escapeText(task.Title))
+
+	if completed {
+		line("STATUS", "COMPLETED")
+		completedAt := stampOf(task)
+		if task.CompletedAt != nil {
+			completedAt = *task.CompletedAt
+		}
+		line("COMPLETED", completedAt.UTC().Format(icalUTCLayout))
+	} else {
+		line("STATUS", "NEEDS-ACTION")
+	}
+
+	switch task.Priority {
+	case domain.PriorityHigh:
+		line("PRIORITY", "1")
+	case domain.PriorityLow:
+		line("PRIORITY", "9")
+	default:
+		line("PRIORITY", "5")
+	}
+
+	if task.DueDate != nil {
+		due := task.DueDate.Local()
+		if due.Hour() == 0 && due.Minute() == 0 && due.Second() == 0 {
+			line("DUE;VALUE=DATE", due.Format(icalDateLayout))
+		} else {
+			line("DUE", due.UTC().Format(icalUTCLayout))
+		}
+	}
+
+	if len(task.Tags) > 0 {
+		escaped := make([]string, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			escaped = append(escaped, escapeText(tag))
+		}
+		line("CATEGORIES", strings.Join(escaped, ","))
+	}
+
+	if task.ParentID != nil {
+		line("RELATED-TO", escapeText(*task.ParentID))
+	}
+
+	line("END", "VTODO")
+	line("END", "VCALENDAR")
+
+	return b.String()
+}
+
+func stampOf(task *domain.Task) time.Time {
+	if task.UpdatedAt.IsZero() {
+		return task.CreatedAt
+	}
+	return task.UpdatedAt
+}
+
+// decodeVTODO разбирает первый VTODO из VCALENDAR
+func decodeVTODO(data string) (vtodo, error) {
+	var (
+		todo    vtodo
+		inTodo  bool
+		found   bool
+		nesting int // вложенные компоненты внутри VTODO (VALARM)
+	)
+	todo.Priority = domain.PriorityMedium
+
+	for _, l := range unfoldLines(data) {
+		name, params, value, ok := parseContentLine(l)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VTODO") && !found:
+			inTodo = true
+			continue
+		case name == "END" && strings.EqualFold(value, "VTODO") && inTodo:
+			inTodo = false
+			found = true
+			continue
+		}
+
+		if !inTodo {
+			continue
+		}
+		if name == "BEGIN" {
+			nesting++
+			continue
+		}
+		if name == "END" {
+			nesting--
+			continue
+		}
+		if nesting > 0 {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			todo.UID = unescapeText(value)
+		case "SUMMARY":
+			todo.Summary = unescapeText(value)
+		case "STATUS":
+			todo.Completed = strings.EqualFold(value, "COMPLETED")
+		case "COMPLETED":
+			todo.Completed = true
+		case "PRIORITY":
+			todo.Priority = priorityFromICal(value)
+		case "DUE":
+			due, err := parseICalTime(value, params)
+			if err != nil {
+				return vtodo{}, err
+			}
+			todo.Due = &due
+		case "CATEGORIES":
+			for _, c := range splitEscaped(value, ',') {
+				if c = strings.TrimSpace(unescapeText(c)); c != "" {
+					todo.Categories = append(todo.Categories, c)
+				}
+			}
+		case "RELATED-TO":
+			// Берем только родителя (RELTYPE по умолчанию PARENT)
+			if rel := params["RELTYPE"]; rel == "" || strings.EqualFold(rel, "PARENT") {
+				todo.RelatedTo = unescapeText(value)
+			}
+		}
+	}
+
+	if !found {
+		return vtodo{}, errNoVTODO
+	}
+
+	return todo, nil
+}
+
+func priorityFromICal(value string) domain.Priority {
+	p, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return domain.PriorityMedium
+	}
+
+	switch {
+	case p >= 1 && p <= 4:
+		return domain.PriorityHigh
+	case p >= 6 && p <= 9:
+		return domain.PriorityLow
+	}
+	return domain.PriorityMedium
+}
+
+func parseICalTime(value string, params map[string]string) (time.Time, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(icalDateLayout) {
+		t, err := time.ParseInLocation(icalDateLayout, value, time.Local)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q", domain.ErrInvalidDate, value)
+		}
+		return t, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icalUTCLayout, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q", domain.ErrInvalidDate, value)
+		}
+		return t, nil
+	}
+
+	loc := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	t, err := time.ParseInLocation(icalLocalLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", domain.ErrInvalidDate, value)
+	}
+	return t, nil
+}
+
+// ------ content lines -------
+
+func unfoldLines(data string) []string {
+	var lines []string
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		l := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+
+	return lines
+}
+
+// parseContentLine разбирает "NAME;PARAM=VALUE:value"
+func parseContentLine(l string) (name string, params map[string]string, value string, ok bool) {
+	colon := -1
+	inQuotes := false
+	for i, r := range l {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(l[:colon], ";")
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if k, v, found := strings.Cut(p, "="); found {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+
+	return name, params, l[colon+1:], true
+}
+
+// writeFolded пишет строку, перенося ее каждые 75 октетов без разрыва UTF-8 символов
+func writeFolded(b *strings.Builder, l string) {
+	limit := icalMaxLineOctets
+	for len(l) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(l[cut]) {
+			cut--
+		}
+		b.WriteString(l[:cut])
+		b.WriteString("\r\n ")
+		l = l[cut:]
+		limit = icalMaxLineOctets - 1 // учитываем ведущий пробел
+	}
+	b.WriteString(l)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "")
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// splitEscaped делит строку по sep, игнорируя экранированные разделители
+func splitEscaped(s string, sep byte) []string {
+	var (
+		parts []string
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == sep {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,784 @@
+package caldav
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+const (
+	rootPath      = "/caldav/"
+	principalPath = rootPath + "principal/"
+	homePath      = rootPath + "calendars/"
+
+	// Коллекция для задач без проекта. "!" в слагах проектов всегда экранируется,
+	// поэтому проект с любым именем, включая "inbox", с ней не совпадет.
+	inboxSlug = "!inbox"
+	icsSuffix = ".ics"
+)
+
+type resourceKind int
+
+const (
+	kindRoot resourceKind = iota
+	kindPrincipal
+	kindHome
+	kindCalendar
+	kindObject
+)
+
+type resource struct {
+	kind    resourceKind
+	href    string
+	project string
+	tasks   []*domain.Task // задачи коллекции, для kindCalendar
+	task    *domain.Task   // для kindObject
+	// workflow нужен, чтобы отличать завершенные задачи от открытых
+	workflow domain.Workflow
+}
+
+// Server - встроенный CalDAV сервер: каждый проект отдается как календарь VTODO.
+// Все изменения идут через те же use cases, что и UI.
+type Server struct {
+	createTask  app.CreateTask
+	updateTask  app.UpdateTask
+	getTask     app.GetTask
+	listTasks   app.ListTasks
+	deleteTask  app.DeleteTask
+	getWorkflow app.GetWorkflow
+
+	username string
+	password string
+}
+
+func NewServer(
+	createTask app.CreateTask,
+	updateTask app.UpdateTask,
+	getTask app.GetTask,
+	listTasks app.ListTasks,
+	deleteTask app.DeleteTask,
+	getWorkflow app.GetWorkflow,
+	cfg util.CalDAVConfig,
+) *Server {
+	return &Server{
+		createTask:  createTask,
+		updateTask:  updateTask,
+		getTask:     getTask,
+		listTasks:   listTasks,
+		deleteTask:  deleteTask,
+		getWorkflow: getWorkflow,
+		username:    cfg.Username,
+		password:    cfg.Password,
+	}
+}
+
+// ListenAndServe блокируется до отмены ctx
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="todo"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("DAV", "1, 3, calendar-access")
+
+	if r.URL.Path == "/.well-known/caldav" {
+		http.Redirect(w, r, rootPath, http.StatusMovedPermanently)
+		return
+	}
+
+	var err error
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Allow", "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT")
+		w.WriteHeader(http.StatusOK)
+	case "PROPFIND":
+		err = s.handlePropfind(w, r)
+	case "REPORT":
+		err = s.handleReport(w, r)
+	case http.MethodGet, http.MethodHead:
+		err = s.handleGet(w, r)
+	case http.MethodPut:
+		err = s.handlePut(w, r)
+	case http.MethodDelete:
+		err = s.handleDelete(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+
+	if err != nil {
+		status := statusOf(err)
+		if status == http.StatusInternalServerError {
+			log.Printf("caldav: %s %s: %v", r.Method, r.URL.Path, err)
+		}
+		http.Error(w, err.Error(), status)
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.username == "" {
+		return true
+	}
+
+	user, pass, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	userOK := subtle.ConstantTimeCompare([]byte(user), []byte(s.username)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(s.password)) == 1
+	return userOK && passOK
+}
+
+type httpError struct {
+	status int
+	msg    string
+}
+
+func (e httpError) Error() string { return e.msg }
+
+func statusOf(err error) int {
+	var he httpError
+	switch {
+	case errors.As(err, &he):
+		return he.status
+	case errors.Is(err, domain.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrVersionConflict), errors.Is(err, domain.ErrTaskExists):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, domain.ErrTaskBlocked), errors.Is(err, domain.ErrInvalidTransition):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidTitle),
+		errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, domain.ErrInvalidPriority),
+		errors.Is(err, domain.ErrInvalidDate),
+		errors.Is(err, domain.ErrInvalidProject),
+		errors.Is(err, domain.ErrInvalidParent),
+		errors.Is(err, domain.ErrInvalidTag),
+		errors.Is(err, errNoVTODO):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// ------ resources -------
+
+func projectSlug(project string) string {
+	if project == "" {
+		return inboxSlug
+	}
+	return strings.ReplaceAll(url.PathEscape(project), "!", "%21")
+}
+
+func calendarHref(project string) string {
+	return homePath + projectSlug(project) + "/"
+}
+
+func objectHref(task *domain.Task) string {
+	return calendarHref(task.Project) + url.PathEscape(task.ID) + icsSuffix
+}
+
+func etagOf(task *domain.Task) string {
+	return `"` + strconv.FormatInt(task.Version, 10) + `"`
+}
+
+// ctagOf меняется при любом изменении, добавлении или удалении задачи коллекции
+func ctagOf(tasks []*domain.Task) string {
+	keys := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		keys = append(keys, task.ID+":"+strconv.FormatInt(task.Version, 10))
+	}
+	sort.Strings(keys)
+
+	h := fnv.New64a()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{';'})
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+func (s *Server) allTasks(ctx context.Context) ([]*domain.Task, error) {
+	// Отложенные тоже: иначе клиент сочтет их удаленными
+	out, err := s.listTasks.Execute(ctx, app.ListTasksInput{IncludeDeferred: true})
+	if err != nil {
+		return nil, err
+	}
+	return out.Tasks, nil
+}
+
+// groupByProject группирует задачи по проектам; inbox есть всегда
+func groupByProject(tasks []*domain.Task) map[string][]*domain.Task {
+	groups := map[string][]*domain.Task{"": nil}
+	for _, task := range tasks {
+		groups[task.Project] = append(groups[task.Project], task)
+	}
+	return groups
+}
+
+// splitPath возвращает проект и имя объекта из пути внутри homePath
+func splitPath(p string) (project string, object string, ok bool) {
+	rest := strings.TrimPrefix(p, homePath)
+	slug, object, _ := strings.Cut(rest, "/")
+
+	project, err := url.PathUnescape(slug)
+	if err != nil {
+		return "", "", false
+	}
+	if slug == inboxSlug {
+		project = ""
+	}
+
+	if object != "" {
+		if !strings.HasSuffix(object, icsSuffix) || strings.Contains(object, "/") {
+			return "", "", false
+		}
+		object, err = url.PathUnescape(strings.TrimSuffix(object, icsSuffix))
+		if err != nil {
+			return "", "", false
+		}
+	}
+
+	return project, object, true
+}
+
+func (s *Server) resolve(ctx context.Context, p string) (resource, error) {
+	if p == "/" || p == "" || p == rootPath || p == strings.TrimSuffix(rootPath, "/") {
+		return resource{kind: kindRoot, href: rootPath}, nil
+	}
+	if strings.TrimSuffix(p, "/")+"/" == principalPath {
+		return resource{kind: kindPrincipal, href: principalPath}, nil
+	}
+	if strings.TrimSuffix(p, "/")+"/" == homePath {
+		return resource{kind: kindHome, href: homePath}, nil
+	}
+	if !strings.HasPrefix(p, homePath) {
+		return resource{}, domain.ErrTaskNotFound
+	}
+
+	project, object, ok := splitPath(p)
+	if !ok {
+		return resource{}, httpError{http.StatusNotFound, "not found"}
+	}
+
+	w, err := s.getWorkflow.Execute(ctx)
+	if err != nil {
+		return resource{}, err
+	}
+
+	if object == "" {
+		tasks, err := s.allTasks(ctx)
+		if err != nil {
+			return resource{}, err
+		}
+
+		groups := groupByProject(tasks)
+		if _, exists := groups[project]; !exists {
+			return resource{}, httpError{http.StatusNotFound, "calendar not found"}
+		}
+		return resource{kind: kindCalendar, href: calendarHref(project), project: project, tasks: groups[project], workflow: w}, nil
+	}
+
+	out, err := s.getTask.Execute(ctx, app.GetTaskInput{ID: object})
+	if err != nil {
+		return resource{}, err
+	}
+	if out.Task.Project != project {
+		return resource{}, domain.ErrTaskNotFound
+	}
+
+	return resource{kind: kindObject, href: objectHref(out.Task), project: project, task: out.Task, workflow: w}, nil
+}
+
+// ------ properties -------
+
+var allProps = map[resourceKind][]xml.Name{
+	kindRoot:      {propResourceType, propCurrentPrincipal},
+	kindPrincipal: {propResourceType, propDisplayName, propCurrentPrincipal, propPrincipalURL, propCalendarHomeSet},
+	kindHome:      {propResourceType, propDisplayName, propCurrentPrincipal},
+	kindCalendar: {
+		propResourceType, propDisplayName, propCurrentPrincipal,
+		propSupportedCompSet, propSupportedReportSet, propGetCTag,
+	},
+	kindObject: {propResourceType, propGetETag, propGetContentType},
+}
+
+func (s *Server) propValue(res resource, name xml.Name) (string, bool) {
+	switch name {
+	case propResourceType:
+		switch res.kind {
+		case kindPrincipal:
+			return element(name, "<principal/><collection/>"), true
+		case kindRoot, kindHome:
+			return element(name, "<collection/>"), true
+		case kindCalendar:
+			return element(name, `<collection/><calendar xmlns="`+nsCalDAV+`"/>`), true
+		case kindObject:
+			return element(name, ""), true
+		}
+	case propDisplayName:
+		switch res.kind {
+		case kindCalendar:
+			title := res.project
+			if title == "" {
+				title = "Inbox"
+			}
+			return element(name, escape(title)), true
+		case kindPrincipal, kindHome:
+			return element(name, "Tasks"), true
+		}
+	case propCurrentPrincipal, propPrincipalURL:
+		return element(name, hrefElement(principalPath)), true
+	case propCalendarHomeSet:
+		if res.kind == kindPrincipal {
+			return element(name, hrefElement(homePath)), true
+		}
+	case propSupportedCompSet:
+		if res.kind == kindCalendar {
+			return element(name, `<comp name="VTODO"/>`), true
+		}
+	case propSupportedReportSet:
+		if res.kind == kindCalendar {
+			reports := `<supported-report><report><calendar-multiget xmlns="` + nsCalDAV + `"/></report></supported-report>` +
+				`<supported-report><report><calendar-query xmlns="` + nsCalDAV + `"/></report></supported-report>`
+			return element(name, reports), true
+		}
+	case propGetCTag:
+		if res.kind == kindCalendar {
+			return element(name, ctagOf(res.tasks)), true
+		}
+	case propGetETag:
+		if res.kind == kindObject {
+			return element(name, escape(etagOf(res.task))), true
+		}
+	case propGetContentType:
+		if res.kind == kindObject {
+			return element(name, escape(icalContentTypeHdr)), true
+		}
+	case propCalendarData:
+		if res.kind == kindObject {
+			return element(name, escape(encodeVTODO(res.task, res.task.IsClosed(res.workflow)))), true
+		}
+	}
+	return "", false
+}
+
+func (s *Server) propstatOf(res resource, names []xml.Name) propstat {
+	if names == nil {
+		names = allProps[res.kind]
+	}
+
+	var ps propstat
+	for _, name := range names {
+		if v, ok := s.propValue(res, name); ok {
+			ps.found = append(ps.found, v)
+		} else {
+			ps.notFound = append(ps.notFound, name)
+		}
+	}
+	return ps
+}
+
+// ------ handlers -------
+
+func (s *Server) handlePropfind(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	body, ok, err := readBody(r)
+	if err != nil {
+		return httpError{http.StatusBadRequest, "invalid xml body"}
+	}
+	var names []xml.Name
+	if ok {
+		names = requestedProps(body)
+	}
+
+	res, err := s.resolve(ctx, r.URL.EscapedPath())
+	if err != nil {
+		return err
+	}
+
+	ms := newMultistatus()
+	ms.addResponse(res.href, s.propstatOf(res, names))
+
+	if r.Header.Get("Depth") != "0" {
+		switch res.kind {
+		case kindRoot:
+			ms.addResponse(principalPath, s.propstatOf(resource{kind: kindPrincipal, href: principalPath}, names))
+			ms.addResponse(homePath, s.propstatOf(resource{kind: kindHome, href: homePath}, names))
+		case kindHome:
+			wf, err := s.getWorkflow.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			tasks, err := s.allTasks(ctx)
+			if err != nil {
+				return err
+			}
+
+			groups := groupByProject(tasks)
+			projects := make([]string, 0, len(groups))
+			for project := range groups {
+				projects = append(projects, project)
+			}
+			sort.Strings(projects)
+
+			for _, project := range projects {
+				cal := resource{kind: kindCalendar, href: calendarHref(project), project: project, tasks: groups[project], workflow: wf}
+				ms.addResponse(cal.href, s.propstatOf(cal, names))
+			}
+		case kindCalendar:
+			for _, task := range res.tasks {
+				obj := resource{kind: kindObject, href: objectHref(task), project: res.project, task: task, workflow: res.workflow}
+				ms.addResponse(obj.href, s.propstatOf(obj, names))
+			}
+		}
+	}
+
+	ms.write(w)
+	return nil
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	body, ok, err := readBody(r)
+	if err != nil || !ok {
+		return httpError{http.StatusBadRequest, "invalid xml body"}
+	}
+	names := requestedProps(body)
+
+	res, err := s.resolve(ctx, r.URL.EscapedPath())
+	if err != nil {
+		return err
+	}
+	if res.kind != kindCalendar {
+		return httpError{http.StatusForbidden, "report is supported only on calendar collections"}
+	}
+
+	ms := newMultistatus()
+
+	switch {
+	case body.XMLName.Space == nsCalDAV && body.XMLName.Local == "calendar-multiget":
+		byHref := make(map[string]*domain.Task, len(res.tasks))
+		for _, task := range res.tasks {
+			byHref[objectHref(task)] = task
+		}
+
+		for _, c := range body.Children {
+			if c.XMLName.Space != nsDAV || c.XMLName.Local != "href" {
+				continue
+			}
+			href := strings.TrimSpace(c.Text)
+			if u, err := url.Parse(href); err == nil {
+				href = u.EscapedPath()
+			}
+
+			task, found := byHref[href]
+			if !found {
+				ms.addStatus(href, http.StatusNotFound)
+				continue
+			}
+			obj := resource{kind: kindObject, href: href, project: res.project, task: task, workflow: res.workflow}
+			ms.addResponse(href, s.propstatOf(obj, names))
+		}
+
+	case body.XMLName.Space == nsCalDAV && body.XMLName.Local == "calendar-query":
+		filter, _ := body.child(nsCalDAV, "filter")
+		for _, task := range res.tasks {
+			if !matchFilter(filter, task, res.workflow) {
+				continue
+			}
+			obj := resource{kind: kindObject, href: objectHref(task), project: res.project, task: task, workflow: res.workflow}
+			ms.addResponse(obj.href, s.propstatOf(obj, names))
+		}
+
+	default:
+		return httpError{http.StatusForbidden, "unsupported report " + body.XMLName.Local}
+	}
+
+	ms.write(w)
+	return nil
+}
+
+// matchFilter поддерживает comp-filter VCALENDAR/VTODO, time-range по DUE
+// и prop-filter COMPLETED/STATUS, которых достаточно распространенным клиентам
+func matchFilter(filter node, task *domain.Task, w domain.Workflow) bool {
+	cal, ok := filter.child(nsCalDAV, "comp-filter")
+	if !ok {
+		return true
+	}
+	if !strings.EqualFold(cal.attr("name"), "VCALENDAR") {
+		return false
+	}
+
+	todo, ok := cal.child(nsCalDAV, "comp-filter")
+	if !ok {
+		return true
+	}
+	if !strings.EqualFold(todo.attr("name"), "VTODO") {
+		return false
+	}
+
+	for _, c := range todo.Children {
+		if c.XMLName.Space != nsCalDAV {
+			continue
+		}
+
+		switch c.XMLName.Local {
+		case "time-range":
+			if task.DueDate == nil {
+				continue
+			}
+			if start, err := time.Parse(icalUTCLayout, c.attr("start")); err == nil && task.DueDate.Before(start) {
+				return false
+			}
+			if end, err := time.Parse(icalUTCLayout, c.attr("end")); err == nil && !task.DueDate.Before(end) {
+				return false
+			}
+
+		case "prop-filter":
+			completed := task.IsClosed(w)
+			_, notDefined := c.child(nsCalDAV, "is-not-defined")
+
+			switch strings.ToUpper(c.attr("name")) {
+			case "COMPLETED":
+				if notDefined == completed {
+					return false
+				}
+			case "STATUS":
+				match, ok := c.child(nsCalDAV, "text-match")
+				if !ok {
+					continue
+				}
+				equals := strings.EqualFold(strings.TrimSpace(match.Text), "COMPLETED") == completed
+				if strings.EqualFold(match.attr("negate-condition"), "yes") {
+					equals = !equals
+				}
+				if !equals {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) error {
+	res, err := s.resolve(r.Context(), r.URL.EscapedPath())
+	if err != nil {
+		return err
+	}
+	if res.kind != kindObject {
+		return httpError{http.StatusMethodNotAllowed, "not a calendar object"}
+	}
+
+	data := encodeVTODO(res.task, res.task.IsClosed(res.workflow))
+	w.Header().Set("Content-Type", icalContentTypeHdr)
+	w.Header().Set("ETag", etagOf(res.task))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		io.WriteString(w, data)
+	}
+	return nil
+}
+
+func (s *Server) handlePut(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	p := r.URL.EscapedPath()
+	if !strings.HasPrefix(p, homePath) {
+		return httpError{http.StatusForbidden, "forbidden"}
+	}
+	project, id, ok := splitPath(p)
+	if !ok || id == "" {
+		return httpError{http.StatusForbidden, "only calendar objects can be stored"}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	todo, err := decodeVTODO(string(data))
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.getTask.Execute(ctx, app.GetTaskInput{ID: id})
+	switch {
+	case err == nil:
+		if r.Header.Get("If-None-Match") == "*" {
+			return domain.ErrTaskExists
+		}
+		if err := s.updateFromVTODO(ctx, existing.Task, project, todo, r.Header.Get("If-Match")); err != nil {
+			return err
+		}
+	case errors.Is(err, domain.ErrTaskNotFound):
+		if m := r.Header.Get("If-Match"); m != "" {
+			return domain.ErrVersionConflict
+		}
+		if err := s.createFromVTODO(ctx, id, project, todo); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	saved, err := s.getTask.Execute(ctx, app.GetTaskInput{ID: id})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", etagOf(saved.Task))
+	if existing.Task == nil {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	return nil
+}
+
+func (s *Server) createFromVTODO(ctx context.Context, id, project string, todo vtodo) error {
+	in := app.CreateTaskInput{
+		ID:       id,
+		Title:    todo.Summary,
+		Priority: string(todo.Priority),
+		DueDate:  todo.Due,
+		Project:  project,
+		Tags:     todo.Categories,
+		// Завершенный VTODO создается и закрывается одной транзакцией
+		Completed: todo.Completed,
+	}
+	if todo.RelatedTo != "" {
+		// Неизвестного родителя игнорируем: клиент мог еще не загрузить его
+		if _, err := s.getTask.Execute(ctx, app.GetTaskInput{ID: todo.RelatedTo}); err == nil {
+			in.ParentID = &todo.RelatedTo
+		}
+	}
+
+	_, err := s.createTask.Execute(ctx, in)
+	return err
+}
+
+func (s *Server) updateFromVTODO(ctx context.Context, task *domain.Task, project string, todo vtodo, ifMatch string) error {
+	w, err := s.getWorkflow.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Пользовательский статус сохраняется, пока клиент не сменил завершенность
+	status := string(task.Status)
+	switch {
+	case todo.Completed && !task.IsClosed(w):
+		status = string(w.DoneStatus())
+	case !todo.Completed && task.IsClosed(w):
+		status = string(w.InitialStatus())
+	}
+	priority := string(todo.Priority)
+	tags := todo.Categories
+	if tags == nil {
+		tags = []string{}
+	}
+
+	in := app.UpdateTaskInput{
+		ID:           task.ID,
+		Title:        &todo.Summary,
+		Status:       &status,
+		Priority:     &priority,
+		DueDate:      todo.Due,
+		ClearDueDate: todo.Due == nil,
+		Project:      &project,
+		Tags:         tags,
+	}
+
+	// Пустая строка отвязывает подзадачу; неизвестного родителя не трогаем
+	parentID := todo.RelatedTo
+	if parentID == "" {
+		in.ParentID = &parentID
+	} else if _, err := s.getTask.Execute(ctx, app.GetTaskInput{ID: parentID}); err == nil {
+		in.ParentID = &parentID
+	}
+
+	if ifMatch != "" && ifMatch != "*" {
+		version, err := parseETag(ifMatch)
+		if err != nil {
+			return domain.ErrVersionConflict
+		}
+		in.ExpectedVersion = &version
+	}
+
+	return s.updateTask.Execute(ctx, in)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) error {
+	res, err := s.resolve(r.Context(), r.URL.EscapedPath())
+	if err != nil {
+		return err
+	}
+	if res.kind != kindObject {
+		return httpError{http.StatusForbidden, "only calendar objects can be deleted"}
+	}
+
+	in := app.DeleteTaskInput{ID: res.task.ID}
+	if m := r.Header.Get("If-Match"); m != "" && m != "*" {
+		version, err := parseETag(m)
+		if err != nil {
+			return domain.ErrVersionConflict
+		}
+		in.ExpectedVersion = &version
+	}
+
+	if err := s.deleteTask.Execute(r.Context(), in); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func parseETag(v string) (int64, error) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+	v = strings.Trim(v, `"`)
+	version, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid etag %q", v)
+	}
+	return version, nil
+}
@@ -0,0 +1,157 @@
+package caldav
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+func newTestServer(repo *testutil.Repo) *Server {
+	events := testutil.NopPublisher{}
+	return NewServer(
+		app.NewCreateTask(repo, events),
+		app.NewUpdateTask(repo, events),
+		app.NewGetTask(repo),
+		app.NewListTasks(repo),
+		app.NewDeleteTask(repo, events),
+		app.NewGetWorkflow(repo),
+		util.CalDAVConfig{},
+	)
+}
+
+func vcalendar(uid, summary string, completed bool) string {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "BEGIN:VTODO", "UID:" + uid, "SUMMARY:" + summary}
+	if completed {
+		lines = append(lines, "STATUS:COMPLETED", "COMPLETED:20260101T100000Z")
+	}
+	lines = append(lines, "END:VTODO", "END:VCALENDAR")
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func do(t *testing.T, s *Server, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPutCompletedTodoCreatesClosedTask(t *testing.T) {
+	repo := testutil.NewRepo()
+	s := newTestServer(repo)
+
+	rec := do(t, s, http.MethodPut, homePath+"work/t1.ics", vcalendar("t1", "Report", true), nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("PUT status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	task, err := repo.GetByID(context.Background(), "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := domain.DefaultWorkflow()
+	if !task.IsClosed(w) || task.CompletedAt == nil {
+		t.Fatalf("task status = %q, completed_at = %v; want closed", task.Status, task.CompletedAt)
+	}
+	if task.Project != "work" {
+		t.Fatalf("project = %q, want work", task.Project)
+	}
+
+	rec = do(t, s, http.MethodGet, homePath+"work/t1.ics", "", nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "STATUS:COMPLETED") {
+		t.Fatalf("GET status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if etag := rec.Header().Get("ETag"); etag != etagOf(task) {
+		t.Fatalf("ETag = %s, want %s", etag, etagOf(task))
+	}
+}
+
+func TestPropfindChildrenUseWorkflow(t *testing.T) {
+	repo := testutil.NewRepo()
+	s := newTestServer(repo)
+
+	do(t, s, http.MethodPut, homePath+"work/open.ics", vcalendar("open", "Open", false), nil)
+	do(t, s, http.MethodPut, homePath+"work/done.ics", vcalendar("done", "Done", true), nil)
+
+	body := `<?xml version="1.0"?><propfind xmlns="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">` +
+		`<prop><getetag/><C:calendar-data/></prop></propfind>`
+	rec := do(t, s, "PROPFIND", homePath+"work/", body, map[string]string{"Depth": "1"})
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("PROPFIND status = %d", rec.Code)
+	}
+
+	out := rec.Body.String()
+	if got := strings.Count(out, "STATUS:COMPLETED"); got != 1 {
+		t.Fatalf("completed objects in PROPFIND = %d, want 1:\n%s", got, out)
+	}
+	if got := strings.Count(out, "STATUS:NEEDS-ACTION"); got != 1 {
+		t.Fatalf("open objects in PROPFIND = %d, want 1:\n%s", got, out)
+	}
+}
+
+func TestInboxAndProjectNamedInboxAreDistinct(t *testing.T) {
+	repo := testutil.NewRepo()
+	s := newTestServer(repo)
+
+	if rec := do(t, s, http.MethodPut, calendarHref("")+"a.ics", vcalendar("a", "No project", false), nil); rec.Code != http.StatusCreated {
+		t.Fatalf("PUT inbox status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(t, s, http.MethodPut, calendarHref("inbox")+"b.ics", vcalendar("b", "Inbox project", false), nil); rec.Code != http.StatusCreated {
+		t.Fatalf("PUT project status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	a, _ := repo.GetByID(context.Background(), "a")
+	b, _ := repo.GetByID(context.Background(), "b")
+	if a.Project != "" || b.Project != "inbox" {
+		t.Fatalf("projects = %q, %q; want empty and inbox", a.Project, b.Project)
+	}
+
+	if rec := do(t, s, http.MethodGet, calendarHref("inbox")+"b.ics", "", nil); rec.Code != http.StatusOK {
+		t.Fatalf("GET project object status = %d", rec.Code)
+	}
+	if rec := do(t, s, http.MethodGet, calendarHref("")+"b.ics", "", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("GET project object from inbox status = %d, want 404", rec.Code)
+	}
+}
+
+func TestSplitPathRoundTrip(t *testing.T) {
+	for _, project := range []string{"", "inbox", "!inbox", "a b/c", "Работа"} {
+		got, object, ok := splitPath(calendarHref(project) + "x.ics")
+		if !ok || got != project || object != "x" {
+			t.Errorf("splitPath(%q) = %q, %q, %v", calendarHref(project), got, object, ok)
+		}
+	}
+}
+
+func TestPutIfMatchConflict(t *testing.T) {
+	repo := testutil.NewRepo()
+	s := newTestServer(repo)
+
+	do(t, s, http.MethodPut, homePath+"work/t1.ics", vcalendar("t1", "v1", false), nil)
+
+	rec := do(t, s, http.MethodPut, homePath+"work/t1.ics", vcalendar("t1", "v2", false), map[string]string{"If-Match": `"999"`})
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("PUT with stale If-Match status = %d, want 412", rec.Code)
+	}
+
+	task, _ := repo.GetByID(context.Background(), "t1")
+	rec = do(t, s, http.MethodPut, homePath+"work/t1.ics", vcalendar("t1", "v2", false), map[string]string{"If-Match": etagOf(task)})
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT with current If-Match status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	rec = do(t, s, http.MethodDelete, homePath+"work/t1.ics", "", map[string]string{"If-Match": etagOf(task)})
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("DELETE with stale If-Match status = %d, want 412", rec.Code)
+	}
+}
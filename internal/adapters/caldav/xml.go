@@ -0,0 +1,173 @@
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsCS     = "http://calendarserver.org/ns/"
+)
+
+var (
+	propResourceType       = xml.Name{Space: nsDAV, Local: "resourcetype"}
+	propDisplayName        = xml.Name{Space: nsDAV, Local: "displayname"}
+	propCurrentPrincipal   = xml.Name{Space: nsDAV, Local: "current-user-principal"}
+	propPrincipalURL       = xml.Name{Space: nsDAV, Local: "principal-URL"}
+	propGetETag            = xml.Name{Space: nsDAV, Local: "getetag"}
+	propGetContentType     = xml.Name{Space: nsDAV, Local: "getcontenttype"}
+	propSupportedReportSet = xml.Name{Space: nsDAV, Local: "supported-report-set"}
+	propCalendarHomeSet    = xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}
+	propSupportedCompSet   = xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}
+	propCalendarData       = xml.Name{Space: nsCalDAV, Local: "calendar-data"}
+	propGetCTag            = xml.Name{Space: nsCS, Local: "getctag"}
+)
+
+// node - минимальное DOM-представление XML тела запроса
+type node struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []node     `xml:",any"`
+	Text     string     `xml:",chardata"`
+}
+
+func (n node) child(space, local string) (node, bool) {
+	for _, c := range n.Children {
+		if c.XMLName.Space == space && c.XMLName.Local == local {
+			return c, true
+		}
+	}
+	return node{}, false
+}
+
+func (n node) attr(local string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// readBody разбирает XML тело; пустое тело возвращает ok=false без ошибки
+func readBody(r *http.Request) (node, bool, error) {
+	data, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		return node{}, false, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return node{}, false, nil
+	}
+
+	var root node
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return node{}, false, err
+	}
+	return root, true, nil
+}
+
+// requestedProps возвращает имена свойств из <prop>; nil означает allprop
+func requestedProps(root node) []xml.Name {
+	prop, ok := root.child(nsDAV, "prop")
+	if !ok {
+		return nil
+	}
+
+	names := make([]xml.Name, 0, len(prop.Children))
+	for _, c := range prop.Children {
+		names = append(names, c.XMLName)
+	}
+	return names
+}
+
+// ------ multistatus -------
+
+type propstat struct {
+	found    []string // готовые XML-элементы свойств
+	notFound []xml.Name
+}
+
+type multistatus struct {
+	buf bytes.Buffer
+}
+
+func newMultistatus() *multistatus {
+	ms := &multistatus{}
+	ms.buf.WriteString(xml.Header)
+	ms.buf.WriteString(`<multistatus xmlns="DAV:">`)
+	return ms
+}
+
+func (ms *multistatus) addResponse(href string, ps propstat) {
+	ms.buf.WriteString("<response><href>")
+	xml.EscapeText(&ms.buf, []byte(href))
+	ms.buf.WriteString("</href>")
+
+	if len(ps.found) > 0 {
+		ms.buf.WriteString("<propstat><prop>")
+		for _, p := range ps.found {
+			ms.buf.WriteString(p)
+		}
+		ms.buf.WriteString("</prop><status>HTTP/1.1 200 OK</status></propstat>")
+	}
+
+	if len(ps.notFound) > 0 {
+		ms.buf.WriteString("<propstat><prop>")
+		for _, name := range ps.notFound {
+			ms.buf.WriteString(emptyElement(name))
+		}
+		ms.buf.WriteString("</prop><status>HTTP/1.1 404 Not Found</status></propstat>")
+	}
+
+	ms.buf.WriteString("</response>")
+}
+
+func (ms *multistatus) addStatus(href string, status int) {
+	ms.buf.WriteString("<response><href>")
+	xml.EscapeText(&ms.buf, []byte(href))
+	ms.buf.WriteString("</href><status>HTTP/1.1 ")
+	ms.buf.WriteString(strconv.Itoa(status) + " " + http.StatusText(status))
+	ms.buf.WriteString("</status></response>")
+}
+
+func (ms *multistatus) write(w http.ResponseWriter) {
+	ms.buf.WriteString("</multistatus>")
+	w.Header().Set("Content-Type", `application/xml; charset="utf-8"`)
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write(ms.buf.Bytes())
+}
+
+// element строит <local xmlns="space">inner</local>; inner уже экранирован
+func element(name xml.Name, inner string) string {
+	var b strings.Builder
+	b.WriteString("<" + name.Local)
+	if name.Space != nsDAV {
+		b.WriteString(` xmlns="` + name.Space + `"`)
+	}
+	b.WriteString(">" + inner + "</" + name.Local + ">")
+	return b.String()
+}
+
+func emptyElement(name xml.Name) string {
+	if name.Space == nsDAV || name.Space == "" {
+		return "<" + name.Local + "/>"
+	}
+	return "<" + name.Local + ` xmlns="` + name.Space + `"/>`
+}
+
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// hrefElement явно указывает DAV:, потому что href бывает вложен в свойства CalDAV
+func hrefElement(href string) string {
+	return `<href xmlns="DAV:">` + escape(href) + "</href>"
+}
@@ -0,0 +1,92 @@
+package syncapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/syncclient"
+)
+
+// Ограничение тела запроса: полная выгрузка большой базы при первой синхронизации
+const maxRequestBody = 64 << 20
+
+// Server - сервер синхронизации: хранит общую копию задач и раздает изменения клиентам
+type Server struct {
+	serveSync app.ServeSync
+	token     string
+
+	// Обмены выполняются по одному, чтобы номера изменений выдавались в порядке коммитов
+	mu sync.Mutex
+}
+
+func NewServer(serveSync app.ServeSync, token string) *Server {
+	return &Server{serveSync: serveSync, token: token}
+}
+
+// ListenAndServe блокируется до отмены ctx
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle(syncclient.ExchangePath, s)
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req app.SyncRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody)).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	resp, err := s.serveSync.Execute(r.Context(), req)
+	s.mu.Unlock()
+	if err != nil {
+		log.Printf("sync: exchange with %s: %v", req.Node, err)
+		http.Error(w, "sync failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) == 1
+}
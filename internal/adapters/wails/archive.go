@@ -0,0 +1,27 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// ArchiveHandler - архив давно закрытых задач
+type ArchiveHandler struct {
+	listArchive  app.ListArchive
+	archiveTasks app.ArchiveTasks
+}
+
+func NewArchiveHandler(listArchive app.ListArchive, archiveTasks app.ArchiveTasks) *ArchiveHandler {
+	return &ArchiveHandler{listArchive: listArchive, archiveTasks: archiveTasks}
+}
+
+// ListArchive ищет по названию, проекту и тегам; страницы с 1
+func (h *ArchiveHandler) ListArchive(in app.ListArchiveInput) (app.ListArchiveOutput, error) {
+	return h.listArchive.Execute(context.Background(), in)
+}
+
+// ArchiveNow запускает автоархив сразу и возвращает число заархивированных задач
+func (h *ArchiveHandler) ArchiveNow() (int, error) {
+	return h.archiveTasks.Execute(context.Background())
+}
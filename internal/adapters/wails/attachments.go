@@ -0,0 +1,65 @@
+package wails
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// AttachmentHandler - файлы, прикрепленные к задачам
+type AttachmentHandler struct {
+	addAttachment     app.AddAttachment
+	listAttachments   app.ListAttachments
+	getAttachmentPath app.GetAttachmentPath
+	deleteAttachment  app.DeleteAttachment
+}
+
+func NewAttachmentHandler(
+	addAttachment app.AddAttachment,
+	listAttachments app.ListAttachments,
+	getAttachmentPath app.GetAttachmentPath,
+	deleteAttachment app.DeleteAttachment,
+) *AttachmentHandler {
+	return &AttachmentHandler{
+		addAttachment:     addAttachment,
+		listAttachments:   listAttachments,
+		getAttachmentPath: getAttachmentPath,
+		deleteAttachment:  deleteAttachment,
+	}
+}
+
+// AddAttachmentFromFile прикрепляет файл с диска, например выбранный в диалоге
+func (h *AttachmentHandler) AddAttachmentFromFile(taskID, path string) (*domain.Attachment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return h.addAttachment.Execute(context.Background(), app.AddAttachmentInput{TaskID: taskID, Name: path, Content: f})
+}
+
+// AddAttachment прикрепляет содержимое из фронтенда, например вставленный скриншот
+func (h *AttachmentHandler) AddAttachment(taskID, name string, data []byte) (*domain.Attachment, error) {
+	return h.addAttachment.Execute(context.Background(), app.AddAttachmentInput{
+		TaskID:  taskID,
+		Name:    name,
+		Content: bytes.NewReader(data),
+	})
+}
+
+func (h *AttachmentHandler) ListAttachments(taskID string) ([]*domain.Attachment, error) {
+	return h.listAttachments.Execute(context.Background(), taskID)
+}
+
+// GetAttachmentPath - путь к файлу с исходным именем для открытия в системе
+func (h *AttachmentHandler) GetAttachmentPath(id string) (string, error) {
+	return h.getAttachmentPath.Execute(context.Background(), id)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(id string) error {
+	return h.deleteAttachment.Execute(context.Background(), id)
+}
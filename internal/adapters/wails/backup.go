@@ -0,0 +1,65 @@
+package wails
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+type backupWriter interface {
+	BackupNow(ctx context.Context) (string, error)
+}
+
+// BackupHandler - экспорт/импорт JSON-снапшота всей базы
+type BackupHandler struct {
+	exportBackup app.ExportBackup
+	importBackup app.ImportBackup
+	writer       backupWriter
+}
+
+func NewBackupHandler(
+	exportBackup app.ExportBackup,
+	importBackup app.ImportBackup,
+	writer backupWriter,
+) *BackupHandler {
+	return &BackupHandler{
+		exportBackup: exportBackup,
+		importBackup: importBackup,
+		writer:       writer,
+	}
+}
+
+// ExportBackup возвращает снапшот в виде JSON-строки, фронтенд сам сохраняет файл
+func (h *BackupHandler) ExportBackup() (string, error) {
+	snapshot, err := h.exportBackup.Execute(context.Background())
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal snapshot: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// ImportBackup принимает JSON снапшота и режим "merge" или "replace"
+func (h *BackupHandler) ImportBackup(data, mode string) (app.ImportBackupOutput, error) {
+	var snapshot app.Snapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		return app.ImportBackupOutput{}, fmt.Errorf("parse snapshot: %w", err)
+	}
+
+	return h.importBackup.Execute(context.Background(), app.ImportBackupInput{
+		Snapshot: snapshot,
+		Mode:     app.ImportMode(mode),
+	})
+}
+
+// BackupNow сразу пишет бэкап в настроенную директорию
+func (h *BackupHandler) BackupNow() (string, error) {
+	return h.writer.BackupNow(context.Background())
+}
@@ -0,0 +1,32 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// BoardHandler - канбан-доска: колонки, перенос карточек и WIP-лимиты
+type BoardHandler struct {
+	getBoard    app.GetBoard
+	moveCard    app.MoveCard
+	setWIPLimit app.SetWIPLimit
+}
+
+func NewBoardHandler(getBoard app.GetBoard, moveCard app.MoveCard, setWIPLimit app.SetWIPLimit) *BoardHandler {
+	return &BoardHandler{getBoard: getBoard, moveCard: moveCard, setWIPLimit: setWIPLimit}
+}
+
+// GetBoard группирует по "status", "priority" или "due"; project - необязательный фильтр
+func (h *BoardHandler) GetBoard(in app.GetBoardInput) (app.GetBoardOutput, error) {
+	return h.getBoard.Execute(context.Background(), in)
+}
+
+// MoveCard переносит карточку; перенос сверх WIP-лимита колонки отклоняется
+func (h *BoardHandler) MoveCard(in app.MoveCardInput) error {
+	return h.moveCard.Execute(context.Background(), in)
+}
+
+func (h *BoardHandler) SetWIPLimit(in app.SetWIPLimitInput) error {
+	return h.setWIPLimit.Execute(context.Background(), in)
+}
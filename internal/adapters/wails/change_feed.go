@@ -0,0 +1,18 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventTaskChanged - событие фронтенду об изменении задачи в базе
+const EventTaskChanged = "task:changed"
+
+// ChangeFeed пересылает пачки изменений задач во фронтенд как события Wails
+func ChangeFeed(ctx context.Context) func([]domain.TaskChange) {
+	return func(changes []domain.TaskChange) {
+		runtime.EventsEmit(ctx, EventTaskChanged, changes)
+	}
+}
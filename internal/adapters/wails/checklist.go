@@ -0,0 +1,62 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// ChecklistHandler - чек-листы внутри задач
+type ChecklistHandler struct {
+	addItem    app.AddChecklistItem
+	renameItem app.RenameChecklistItem
+	checkItem  app.CheckChecklistItem
+	moveItem   app.MoveChecklistItem
+	deleteItem app.DeleteChecklistItem
+	list       app.ListChecklist
+}
+
+func NewChecklistHandler(
+	addItem app.AddChecklistItem,
+	renameItem app.RenameChecklistItem,
+	checkItem app.CheckChecklistItem,
+	moveItem app.MoveChecklistItem,
+	deleteItem app.DeleteChecklistItem,
+	list app.ListChecklist,
+) *ChecklistHandler {
+	return &ChecklistHandler{
+		addItem:    addItem,
+		renameItem: renameItem,
+		checkItem:  checkItem,
+		moveItem:   moveItem,
+		deleteItem: deleteItem,
+		list:       list,
+	}
+}
+
+func (h *ChecklistHandler) AddChecklistItem(taskID, title string) (*domain.ChecklistItem, error) {
+	return h.addItem.Execute(context.Background(), app.AddChecklistItemInput{TaskID: taskID, Title: title})
+}
+
+func (h *ChecklistHandler) RenameChecklistItem(id, title string) (*domain.ChecklistItem, error) {
+	return h.renameItem.Execute(context.Background(), app.RenameChecklistItemInput{ID: id, Title: title})
+}
+
+// CheckChecklistItem при SuggestComplete фронтенд предлагает завершить задачу
+func (h *ChecklistHandler) CheckChecklistItem(id string, checked bool) (app.CheckChecklistItemOutput, error) {
+	return h.checkItem.Execute(context.Background(), app.CheckChecklistItemInput{ID: id, Checked: checked})
+}
+
+// MoveChecklistItem ставит пункт перед beforeID, пустой - в конец; возвращает новый порядок
+func (h *ChecklistHandler) MoveChecklistItem(id, beforeID string) ([]*domain.ChecklistItem, error) {
+	return h.moveItem.Execute(context.Background(), app.MoveChecklistItemInput{ID: id, BeforeID: beforeID})
+}
+
+func (h *ChecklistHandler) DeleteChecklistItem(id string) error {
+	return h.deleteItem.Execute(context.Background(), id)
+}
+
+func (h *ChecklistHandler) ListChecklist(taskID string) ([]*domain.ChecklistItem, error) {
+	return h.list.Execute(context.Background(), taskID)
+}
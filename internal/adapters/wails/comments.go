@@ -0,0 +1,43 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// CommentHandler - комментарии и лента активности задачи
+type CommentHandler struct {
+	add      app.AddComment
+	edit     app.EditComment
+	remove   app.DeleteComment
+	activity app.GetTaskActivity
+}
+
+func NewCommentHandler(
+	add app.AddComment,
+	edit app.EditComment,
+	remove app.DeleteComment,
+	activity app.GetTaskActivity,
+) *CommentHandler {
+	return &CommentHandler{add: add, edit: edit, remove: remove, activity: activity}
+}
+
+// AddComment - body в Markdown, рендерит фронтенд
+func (h *CommentHandler) AddComment(taskID, body string) (*domain.Comment, error) {
+	return h.add.Execute(context.Background(), app.AddCommentInput{TaskID: taskID, Body: body})
+}
+
+func (h *CommentHandler) EditComment(id, body string) (*domain.Comment, error) {
+	return h.edit.Execute(context.Background(), app.EditCommentInput{ID: id, Body: body})
+}
+
+func (h *CommentHandler) DeleteComment(id string) error {
+	return h.remove.Execute(context.Background(), id)
+}
+
+// GetTaskActivity - комментарии и изменения задачи одной лентой, новые первыми
+func (h *CommentHandler) GetTaskActivity(in app.GetTaskActivityInput) (app.GetTaskActivityOutput, error) {
+	return h.activity.Execute(context.Background(), in)
+}
@@ -0,0 +1,68 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// CustomFieldHandler - пользовательские поля задач: описания, значения и выборка по ним
+type CustomFieldHandler struct {
+	listFields  app.ListCustomFields
+	saveField   app.SaveCustomField
+	deleteField app.DeleteCustomField
+	createTask  app.CreateTask
+	updateTask  app.UpdateTask
+	listTasks   app.ListTasks
+}
+
+func NewCustomFieldHandler(
+	listFields app.ListCustomFields,
+	saveField app.SaveCustomField,
+	deleteField app.DeleteCustomField,
+	createTask app.CreateTask,
+	updateTask app.UpdateTask,
+	listTasks app.ListTasks,
+) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		listFields:  listFields,
+		saveField:   saveField,
+		deleteField: deleteField,
+		createTask:  createTask,
+		updateTask:  updateTask,
+		listTasks:   listTasks,
+	}
+}
+
+// ListCustomFields - поля проекта; nil - все поля
+func (h *CustomFieldHandler) ListCustomFields(project *string) ([]domain.CustomField, error) {
+	return h.listFields.Execute(context.Background(), app.ListCustomFieldsInput{Project: project})
+}
+
+func (h *CustomFieldHandler) SaveCustomField(field domain.CustomField) (domain.CustomField, error) {
+	return h.saveField.Execute(context.Background(), field)
+}
+
+// DeleteCustomField удаляет поле и его значения у всех задач
+func (h *CustomFieldHandler) DeleteCustomField(key string) (app.DeleteCustomFieldOutput, error) {
+	return h.deleteField.Execute(context.Background(), key)
+}
+
+// CreateTaskWithFields - создание из полной формы задачи, с проверкой обязательных полей
+func (h *CustomFieldHandler) CreateTaskWithFields(in app.CreateTaskInput) (app.CreateTaskOutput, error) {
+	return h.createTask.Execute(context.Background(), in)
+}
+
+// SetTaskFields меняет значения полей задачи; null в значении очищает поле
+func (h *CustomFieldHandler) SetTaskFields(taskID string, values map[string]any) error {
+	if values == nil {
+		values = map[string]any{}
+	}
+	return h.updateTask.Execute(context.Background(), app.UpdateTaskInput{ID: taskID, CustomFields: values})
+}
+
+// ListTasksByFields - ListTasks с фильтрами и сортировкой по пользовательским полям
+func (h *CustomFieldHandler) ListTasksByFields(in app.ListTasksInput) (app.ListTasksOutput, error) {
+	return h.listTasks.Execute(context.Background(), in)
+}
@@ -0,0 +1,45 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// DependencyHandler - связи blocked_by между задачами
+type DependencyHandler struct {
+	addDependency      app.AddDependency
+	removeDependency   app.RemoveDependency
+	getDependencyGraph app.GetDependencyGraph
+}
+
+func NewDependencyHandler(
+	addDependency app.AddDependency,
+	removeDependency app.RemoveDependency,
+	getDependencyGraph app.GetDependencyGraph,
+) *DependencyHandler {
+	return &DependencyHandler{
+		addDependency:      addDependency,
+		removeDependency:   removeDependency,
+		getDependencyGraph: getDependencyGraph,
+	}
+}
+
+// AddDependency: taskID нельзя завершить, пока не завершена blockedByID
+func (h *DependencyHandler) AddDependency(taskID, blockedByID string) error {
+	return h.addDependency.Execute(context.Background(), app.DependencyInput{
+		TaskID:      taskID,
+		BlockedByID: blockedByID,
+	})
+}
+
+func (h *DependencyHandler) RemoveDependency(taskID, blockedByID string) error {
+	return h.removeDependency.Execute(context.Background(), app.DependencyInput{
+		TaskID:      taskID,
+		BlockedByID: blockedByID,
+	})
+}
+
+func (h *DependencyHandler) GetDependencyGraph() (app.GetDependencyGraphOutput, error) {
+	return h.getDependencyGraph.Execute(context.Background())
+}
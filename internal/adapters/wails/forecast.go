@@ -0,0 +1,30 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// ForecastHandler - оценки задач и прогноз загрузки по дням
+type ForecastHandler struct {
+	updateTask  app.UpdateTask
+	getForecast app.GetForecast
+}
+
+func NewForecastHandler(updateTask app.UpdateTask, getForecast app.GetForecast) *ForecastHandler {
+	return &ForecastHandler{updateTask: updateTask, getForecast: getForecast}
+}
+
+// SetTaskEstimate задает оценку в минутах и/или очках; 0 снимает оценку
+func (h *ForecastHandler) SetTaskEstimate(id string, minutes, points int) error {
+	return h.updateTask.Execute(context.Background(), app.UpdateTaskInput{
+		ID:              id,
+		EstimateMinutes: &minutes,
+		EstimatePoints:  &points,
+	})
+}
+
+func (h *ForecastHandler) GetForecast() (app.GetForecastOutput, error) {
+	return h.getForecast.Execute(context.Background())
+}
@@ -0,0 +1,61 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// ImportExportHandler - обмен задачами с внешними форматами
+type ImportExportHandler struct {
+	exportCSV      app.ExportCSV
+	importCSV      app.ImportCSV
+	exportMarkdown app.ExportMarkdown
+	importMarkdown app.ImportMarkdown
+	importTW       app.ImportTaskwarrior
+	importTodoist  app.ImportTodoist
+}
+
+func NewImportExportHandler(
+	exportCSV app.ExportCSV,
+	importCSV app.ImportCSV,
+	exportMarkdown app.ExportMarkdown,
+	importMarkdown app.ImportMarkdown,
+	importTW app.ImportTaskwarrior,
+	importTodoist app.ImportTodoist,
+) *ImportExportHandler {
+	return &ImportExportHandler{
+		exportCSV:      exportCSV,
+		importCSV:      importCSV,
+		exportMarkdown: exportMarkdown,
+		importMarkdown: importMarkdown,
+		importTW:       importTW,
+		importTodoist:  importTodoist,
+	}
+}
+
+func (h *ImportExportHandler) ExportCSV(in app.ExportCSVInput) (app.ExportCSVOutput, error) {
+	return h.exportCSV.Execute(context.Background(), in)
+}
+
+// ImportCSV с DryRun=true только валидирует строки и возвращает превью
+func (h *ImportExportHandler) ImportCSV(in app.ImportCSVInput) (app.ImportCSVOutput, error) {
+	return h.importCSV.Execute(context.Background(), in)
+}
+
+func (h *ImportExportHandler) ExportMarkdown(in app.ExportMarkdownInput) (app.ExportMarkdownOutput, error) {
+	return h.exportMarkdown.Execute(context.Background(), in)
+}
+
+func (h *ImportExportHandler) ImportMarkdown(in app.ImportMarkdownInput) (app.ImportMarkdownOutput, error) {
+	return h.importMarkdown.Execute(context.Background(), in)
+}
+
+// ImportTaskwarrior принимает вывод `task export`
+func (h *ImportExportHandler) ImportTaskwarrior(in app.ImportTaskwarriorInput) (app.ImportReport, error) {
+	return h.importTW.Execute(context.Background(), in)
+}
+
+func (h *ImportExportHandler) ImportTodoist(in app.ImportTodoistInput) (app.ImportReport, error) {
+	return h.importTodoist.Execute(context.Background(), in)
+}
@@ -0,0 +1,32 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// OutboxHandler - просмотр очереди исходящих событий и повтор dead-letter
+type OutboxHandler struct {
+	listOutbox app.ListOutbox
+	retryEntry app.RetryOutboxEntry
+}
+
+func NewOutboxHandler(listOutbox app.ListOutbox, retryEntry app.RetryOutboxEntry) *OutboxHandler {
+	return &OutboxHandler{
+		listOutbox: listOutbox,
+		retryEntry: retryEntry,
+	}
+}
+
+// ListOutbox возвращает записи со статусом pending, delivered или dead (по умолчанию)
+func (h *OutboxHandler) ListOutbox(status string, limit int) ([]app.OutboxEntryOutput, error) {
+	return h.listOutbox.Execute(context.Background(), app.ListOutboxInput{
+		Status: status,
+		Limit:  limit,
+	})
+}
+
+func (h *OutboxHandler) RetryOutboxEntry(id int64) error {
+	return h.retryEntry.Execute(context.Background(), id)
+}
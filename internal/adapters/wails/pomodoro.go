@@ -0,0 +1,51 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventPomodoroPhase - смена фазы помидора, в том числе запуск и остановка
+const EventPomodoroPhase = "pomodoro:phase"
+
+type pomodoroRunner interface {
+	Start(ctx context.Context, taskID string) (app.PomodoroState, error)
+	Skip(ctx context.Context) (app.PomodoroState, error)
+	Stop(ctx context.Context) (app.PomodoroState, error)
+	State() app.PomodoroState
+}
+
+// PomodoroHandler - управление циклом помидоров
+type PomodoroHandler struct {
+	runner pomodoroRunner
+}
+
+func NewPomodoroHandler(runner pomodoroRunner) *PomodoroHandler {
+	return &PomodoroHandler{runner: runner}
+}
+
+func (h *PomodoroHandler) StartPomodoro(taskID string) (app.PomodoroState, error) {
+	return h.runner.Start(context.Background(), taskID)
+}
+
+// SkipPomodoroPhase досрочно переходит к следующей фазе
+func (h *PomodoroHandler) SkipPomodoroPhase() (app.PomodoroState, error) {
+	return h.runner.Skip(context.Background())
+}
+
+func (h *PomodoroHandler) StopPomodoro() (app.PomodoroState, error) {
+	return h.runner.Stop(context.Background())
+}
+
+func (h *PomodoroHandler) GetPomodoroState() app.PomodoroState {
+	return h.runner.State()
+}
+
+// PomodoroFeed пересылает смены фаз во фронтенд как события Wails
+func PomodoroFeed(ctx context.Context) func(app.PomodoroState) {
+	return func(state app.PomodoroState) {
+		runtime.EventsEmit(ctx, EventPomodoroPhase, state)
+	}
+}
@@ -0,0 +1,31 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+type reviewWriter interface {
+	WriteNow(ctx context.Context) ([]string, error)
+}
+
+// ReviewHandler - еженедельный обзор в Markdown и HTML
+type ReviewHandler struct {
+	generateReview app.GenerateReview
+	writer         reviewWriter
+}
+
+func NewReviewHandler(generateReview app.GenerateReview, writer reviewWriter) *ReviewHandler {
+	return &ReviewHandler{generateReview: generateReview, writer: writer}
+}
+
+// GenerateReview строит обзор недели, в которую попадает date; пустая date - текущая неделя
+func (h *ReviewHandler) GenerateReview(in app.GenerateReviewInput) (app.GenerateReviewOutput, error) {
+	return h.generateReview.Execute(context.Background(), in)
+}
+
+// WriteReviewNow пишет обзор текущей недели в директорию из настроек и возвращает пути файлов
+func (h *ReviewHandler) WriteReviewNow() ([]string, error) {
+	return h.writer.WriteNow(context.Background())
+}
@@ -0,0 +1,42 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// EventTaskWoke - у отложенной задачи наступила дата начала
+const EventTaskWoke = "task:woke"
+
+// SnoozeHandler - отложенные задачи
+type SnoozeHandler struct {
+	snoozeTask app.SnoozeTask
+	updateTask app.UpdateTask
+}
+
+func NewSnoozeHandler(snoozeTask app.SnoozeTask, updateTask app.UpdateTask) *SnoozeHandler {
+	return &SnoozeHandler{snoozeTask: snoozeTask, updateTask: updateTask}
+}
+
+// SnoozeTask откладывает задачу до времени или по варианту "tomorrow"/"next_week"
+func (h *SnoozeHandler) SnoozeTask(in app.SnoozeTaskInput) (app.SnoozeTaskOutput, error) {
+	return h.snoozeTask.Execute(context.Background(), in)
+}
+
+// UnsnoozeTask снимает отсрочку, задача сразу возвращается в список
+func (h *SnoozeHandler) UnsnoozeTask(id string) error {
+	return h.updateTask.Execute(context.Background(), app.UpdateTaskInput{ID: id, ClearStartDate: true})
+}
+
+// WakeFeed пересылает task.woke во фронтенд как события Wails
+func WakeFeed(ctx context.Context) func(context.Context, domain.Event) error {
+	return func(_ context.Context, event domain.Event) error {
+		if woke, ok := event.(domain.TaskWoke); ok {
+			runtime.EventsEmit(ctx, EventTaskWoke, woke.Task)
+		}
+		return nil
+	}
+}
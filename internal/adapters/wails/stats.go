@@ -0,0 +1,27 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// StatsHandler - статистика завершенных задач и данные для burndown/burnup
+type StatsHandler struct {
+	getStats    app.GetStats
+	getBurndown app.GetBurndown
+}
+
+func NewStatsHandler(getStats app.GetStats, getBurndown app.GetBurndown) *StatsHandler {
+	return &StatsHandler{getStats: getStats, getBurndown: getBurndown}
+}
+
+// GetStats за период [from, to); пустые границы - последние 30 дней
+func (h *StatsHandler) GetStats(in app.GetStatsInput) (app.GetStatsOutput, error) {
+	return h.getStats.Execute(context.Background(), in)
+}
+
+// GetBurndown - ряды открытого и закрытого объема по дням с фильтром по проекту или тегу
+func (h *StatsHandler) GetBurndown(in app.GetBurndownInput) (app.GetBurndownOutput, error) {
+	return h.getBurndown.Execute(context.Background(), in)
+}
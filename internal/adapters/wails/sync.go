@@ -0,0 +1,20 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// SyncHandler - ручной запуск синхронизации с сервером
+type SyncHandler struct {
+	syncNow app.SyncNow
+}
+
+func NewSyncHandler(syncNow app.SyncNow) *SyncHandler {
+	return &SyncHandler{syncNow: syncNow}
+}
+
+func (h *SyncHandler) SyncNow() (app.SyncOutput, error) {
+	return h.syncNow.Execute(context.Background())
+}
@@ -0,0 +1,85 @@
+package wails
+
+import (
+	"context"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// TimeTrackingHandler - таймеры и записи времени по задачам
+type TimeTrackingHandler struct {
+	startTimer    app.StartTimer
+	stopTimer     app.StopTimer
+	getRunning    app.GetRunningTimer
+	addEntry      app.AddTimeEntry
+	updateEntry   app.UpdateTimeEntry
+	deleteEntry   app.DeleteTimeEntry
+	listEntries   app.ListTimeEntries
+	getTimeReport app.GetTimeReport
+}
+
+func NewTimeTrackingHandler(
+	startTimer app.StartTimer,
+	stopTimer app.StopTimer,
+	getRunning app.GetRunningTimer,
+	addEntry app.AddTimeEntry,
+	updateEntry app.UpdateTimeEntry,
+	deleteEntry app.DeleteTimeEntry,
+	listEntries app.ListTimeEntries,
+	getTimeReport app.GetTimeReport,
+) *TimeTrackingHandler {
+	return &TimeTrackingHandler{
+		startTimer:    startTimer,
+		stopTimer:     stopTimer,
+		getRunning:    getRunning,
+		addEntry:      addEntry,
+		updateEntry:   updateEntry,
+		deleteEntry:   deleteEntry,
+		listEntries:   listEntries,
+		getTimeReport: getTimeReport,
+	}
+}
+
+func (h *TimeTrackingHandler) StartTimer(taskID, note string) (app.TimeEntryOutput, error) {
+	return h.startTimer.Execute(context.Background(), app.StartTimerInput{TaskID: taskID, Note: note})
+}
+
+func (h *TimeTrackingHandler) StopTimer() (app.TimeEntryOutput, error) {
+	return h.stopTimer.Execute(context.Background())
+}
+
+// GetRunningTimer возвращает null, если таймер не запущен
+func (h *TimeTrackingHandler) GetRunningTimer() (*app.TimeEntryOutput, error) {
+	return h.getRunning.Execute(context.Background())
+}
+
+func (h *TimeTrackingHandler) AddTimeEntry(taskID string, startedAt, endedAt time.Time, note string) (app.TimeEntryOutput, error) {
+	return h.addEntry.Execute(context.Background(), app.AddTimeEntryInput{
+		TaskID:    taskID,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Note:      note,
+	})
+}
+
+func (h *TimeTrackingHandler) UpdateTimeEntry(id string, startedAt, endedAt *time.Time, note *string) (app.TimeEntryOutput, error) {
+	return h.updateEntry.Execute(context.Background(), app.UpdateTimeEntryInput{
+		ID:        id,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Note:      note,
+	})
+}
+
+func (h *TimeTrackingHandler) DeleteTimeEntry(id string) error {
+	return h.deleteEntry.Execute(context.Background(), id)
+}
+
+func (h *TimeTrackingHandler) ListTimeEntries(taskID string) ([]app.TimeEntryOutput, error) {
+	return h.listEntries.Execute(context.Background(), taskID)
+}
+
+func (h *TimeTrackingHandler) GetTimeReport(from, to time.Time) (app.GetTimeReportOutput, error) {
+	return h.getTimeReport.Execute(context.Background(), app.GetTimeReportInput{From: from, To: to})
+}
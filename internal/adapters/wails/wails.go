@@ -2,8 +2,10 @@ package wails
 
 import (
 	"context"
+	"errors"
 	"time"
 	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
 )
 
 // TaskHandler - адаптер для Wails frontend binding
@@ -57,8 +59,25 @@ func (h *TaskHandler) UpdateTask(id string, title, status, priority *string, due
 	})
 }
 
-func (h *TaskHandler) CompleteTask(id string) error {
-	return h.completeTask.Execute(context.Background(), app.CompleteTaskInput{ID: id})
+// CompleteTaskResult - Blocked: задача не закрыта из-за открытых Blockers,
+// фронтенд спрашивает подтверждение и вызывает ForceCompleteTask
+type CompleteTaskResult struct {
+	Blocked  bool           `json:"blocked"`
+	Blockers []*domain.Task `json:"blockers,omitempty"`
+}
+
+func (h *TaskHandler) CompleteTask(id string) (CompleteTaskResult, error) {
+	err := h.completeTask.Execute(context.Background(), app.CompleteTaskInput{ID: id})
+	var blocked *app.BlockedError
+	if errors.As(err, &blocked) {
+		return CompleteTaskResult{Blocked: true, Blockers: blocked.Blockers}, nil
+	}
+	return CompleteTaskResult{}, err
+}
+
+// ForceCompleteTask завершает задачу, несмотря на открытые блокеры
+func (h *TaskHandler) ForceCompleteTask(id string) error {
+	return h.completeTask.Execute(context.Background(), app.CompleteTaskInput{ID: id, Force: true})
 }
 
 func (h *TaskHandler) GetTask(id string) (app.GetTaskOutput, error) {
@@ -0,0 +1,61 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// WebhookHandler - управление исходящими вебхуками и журнал доставок
+type WebhookHandler struct {
+	saveWebhook     app.SaveWebhook
+	deleteWebhook   app.DeleteWebhook
+	listWebhooks    app.ListWebhooks
+	listDeliveries  app.ListWebhookDeliveries
+	sendTestWebhook app.SendTestWebhook
+}
+
+func NewWebhookHandler(
+	saveWebhook app.SaveWebhook,
+	deleteWebhook app.DeleteWebhook,
+	listWebhooks app.ListWebhooks,
+	listDeliveries app.ListWebhookDeliveries,
+	sendTestWebhook app.SendTestWebhook,
+) *WebhookHandler {
+	return &WebhookHandler{
+		saveWebhook:     saveWebhook,
+		deleteWebhook:   deleteWebhook,
+		listWebhooks:    listWebhooks,
+		listDeliveries:  listDeliveries,
+		sendTestWebhook: sendTestWebhook,
+	}
+}
+
+// WebhookEventTypes - список событий для выбора в форме подписки
+func (h *WebhookHandler) WebhookEventTypes() []string {
+	return domain.WebhookEventTypes
+}
+
+func (h *WebhookHandler) SaveWebhook(in app.SaveWebhookInput) (app.WebhookOutput, error) {
+	return h.saveWebhook.Execute(context.Background(), in)
+}
+
+func (h *WebhookHandler) DeleteWebhook(id string) error {
+	return h.deleteWebhook.Execute(context.Background(), id)
+}
+
+func (h *WebhookHandler) ListWebhooks() ([]app.WebhookOutput, error) {
+	return h.listWebhooks.Execute(context.Background())
+}
+
+func (h *WebhookHandler) ListWebhookDeliveries(webhookID string, limit int) ([]app.WebhookDeliveryOutput, error) {
+	return h.listDeliveries.Execute(context.Background(), app.ListWebhookDeliveriesInput{
+		WebhookID: webhookID,
+		Limit:     limit,
+	})
+}
+
+func (h *WebhookHandler) SendTestWebhook(webhookID string) (app.WebhookDeliveryOutput, error) {
+	return h.sendTestWebhook.Execute(context.Background(), webhookID)
+}
@@ -0,0 +1,27 @@
+package wails
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// WorkflowHandler - настройка статусов задач и переходов между ними
+type WorkflowHandler struct {
+	getWorkflow  app.GetWorkflow
+	saveWorkflow app.SaveWorkflow
+}
+
+func NewWorkflowHandler(getWorkflow app.GetWorkflow, saveWorkflow app.SaveWorkflow) *WorkflowHandler {
+	return &WorkflowHandler{getWorkflow: getWorkflow, saveWorkflow: saveWorkflow}
+}
+
+func (h *WorkflowHandler) GetWorkflow() (domain.Workflow, error) {
+	return h.getWorkflow.Execute(context.Background())
+}
+
+// SaveWorkflow заменяет статусы и переходы; remap задает, куда перенести задачи удаляемых статусов
+func (h *WorkflowHandler) SaveWorkflow(in app.SaveWorkflowInput) (app.SaveWorkflowOutput, error) {
+	return h.saveWorkflow.Execute(context.Background(), in)
+}
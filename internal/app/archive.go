@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const (
+	defaultArchivePageSize = 50
+	maxArchivePageSize     = 500
+)
+
+// ArchiveTasks убирает в архив задачи, закрытые больше afterDays дней назад
+type ArchiveTasks struct {
+	repo      domain.TaskRepository
+	afterDays int // 0 - автоархив выключен
+}
+
+func NewArchiveTasks(repo domain.TaskRepository, afterDays int) ArchiveTasks {
+	return ArchiveTasks{repo: repo, afterDays: afterDays}
+}
+
+func (uc ArchiveTasks) Enabled() bool {
+	return uc.afterDays > 0
+}
+
+// Execute возвращает число заархивированных задач
+func (uc ArchiveTasks) Execute(ctx context.Context) (int, error) {
+	if !uc.Enabled() {
+		return 0, nil
+	}
+
+	now := time.Now()
+	n, err := uc.repo.Archive().Archive(ctx, now.AddDate(0, 0, -uc.afterDays), now)
+	if err != nil {
+		return 0, fmt.Errorf("archive tasks: %w", err)
+	}
+	return n, nil
+}
+
+// ListArchive - поиск по архиву с постраничным выводом, новые закрытые первыми
+type ListArchive struct {
+	repo domain.TaskRepository
+}
+
+func NewListArchive(repo domain.TaskRepository) ListArchive {
+	return ListArchive{repo: repo}
+}
+
+type ListArchiveInput struct {
+	Query    string  `json:"query,omitempty"` // подстрока названия, проекта или тега
+	Project  *string `json:"project,omitempty"`
+	Page     int     `json:"page"`      // с 1
+	PageSize int     `json:"page_size"` // по умолчанию 50
+}
+
+type ListArchiveOutput struct {
+	Tasks    []*domain.Task `json:"tasks"`
+	Total    int            `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+func (uc ListArchive) Execute(ctx context.Context, in ListArchiveInput) (ListArchiveOutput, error) {
+	page := max(in.Page, 1)
+	size := in.PageSize
+	if size <= 0 {
+		size = defaultArchivePageSize
+	}
+	size = min(size, maxArchivePageSize)
+
+	filter := domain.ArchiveFilter{
+		Query:   in.Query,
+		Project: in.Project,
+		Limit:   size,
+		Offset:  (page - 1) * size,
+	}
+
+	tasks, err := uc.repo.Archive().List(ctx, filter)
+	if err != nil {
+		return ListArchiveOutput{}, fmt.Errorf("list archive: %w", err)
+	}
+	total, err := uc.repo.Archive().Count(ctx, filter)
+	if err != nil {
+		return ListArchiveOutput{}, fmt.Errorf("count archive: %w", err)
+	}
+
+	return ListArchiveOutput{Tasks: tasks, Total: total, Page: page, PageSize: size}, nil
+}
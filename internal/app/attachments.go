@@ -0,0 +1,193 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// blobGCGrace - blob моложе этого не удаляется: его могли только что записать
+// для вложения, транзакция которого еще не закоммичена
+const blobGCGrace = time.Hour
+
+// BlobStore - локальное хранилище содержимого вложений с адресацией по SHA-256
+type BlobStore interface {
+	// Put сохраняет содержимое и определяет тип по сигнатуре;
+	// сверх лимита размера возвращает domain.ErrAttachmentTooLarge
+	Put(ctx context.Context, r io.Reader) (Blob, error)
+	// OpenPath - путь к копии blob'а с исходным именем, чтобы система открыла его нужной программой
+	OpenPath(hash, name string) (string, error)
+	List(ctx context.Context) ([]Blob, error)
+	Delete(hash string) error
+}
+
+type Blob struct {
+	Hash     string
+	Size     int64
+	MimeType string // заполняется только в Put
+	ModTime  time.Time
+}
+
+// AddAttachment сохраняет файл в хранилище и прикрепляет его к задаче
+type AddAttachment struct {
+	repo  domain.TaskRepository
+	blobs BlobStore
+}
+
+func NewAddAttachment(repo domain.TaskRepository, blobs BlobStore) AddAttachment {
+	return AddAttachment{repo: repo, blobs: blobs}
+}
+
+type AddAttachmentInput struct {
+	TaskID  string
+	Name    string
+	Content io.Reader
+}
+
+func (uc AddAttachment) Execute(ctx context.Context, in AddAttachmentInput) (*domain.Attachment, error) {
+	name := domain.CleanAttachmentName(in.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: empty name", domain.ErrInvalidAttachment)
+	}
+	// Проверяем задачу до записи, чтобы не копить blob'ы для несуществующих задач
+	if _, err := uc.repo.GetByID(ctx, in.TaskID); err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+
+	blob, err := uc.blobs.Put(ctx, in.Content)
+	if err != nil {
+		return nil, fmt.Errorf("store blob: %w", err)
+	}
+
+	attachment, err := domain.NewAttachment(in.TaskID, name, attachmentMimeType(blob.MimeType, name), blob.Size, blob.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Если транзакция не пройдет, blob без ссылок уберет CollectBlobs
+	err = uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if _, err := repo.GetByID(ctx, in.TaskID); err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+		if err := repo.Attachments().Save(ctx, attachment); err != nil {
+			return fmt.Errorf("save attachment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// attachmentMimeType доверяет сигнатуре; общий тип уточняется по расширению имени
+func attachmentMimeType(sniffed, name string) string {
+	if sniffed != "" && sniffed != "application/octet-stream" && !strings.HasPrefix(sniffed, "text/plain") {
+		return sniffed
+	}
+	if byExt := mime.TypeByExtension(strings.ToLower(filepath.Ext(name))); byExt != "" {
+		return byExt
+	}
+	if sniffed == "" {
+		return "application/octet-stream"
+	}
+	return sniffed
+}
+
+type ListAttachments struct {
+	repo domain.TaskRepository
+}
+
+func NewListAttachments(repo domain.TaskRepository) ListAttachments {
+	return ListAttachments{repo: repo}
+}
+
+func (uc ListAttachments) Execute(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	attachments, err := uc.repo.Attachments().ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// GetAttachmentPath - локальный путь файла вложения для открытия в системе
+type GetAttachmentPath struct {
+	repo  domain.TaskRepository
+	blobs BlobStore
+}
+
+func NewGetAttachmentPath(repo domain.TaskRepository, blobs BlobStore) GetAttachmentPath {
+	return GetAttachmentPath{repo: repo, blobs: blobs}
+}
+
+func (uc GetAttachmentPath) Execute(ctx context.Context, id string) (string, error) {
+	attachment, err := uc.repo.Attachments().GetByID(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("get attachment: %w", err)
+	}
+
+	path, err := uc.blobs.OpenPath(attachment.Hash, attachment.Name)
+	if err != nil {
+		return "", fmt.Errorf("open blob: %w", err)
+	}
+	return path, nil
+}
+
+// DeleteAttachment удаляет только ссылку: blob может быть общим, его убирает CollectBlobs
+type DeleteAttachment struct {
+	repo domain.TaskRepository
+}
+
+func NewDeleteAttachment(repo domain.TaskRepository) DeleteAttachment {
+	return DeleteAttachment{repo: repo}
+}
+
+func (uc DeleteAttachment) Execute(ctx context.Context, id string) error {
+	if err := uc.repo.Attachments().Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+	return nil
+}
+
+// CollectBlobs удаляет blob'ы, на которые не ссылается ни одно вложение
+type CollectBlobs struct {
+	repo  domain.TaskRepository
+	blobs BlobStore
+}
+
+func NewCollectBlobs(repo domain.TaskRepository, blobs BlobStore) CollectBlobs {
+	return CollectBlobs{repo: repo, blobs: blobs}
+}
+
+// Execute возвращает число удаленных blob'ов
+func (uc CollectBlobs) Execute(ctx context.Context) (int, error) {
+	// Список blob'ов берем до ссылок: blob, записанный после чтения ссылок, в него не попадет
+	blobs, err := uc.blobs.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list blobs: %w", err)
+	}
+	referenced, err := uc.repo.Attachments().ReferencedHashes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get referenced hashes: %w", err)
+	}
+
+	cutoff := time.Now().Add(-blobGCGrace)
+	removed := 0
+	for _, blob := range blobs {
+		if referenced[blob.Hash] || blob.ModTime.After(cutoff) {
+			continue
+		}
+		if err := uc.blobs.Delete(blob.Hash); err != nil {
+			return removed, fmt.Errorf("delete blob %s: %w", blob.Hash, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,347 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type BoardColumn struct {
+	Key       string         `json:"key"`
+	Name      string         `json:"name"`
+	Category  string         `json:"category,omitempty"` // только для колонок-статусов
+	WIPLimit  int            `json:"wip_limit"`          // 0 - без лимита
+	Count     int            `json:"count"`              // карточек всех проектов, с ним сравнивается лимит
+	OverLimit bool           `json:"over_limit"`
+	Cards     []*domain.Task `json:"cards"`
+}
+
+// GetBoard раскладывает задачи по колонкам канбан-доски. При группировке
+// по приоритету и сроку показываются только открытые задачи.
+type GetBoard struct {
+	repo domain.TaskRepository
+}
+
+func NewGetBoard(repo domain.TaskRepository) GetBoard {
+	return GetBoard{repo: repo}
+}
+
+type GetBoardInput struct {
+	GroupBy string  `json:"group_by"` // "status", "priority" или "due"
+	Project *string `json:"project,omitempty"`
+}
+
+type GetBoardOutput struct {
+	GroupBy string        `json:"group_by"`
+	Columns []BoardColumn `json:"columns"`
+}
+
+func (uc GetBoard) Execute(ctx context.Context, in GetBoardInput) (GetBoardOutput, error) {
+	grouping := domain.BoardGrouping(in.GroupBy)
+	if grouping == "" {
+		grouping = domain.BoardByStatus
+	}
+	if err := grouping.IsValid(); err != nil {
+		return GetBoardOutput{}, err
+	}
+
+	columns, err := loadBoard(ctx, uc.repo, grouping, time.Now())
+	if err != nil {
+		return GetBoardOutput{}, err
+	}
+
+	if in.Project != nil {
+		for i := range columns {
+			cards := make([]*domain.Task, 0, len(columns[i].Cards))
+			for _, task := range columns[i].Cards {
+				if task.Project == *in.Project {
+					cards = append(cards, task)
+				}
+			}
+			columns[i].Cards = cards
+		}
+	}
+
+	return GetBoardOutput{GroupBy: string(grouping), Columns: columns}, nil
+}
+
+// MoveCard переносит карточку в колонку и на место перед BeforeID одной транзакцией:
+// меняет статус, приоритет или срок задачи и порядок карточек колонки.
+type MoveCard struct {
+	repo   domain.TaskRepository
+	events domain.EventPublisher
+}
+
+func NewMoveCard(repo domain.TaskRepository, events domain.EventPublisher) MoveCard {
+	return MoveCard{repo: repo, events: events}
+}
+
+type MoveCardInput struct {
+	TaskID  string `json:"task_id"`
+	GroupBy string `json:"group_by"`
+	Column  string `json:"column"`
+	// BeforeID - карточка, перед которой встать; пусто - в конец колонки.
+	// Так порядок не зависит от фильтра по проекту на доске.
+	BeforeID string `json:"before_id,omitempty"`
+}
+
+func (uc MoveCard) Execute(ctx context.Context, in MoveCardInput) error {
+	grouping := domain.BoardGrouping(in.GroupBy)
+	if err := grouping.IsValid(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var events []domain.Event
+
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if err := repo.Board().Lock(ctx); err != nil {
+			return fmt.Errorf("lock board: %w", err)
+		}
+
+		task, err := repo.GetByID(ctx, in.TaskID)
+		if err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+		w, err := loadWorkflow(ctx, repo)
+		if err != nil {
+			return err
+		}
+		columns, err := loadBoard(ctx, repo, grouping, now)
+		if err != nil {
+			return err
+		}
+
+		target := findColumn(columns, in.Column)
+		if target == nil {
+			return fmt.Errorf("%w: %s", domain.ErrInvalidColumn, in.Column)
+		}
+
+		if boardColumnOf(task, grouping, now) != in.Column {
+			if target.WIPLimit > 0 && target.Count >= target.WIPLimit {
+				return fmt.Errorf("%w: %s allows %d cards", domain.ErrWIPLimitExceeded, target.Name, target.WIPLimit)
+			}
+
+			statusBefore := task.Status
+			if err := applyColumn(ctx, repo, w, task, grouping, in.Column, now); err != nil {
+				return err
+			}
+			if err := w.ValidateTask(task); err != nil {
+				return fmt.Errorf("validate task: %w", err)
+			}
+			if err := repo.Save(ctx, task); err != nil {
+				return fmt.Errorf("save task: %w", err)
+			}
+
+			events = append([]domain.Event{domain.TaskUpdated{Task: task.Snapshot(), At: task.UpdatedAt}},
+				domain.StatusEvents(w, statusBefore, task, task.UpdatedAt)...)
+			if err := recordEvents(ctx, repo, events...); err != nil {
+				return err
+			}
+		}
+
+		order := make([]string, 0, len(target.Cards)+1)
+		placed := false
+		for _, card := range target.Cards {
+			if card.ID == task.ID {
+				continue
+			}
+			if card.ID == in.BeforeID {
+				order = append(order, task.ID)
+				placed = true
+			}
+			order = append(order, card.ID)
+		}
+		if !placed {
+			order = append(order, task.ID)
+		}
+
+		if err := repo.Board().SetPositions(ctx, grouping, order); err != nil {
+			return fmt.Errorf("save positions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.events.Publish(ctx, events...)
+	return nil
+}
+
+// applyColumn меняет поле задачи, по которому сгруппирована доска
+func applyColumn(ctx context.Context, repo domain.TaskRepository, w domain.Workflow, task *domain.Task, grouping domain.BoardGrouping, column string, now time.Time) error {
+	switch grouping {
+	case domain.BoardByStatus:
+		status := domain.TaskStatus(column)
+		if err := w.CanTransition(task.Status, status); err != nil {
+			return err
+		}
+		// В закрытую колонку - как CompleteTask: только без открытых блокеров
+		if w.IsClosed(status) && !task.IsClosed(w) {
+			blockers, err := openBlockers(ctx, repo, task.ID, w)
+			if err != nil {
+				return err
+			}
+			if len(blockers) > 0 {
+				return blockedError(blockers)
+			}
+		}
+		task.Status = status
+		task.MarkCompletion(w, now)
+
+	case domain.BoardByPriority:
+		task.Priority = domain.Priority(column)
+
+	case domain.BoardByDue:
+		due, err := domain.DueForBucket(column, now)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, column)
+		}
+		task.DueDate = due
+	}
+	return nil
+}
+
+// SetWIPLimit задает лимит карточек колонки; 0 снимает лимит
+type SetWIPLimit struct {
+	repo domain.TaskRepository
+}
+
+func NewSetWIPLimit(repo domain.TaskRepository) SetWIPLimit {
+	return SetWIPLimit{repo: repo}
+}
+
+type SetWIPLimitInput struct {
+	GroupBy string `json:"group_by"`
+	Column  string `json:"column"`
+	Limit   int    `json:"limit"`
+}
+
+func (uc SetWIPLimit) Execute(ctx context.Context, in SetWIPLimitInput) error {
+	grouping := domain.BoardGrouping(in.GroupBy)
+	if err := grouping.IsValid(); err != nil {
+		return err
+	}
+
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return err
+	}
+	if !hasColumn(boardColumns(grouping, w), in.Column) {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidColumn, in.Column)
+	}
+
+	if err := uc.repo.Board().SetWIPLimit(ctx, grouping, in.Column, in.Limit); err != nil {
+		return fmt.Errorf("set wip limit: %w", err)
+	}
+	return nil
+}
+
+// loadBoard строит все колонки группировки с карточками всех проектов
+func loadBoard(ctx context.Context, repo domain.TaskRepository, grouping domain.BoardGrouping, now time.Time) ([]BoardColumn, error) {
+	w, err := loadWorkflow(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get tasks: %w", err)
+	}
+	positions, err := repo.Board().Positions(ctx, grouping)
+	if err != nil {
+		return nil, fmt.Errorf("get positions: %w", err)
+	}
+	limits, err := repo.Board().WIPLimits(ctx, grouping)
+	if err != nil {
+		return nil, fmt.Errorf("get wip limits: %w", err)
+	}
+
+	columns := boardColumns(grouping, w)
+	index := make(map[string]int, len(columns))
+	for i := range columns {
+		index[columns[i].Key] = i
+		columns[i].WIPLimit = limits[columns[i].Key]
+	}
+
+	for _, task := range tasks {
+		if grouping != domain.BoardByStatus && task.IsClosed(w) {
+			continue
+		}
+		if i, ok := index[boardColumnOf(task, grouping, now)]; ok {
+			columns[i].Cards = append(columns[i].Cards, task)
+		}
+	}
+
+	// Сначала карточки с сохраненной позицией, новые - в конце по дате создания
+	for i := range columns {
+		cards := columns[i].Cards
+		sort.SliceStable(cards, func(a, b int) bool {
+			pa, okA := positions[cards[a].ID]
+			pb, okB := positions[cards[b].ID]
+			if okA != okB {
+				return okA
+			}
+			if okA && pa != pb {
+				return pa < pb
+			}
+			return cards[a].CreatedAt.Before(cards[b].CreatedAt)
+		})
+		columns[i].Count = len(cards)
+		columns[i].OverLimit = columns[i].WIPLimit > 0 && columns[i].Count > columns[i].WIPLimit
+	}
+	return columns, nil
+}
+
+func boardColumns(grouping domain.BoardGrouping, w domain.Workflow) []BoardColumn {
+	var columns []BoardColumn
+	switch grouping {
+	case domain.BoardByStatus:
+		for _, s := range w.Sorted() {
+			columns = append(columns, BoardColumn{Key: string(s.Key), Name: s.Name, Category: string(s.Category)})
+		}
+	case domain.BoardByPriority:
+		for _, p := range priorityOrder {
+			columns = append(columns, BoardColumn{Key: string(p), Name: priorityHeading(p)})
+		}
+	case domain.BoardByDue:
+		names := map[string]string{
+			domain.DueOverdue: "Overdue", domain.DueToday: "Today", domain.DueThisWeek: "This week",
+			domain.DueLater: "Later", domain.DueNone: "No date",
+		}
+		for _, bucket := range domain.DueBuckets {
+			columns = append(columns, BoardColumn{Key: bucket, Name: names[bucket]})
+		}
+	}
+
+	for i := range columns {
+		columns[i].Cards = make([]*domain.Task, 0)
+	}
+	return columns
+}
+
+func boardColumnOf(task *domain.Task, grouping domain.BoardGrouping, now time.Time) string {
+	switch grouping {
+	case domain.BoardByPriority:
+		return string(task.Priority)
+	case domain.BoardByDue:
+		return domain.DueBucket(task, now)
+	}
+	return string(task.Status)
+}
+
+func findColumn(columns []BoardColumn, key string) *BoardColumn {
+	for i := range columns {
+		if columns[i].Key == key {
+			return &columns[i]
+		}
+	}
+	return nil
+}
+
+func hasColumn(columns []BoardColumn, key string) bool {
+	return findColumn(columns, key) != nil
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func boardRepo(tasks ...*domain.Task) *testutil.Repo {
+	repo := testutil.NewRepo()
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	for i, task := range tasks {
+		if task.Status == "" {
+			task.Status = domain.StatusActive
+		}
+		if task.Priority == "" {
+			task.Priority = domain.PriorityMedium
+		}
+		task.Title = task.ID
+		task.CreatedAt = created.Add(time.Duration(i) * time.Minute)
+		repo.Put(task)
+	}
+	return repo
+}
+
+func columnCards(t *testing.T, repo *testutil.Repo, groupBy, column string) []string {
+	t.Helper()
+	out, err := NewGetBoard(repo).Execute(context.Background(), GetBoardInput{GroupBy: groupBy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range out.Columns {
+		if c.Key == column {
+			ids := make([]string, 0, len(c.Cards))
+			for _, card := range c.Cards {
+				ids = append(ids, card.ID)
+			}
+			return ids
+		}
+	}
+	t.Fatalf("no column %s", column)
+	return nil
+}
+
+func TestMoveCardRefusesOverWIPLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := boardRepo(&domain.Task{ID: "a", Priority: domain.PriorityHigh}, &domain.Task{ID: "b"}, &domain.Task{ID: "c", Priority: domain.PriorityHigh})
+	move := NewMoveCard(repo, testutil.NopPublisher{})
+	group := string(domain.BoardByPriority)
+
+	if err := NewSetWIPLimit(repo).Execute(ctx, SetWIPLimitInput{GroupBy: group, Column: string(domain.PriorityHigh), Limit: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := move.Execute(ctx, MoveCardInput{TaskID: "b", GroupBy: group, Column: string(domain.PriorityHigh)})
+	if !errors.Is(err, domain.ErrWIPLimitExceeded) {
+		t.Fatalf("move into full column: err = %v", err)
+	}
+	if b, _ := repo.GetByID(ctx, "b"); b.Priority != domain.PriorityMedium {
+		t.Fatalf("refused move changed priority to %s", b.Priority)
+	}
+
+	// Перестановка внутри полной колонки лимит не нарушает
+	if err := move.Execute(ctx, MoveCardInput{TaskID: "c", GroupBy: group, Column: string(domain.PriorityHigh), BeforeID: "a"}); err != nil {
+		t.Fatalf("reorder in full column: %v", err)
+	}
+	if got := columnCards(t, repo, group, string(domain.PriorityHigh)); !slices.Equal(got, []string{"c", "a"}) {
+		t.Fatalf("high = %v, want [c a]", got)
+	}
+
+	// Снятый лимит пропускает карточку
+	if err := NewSetWIPLimit(repo).Execute(ctx, SetWIPLimitInput{GroupBy: group, Column: string(domain.PriorityHigh)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := move.Execute(ctx, MoveCardInput{TaskID: "b", GroupBy: group, Column: string(domain.PriorityHigh)}); err != nil {
+		t.Fatalf("move without limit: %v", err)
+	}
+	if b, _ := repo.GetByID(ctx, "b"); b.Priority != domain.PriorityHigh {
+		t.Fatalf("priority = %s, want high", b.Priority)
+	}
+}
+
+func TestMoveCardRefusesClosingBlockedTask(t *testing.T) {
+	ctx := context.Background()
+	repo := boardRepo(&domain.Task{ID: "task"}, &domain.Task{ID: "blocker"})
+	repo.Deps = []domain.Dependency{{TaskID: "task", BlockedByID: "blocker"}}
+	move := NewMoveCard(repo, testutil.NopPublisher{})
+	group := string(domain.BoardByStatus)
+
+	err := move.Execute(ctx, MoveCardInput{TaskID: "task", GroupBy: group, Column: string(domain.StatusCompleted)})
+	if !errors.Is(err, domain.ErrTaskBlocked) {
+		t.Fatalf("close blocked task: err = %v", err)
+	}
+	if task, _ := repo.GetByID(ctx, "task"); task.Status != domain.StatusActive || task.CompletedAt != nil {
+		t.Fatalf("refused move changed the task: %+v", task)
+	}
+
+	if err := move.Execute(ctx, MoveCardInput{TaskID: "blocker", GroupBy: group, Column: string(domain.StatusCompleted)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := move.Execute(ctx, MoveCardInput{TaskID: "task", GroupBy: group, Column: string(domain.StatusCompleted)}); err != nil {
+		t.Fatalf("close after blocker is done: %v", err)
+	}
+	if task, _ := repo.GetByID(ctx, "task"); task.Status != domain.StatusCompleted || task.CompletedAt == nil {
+		t.Fatalf("task = %+v, want completed", task)
+	}
+}
+
+func TestMoveCardOrdersBeforeID(t *testing.T) {
+	ctx := context.Background()
+	repo := boardRepo(&domain.Task{ID: "t1"}, &domain.Task{ID: "t2"}, &domain.Task{ID: "t3"}, &domain.Task{ID: "done", Status: domain.StatusCompleted})
+	move := NewMoveCard(repo, testutil.NopPublisher{})
+	group := string(domain.BoardByStatus)
+	active := string(domain.StatusActive)
+
+	// Без сохраненных позиций - по дате создания
+	if got := columnCards(t, repo, group, active); !slices.Equal(got, []string{"t1", "t2", "t3"}) {
+		t.Fatalf("initial = %v", got)
+	}
+
+	steps := []struct {
+		in   MoveCardInput
+		want []string
+	}{
+		{MoveCardInput{TaskID: "t3", BeforeID: "t1"}, []string{"t3", "t1", "t2"}},
+		{MoveCardInput{TaskID: "t3"}, []string{"t1", "t2", "t3"}},                 // пустой BeforeID - в конец
+		{MoveCardInput{TaskID: "t1", BeforeID: "t1"}, []string{"t2", "t3", "t1"}}, // перед собой - некуда, в конец
+		{MoveCardInput{TaskID: "done", BeforeID: "t3"}, []string{"t2", "done", "t3", "t1"}},
+	}
+	for i, step := range steps {
+		step.in.GroupBy, step.in.Column = group, active
+		if err := move.Execute(ctx, step.in); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if got := columnCards(t, repo, group, active); !slices.Equal(got, step.want) {
+			t.Fatalf("step %d: order = %v, want %v", i, got, step.want)
+		}
+	}
+	if done, _ := repo.GetByID(ctx, "done"); done.CompletedAt != nil {
+		t.Fatal("moving to an open column must reopen the task")
+	}
+
+	if err := move.Execute(ctx, MoveCardInput{TaskID: "t1", GroupBy: group, Column: "review"}); !errors.Is(err, domain.ErrInvalidColumn) {
+		t.Fatalf("unknown column: err = %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// AddChecklistItem добавляет пункт в конец чек-листа задачи
+type AddChecklistItem struct {
+	repo domain.TaskRepository
+}
+
+func NewAddChecklistItem(repo domain.TaskRepository) AddChecklistItem {
+	return AddChecklistItem{repo: repo}
+}
+
+type AddChecklistItemInput struct {
+	TaskID string `json:"task_id"`
+	Title  string `json:"title"`
+}
+
+func (uc AddChecklistItem) Execute(ctx context.Context, in AddChecklistItemInput) (*domain.ChecklistItem, error) {
+	var item *domain.ChecklistItem
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if _, err := repo.GetByID(ctx, in.TaskID); err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+
+		items, err := repo.Checklist().ListByTask(ctx, in.TaskID)
+		if err != nil {
+			return fmt.Errorf("list checklist: %w", err)
+		}
+		position := 0
+		if len(items) > 0 {
+			position = items[len(items)-1].Position + 1
+		}
+
+		if item, err = domain.NewChecklistItem(in.TaskID, in.Title, position); err != nil {
+			return err
+		}
+		if err := repo.Checklist().Save(ctx, item); err != nil {
+			return fmt.Errorf("save checklist item: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+type RenameChecklistItem struct {
+	repo domain.TaskRepository
+}
+
+func NewRenameChecklistItem(repo domain.TaskRepository) RenameChecklistItem {
+	return RenameChecklistItem{repo: repo}
+}
+
+type RenameChecklistItemInput struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func (uc RenameChecklistItem) Execute(ctx context.Context, in RenameChecklistItemInput) (*domain.ChecklistItem, error) {
+	var item *domain.ChecklistItem
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		var err error
+		if item, err = repo.Checklist().GetByID(ctx, in.ID); err != nil {
+			return fmt.Errorf("get checklist item: %w", err)
+		}
+
+		item.Title = strings.TrimSpace(in.Title)
+		if err := item.IsValid(); err != nil {
+			return err
+		}
+		if err := repo.Checklist().Save(ctx, item); err != nil {
+			return fmt.Errorf("save checklist item: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// CheckChecklistItem отмечает пункт или снимает отметку
+type CheckChecklistItem struct {
+	repo domain.TaskRepository
+}
+
+func NewCheckChecklistItem(repo domain.TaskRepository) CheckChecklistItem {
+	return CheckChecklistItem{repo: repo}
+}
+
+type CheckChecklistItemInput struct {
+	ID      string `json:"id"`
+	Checked bool   `json:"checked"`
+}
+
+type CheckChecklistItemOutput struct {
+	Item  *domain.ChecklistItem `json:"item"`
+	Done  int                   `json:"done"`
+	Total int                   `json:"total"`
+	// SuggestComplete - отмечен последний пункт открытой задачи, стоит предложить ее завершить
+	SuggestComplete bool `json:"suggest_complete"`
+}
+
+func (uc CheckChecklistItem) Execute(ctx context.Context, in CheckChecklistItemInput) (CheckChecklistItemOutput, error) {
+	var out CheckChecklistItemOutput
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		item, err := repo.Checklist().GetByID(ctx, in.ID)
+		if err != nil {
+			return fmt.Errorf("get checklist item: %w", err)
+		}
+
+		if item.Checked != in.Checked {
+			item.SetChecked(in.Checked, time.Now())
+			if err := repo.Checklist().Save(ctx, item); err != nil {
+				return fmt.Errorf("save checklist item: %w", err)
+			}
+		}
+
+		// Задача перечитывается ради пересчитанного прогресса
+		task, err := repo.GetByID(ctx, item.TaskID)
+		if err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+		w, err := loadWorkflow(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		out = CheckChecklistItemOutput{
+			Item:            item,
+			Done:            task.ChecklistDone,
+			Total:           task.ChecklistTotal,
+			SuggestComplete: in.Checked && task.ChecklistComplete() && !task.IsClosed(w),
+		}
+		return nil
+	})
+	if err != nil {
+		return CheckChecklistItemOutput{}, err
+	}
+
+	return out, nil
+}
+
+// MoveChecklistItem ставит пункт перед BeforeID; пустой BeforeID - в конец
+type MoveChecklistItem struct {
+	repo domain.TaskRepository
+}
+
+func NewMoveChecklistItem(repo domain.TaskRepository) MoveChecklistItem {
+	return MoveChecklistItem{repo: repo}
+}
+
+type MoveChecklistItemInput struct {
+	ID       string `json:"id"`
+	BeforeID string `json:"before_id,omitempty"`
+}
+
+func (uc MoveChecklistItem) Execute(ctx context.Context, in MoveChecklistItemInput) ([]*domain.ChecklistItem, error) {
+	var items []*domain.ChecklistItem
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		item, err := repo.Checklist().GetByID(ctx, in.ID)
+		if err != nil {
+			return fmt.Errorf("get checklist item: %w", err)
+		}
+
+		current, err := repo.Checklist().ListByTask(ctx, item.TaskID)
+		if err != nil {
+			return fmt.Errorf("list checklist: %w", err)
+		}
+
+		order := make([]string, 0, len(current))
+		items = make([]*domain.ChecklistItem, 0, len(current))
+		placed := false
+		for _, other := range current {
+			if other.ID == item.ID {
+				continue
+			}
+			if other.ID == in.BeforeID {
+				order = append(order, item.ID)
+				items = append(items, item)
+				placed = true
+			}
+			order = append(order, other.ID)
+			items = append(items, other)
+		}
+		if !placed {
+			if in.BeforeID != "" {
+				return fmt.Errorf("%w: %s is not in the checklist", domain.ErrInvalidChecklistItem, in.BeforeID)
+			}
+			order = append(order, item.ID)
+			items = append(items, item)
+		}
+
+		if err := repo.Checklist().SetPositions(ctx, item.TaskID, order); err != nil {
+			return fmt.Errorf("save positions: %w", err)
+		}
+		for i, it := range items {
+			it.Position = i
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+type DeleteChecklistItem struct {
+	repo domain.TaskRepository
+}
+
+func NewDeleteChecklistItem(repo domain.TaskRepository) DeleteChecklistItem {
+	return DeleteChecklistItem{repo: repo}
+}
+
+func (uc DeleteChecklistItem) Execute(ctx context.Context, id string) error {
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		return repo.Checklist().Delete(ctx, id)
+	})
+	if err != nil {
+		return fmt.Errorf("delete checklist item: %w", err)
+	}
+	return nil
+}
+
+type ListChecklist struct {
+	repo domain.TaskRepository
+}
+
+func NewListChecklist(repo domain.TaskRepository) ListChecklist {
+	return ListChecklist{repo: repo}
+}
+
+func (uc ListChecklist) Execute(ctx context.Context, taskID string) ([]*domain.ChecklistItem, error) {
+	items, err := uc.repo.Checklist().ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list checklist: %w", err)
+	}
+	return items, nil
+}
+
+// CheckChecklistOnComplete отмечает все пункты, когда задачу завершили любым способом.
+// Подписывается на task.completed синхронно, поэтому работает и для CompleteTask, и для доски,
+// и для UpdateTask, и use case возвращается уже с отмеченными пунктами.
+type CheckChecklistOnComplete struct {
+	repo domain.TaskRepository
+}
+
+func NewCheckChecklistOnComplete(repo domain.TaskRepository) CheckChecklistOnComplete {
+	return CheckChecklistOnComplete{repo: repo}
+}
+
+func (uc CheckChecklistOnComplete) Handle(ctx context.Context, event domain.Event) error {
+	completed, ok := event.(domain.TaskCompleted)
+	if !ok || completed.Task.ChecklistDone == completed.Task.ChecklistTotal {
+		return nil
+	}
+
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		_, err := repo.Checklist().CheckAll(ctx, completed.Task.ID, completed.At)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("check all checklist items: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func checklistRepo(t *testing.T, titles ...string) (*testutil.Repo, []string) {
+	t.Helper()
+	repo := testutil.NewRepo()
+	repo.Put(&domain.Task{ID: "t1", Title: "Release", Status: domain.StatusActive})
+
+	ids := make([]string, 0, len(titles))
+	for _, title := range titles {
+		item, err := NewAddChecklistItem(repo).Execute(context.Background(), AddChecklistItemInput{TaskID: "t1", Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, item.ID)
+	}
+	return repo, ids
+}
+
+func checklistTitles(t *testing.T, repo *testutil.Repo) []string {
+	t.Helper()
+	items, err := NewListChecklist(repo).Execute(context.Background(), "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+func TestMoveChecklistItem(t *testing.T) {
+	ctx := context.Background()
+	repo, ids := checklistRepo(t, "build", "test", "tag", "publish")
+	move := NewMoveChecklistItem(repo)
+
+	steps := []struct {
+		id, before string
+		want       []string
+	}{
+		{ids[3], ids[0], []string{"publish", "build", "test", "tag"}},
+		{ids[3], "", []string{"build", "test", "tag", "publish"}}, // пустой BeforeID - в конец
+		{ids[0], ids[3], []string{"test", "tag", "build", "publish"}},
+		{ids[2], ids[2], nil}, // перед собой - пункта уже нет в списке
+	}
+	for i, step := range steps {
+		items, err := move.Execute(ctx, MoveChecklistItemInput{ID: step.id, BeforeID: step.before})
+		if step.want == nil {
+			if !errors.Is(err, domain.ErrInvalidChecklistItem) {
+				t.Fatalf("step %d: err = %v, want invalid item", i, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		for pos, item := range items {
+			if item.Position != pos {
+				t.Fatalf("step %d: %s at position %d, want %d", i, item.Title, item.Position, pos)
+			}
+		}
+		if got := checklistTitles(t, repo); !slices.Equal(got, step.want) {
+			t.Fatalf("step %d: order = %v, want %v", i, got, step.want)
+		}
+	}
+
+	// Пункт другой задачи как опора не годится, порядок не меняется
+	repo.Put(&domain.Task{ID: "t2", Title: "Other", Status: domain.StatusActive})
+	other, err := NewAddChecklistItem(repo).Execute(ctx, AddChecklistItemInput{TaskID: "t2", Title: "foreign"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := move.Execute(ctx, MoveChecklistItemInput{ID: ids[0], BeforeID: other.ID}); !errors.Is(err, domain.ErrInvalidChecklistItem) {
+		t.Fatalf("foreign before id: err = %v", err)
+	}
+	if got := checklistTitles(t, repo); !slices.Equal(got, []string{"test", "tag", "build", "publish"}) {
+		t.Fatalf("order after refused move = %v", got)
+	}
+
+	// Новый пункт встает в конец, после переставленных
+	if _, err := NewAddChecklistItem(repo).Execute(ctx, AddChecklistItemInput{TaskID: "t1", Title: "announce"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := checklistTitles(t, repo); got[len(got)-1] != "announce" {
+		t.Fatalf("order after add = %v", got)
+	}
+}
+
+func TestChecklistProgress(t *testing.T) {
+	ctx := context.Background()
+	repo, ids := checklistRepo(t, "build", "test")
+	check := NewCheckChecklistItem(repo)
+
+	out, err := check.Execute(ctx, CheckChecklistItemInput{ID: ids[0], Checked: true})
+	if err != nil || out.Done != 1 || out.Total != 2 || out.SuggestComplete || !out.Item.Checked || out.Item.CheckedAt == nil {
+		t.Fatalf("first check = %+v, %v", out, err)
+	}
+	// Повторная отметка ничего не меняет
+	if out, err = check.Execute(ctx, CheckChecklistItemInput{ID: ids[0], Checked: true}); err != nil || out.Done != 1 {
+		t.Fatalf("repeated check = %+v, %v", out, err)
+	}
+
+	out, err = check.Execute(ctx, CheckChecklistItemInput{ID: ids[1], Checked: true})
+	if err != nil || out.Done != 2 || !out.SuggestComplete {
+		t.Fatalf("last item = %+v, %v; want completion suggested", out, err)
+	}
+
+	out, err = check.Execute(ctx, CheckChecklistItemInput{ID: ids[1], Checked: false})
+	if err != nil || out.Done != 1 || out.SuggestComplete || out.Item.CheckedAt != nil {
+		t.Fatalf("uncheck = %+v, %v", out, err)
+	}
+
+	// Удаление пункта пересчитывает прогресс задачи
+	if err := NewDeleteChecklistItem(repo).Execute(ctx, ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	task, _ := repo.GetByID(ctx, "t1")
+	if task.ChecklistTotal != 1 || task.ChecklistDone != 1 || !task.ChecklistComplete() {
+		t.Fatalf("progress after delete = %d/%d", task.ChecklistDone, task.ChecklistTotal)
+	}
+
+	// У закрытой задачи завершать нечего
+	task.Status = domain.StatusCompleted
+	repo.Put(task)
+	if _, err := check.Execute(ctx, CheckChecklistItemInput{ID: ids[0], Checked: false}); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := check.Execute(ctx, CheckChecklistItemInput{ID: ids[0], Checked: true}); out.SuggestComplete {
+		t.Fatal("suggested completing a closed task")
+	}
+}
+
+func TestChecklistItemValidation(t *testing.T) {
+	ctx := context.Background()
+	repo, ids := checklistRepo(t, "build")
+
+	if _, err := NewAddChecklistItem(repo).Execute(ctx, AddChecklistItemInput{TaskID: "t1", Title: "   "}); !errors.Is(err, domain.ErrInvalidChecklistItem) {
+		t.Fatalf("blank title: err = %v", err)
+	}
+	if _, err := NewAddChecklistItem(repo).Execute(ctx, AddChecklistItemInput{TaskID: "missing", Title: "x"}); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("unknown task: err = %v", err)
+	}
+	item, err := NewRenameChecklistItem(repo).Execute(ctx, RenameChecklistItemInput{ID: ids[0], Title: "  compile  "})
+	if err != nil || item.Title != "compile" {
+		t.Fatalf("rename = %+v, %v", item, err)
+	}
+	if _, err := NewRenameChecklistItem(repo).Execute(ctx, RenameChecklistItemInput{ID: ids[0], Title: ""}); !errors.Is(err, domain.ErrInvalidChecklistItem) {
+		t.Fatalf("rename to blank: err = %v", err)
+	}
+}
+
+// syncPublisher вызывает обработчики прямо в Publish, как синхронная подписка шины
+type syncPublisher []func(ctx context.Context, event domain.Event) error
+
+func (p syncPublisher) Publish(ctx context.Context, events ...domain.Event) {
+	for _, event := range events {
+		for _, handle := range p {
+			_ = handle(ctx, event)
+		}
+	}
+}
+
+func TestCompleteTaskChecksChecklist(t *testing.T) {
+	ctx := context.Background()
+	repo, ids := checklistRepo(t, "build", "test", "tag")
+	if _, err := NewCheckChecklistItem(repo).Execute(ctx, CheckChecklistItemInput{ID: ids[0], Checked: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	events := syncPublisher{NewCheckChecklistOnComplete(repo).Handle}
+	if err := NewCompleteTask(repo, events).Execute(ctx, CompleteTaskInput{ID: "t1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// К возврату из CompleteTask чек-лист уже отмечен
+	task, _ := repo.GetByID(ctx, "t1")
+	if task.ChecklistDone != 3 || task.ChecklistTotal != 3 {
+		t.Fatalf("progress = %d/%d, want all checked", task.ChecklistDone, task.ChecklistTotal)
+	}
+	items, _ := NewListChecklist(repo).Execute(ctx, "t1")
+	for _, item := range items {
+		if !item.Checked || item.CheckedAt == nil {
+			t.Fatalf("item %s is not checked", item.Title)
+		}
+	}
+}
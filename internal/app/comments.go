@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const (
+	defaultActivityPageSize = 50
+	maxActivityPageSize     = 500
+)
+
+// Виды элементов ленты задачи
+const (
+	ActivityKindComment = "comment"
+	ActivityKindEvent   = "activity"
+)
+
+// activityFields - поля снапшота, изменения которых показываются в ленте, в порядке вывода
+var activityFields = []string{
+	"title", "status", "priority", "due_date", "start_date", "project",
+	"parent_id", "tags", "estimate_minutes", "estimate_points", "completed_at",
+	"custom_fields",
+}
+
+// AddComment добавляет комментарий от имени автора из настроек
+type AddComment struct {
+	repo   domain.TaskRepository
+	author string
+}
+
+func NewAddComment(repo domain.TaskRepository, author string) AddComment {
+	return AddComment{repo: repo, author: author}
+}
+
+type AddCommentInput struct {
+	TaskID string `json:"task_id"`
+	Body   string `json:"body"` // Markdown
+}
+
+func (uc AddComment) Execute(ctx context.Context, in AddCommentInput) (*domain.Comment, error) {
+	comment, err := domain.NewComment(in.TaskID, uc.author, in.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if _, err := repo.GetByID(ctx, in.TaskID); err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+		if err := repo.Comments().Save(ctx, comment); err != nil {
+			return fmt.Errorf("save comment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+type EditComment struct {
+	repo domain.TaskRepository
+}
+
+func NewEditComment(repo domain.TaskRepository) EditComment {
+	return EditComment{repo: repo}
+}
+
+type EditCommentInput struct {
+	ID   string `json:"id"`
+	Body string `json:"body"`
+}
+
+func (uc EditComment) Execute(ctx context.Context, in EditCommentInput) (*domain.Comment, error) {
+	var comment *domain.Comment
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		var err error
+		if comment, err = repo.Comments().GetByID(ctx, in.ID); err != nil {
+			return fmt.Errorf("get comment: %w", err)
+		}
+
+		if err := comment.Edit(in.Body, time.Now()); err != nil {
+			return err
+		}
+		if err := repo.Comments().Save(ctx, comment); err != nil {
+			return fmt.Errorf("save comment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+type DeleteComment struct {
+	repo domain.TaskRepository
+}
+
+func NewDeleteComment(repo domain.TaskRepository) DeleteComment {
+	return DeleteComment{repo: repo}
+}
+
+func (uc DeleteComment) Execute(ctx context.Context, id string) error {
+	if err := uc.repo.Comments().Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+	return nil
+}
+
+// GetTaskActivity - комментарии и история изменений задачи одной лентой, новые первыми
+type GetTaskActivity struct {
+	repo domain.TaskRepository
+}
+
+func NewGetTaskActivity(repo domain.TaskRepository) GetTaskActivity {
+	return GetTaskActivity{repo: repo}
+}
+
+type GetTaskActivityInput struct {
+	TaskID   string `json:"task_id"`
+	Page     int    `json:"page"`      // с 1
+	PageSize int    `json:"page_size"` // по умолчанию 50
+}
+
+type GetTaskActivityOutput struct {
+	Items    []ActivityItem `json:"items"`
+	Total    int            `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+// ActivityItem - элемент ленты: комментарий или событие задачи с изменениями полей
+type ActivityItem struct {
+	Kind    string           `json:"kind"` // comment или activity
+	At      time.Time        `json:"at"`
+	Comment *domain.Comment  `json:"comment,omitempty"`
+	Edited  bool             `json:"edited,omitempty"`
+	Event   string           `json:"event,omitempty"` // тип доменного события, например task.completed
+	Changes []ActivityChange `json:"changes,omitempty"`
+}
+
+// ActivityChange - значения поля снапшота до и после события
+type ActivityChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+func (uc GetTaskActivity) Execute(ctx context.Context, in GetTaskActivityInput) (GetTaskActivityOutput, error) {
+	page := max(in.Page, 1)
+	size := in.PageSize
+	if size <= 0 {
+		size = defaultActivityPageSize
+	}
+	size = min(size, maxActivityPageSize)
+
+	if _, err := uc.repo.GetByID(ctx, in.TaskID); err != nil {
+		return GetTaskActivityOutput{}, fmt.Errorf("get task: %w", err)
+	}
+
+	entries, err := uc.repo.Activity().Timeline(ctx, in.TaskID, size, (page-1)*size)
+	if err != nil {
+		return GetTaskActivityOutput{}, fmt.Errorf("get timeline: %w", err)
+	}
+	total, err := uc.repo.Activity().CountTimeline(ctx, in.TaskID)
+	if err != nil {
+		return GetTaskActivityOutput{}, fmt.Errorf("count timeline: %w", err)
+	}
+
+	items := make([]ActivityItem, 0, len(entries))
+	for _, entry := range entries {
+		item := ActivityItem{At: entry.At()}
+		if entry.Comment != nil {
+			item.Kind = ActivityKindComment
+			item.Comment = entry.Comment
+			item.Edited = entry.Comment.Edited()
+		} else {
+			item.Kind = ActivityKindEvent
+			item.Event = entry.Activity.EventType
+			if entry.Previous != nil {
+				if item.Changes, err = activityChanges(entry.Previous, entry.Activity.Snapshot); err != nil {
+					return GetTaskActivityOutput{}, fmt.Errorf("decode activity %d: %w", entry.Activity.ID, err)
+				}
+			}
+		}
+		items = append(items, item)
+	}
+
+	return GetTaskActivityOutput{Items: items, Total: total, Page: page, PageSize: size}, nil
+}
+
+// activityChanges сравнивает два снапшота задачи (JSON SnapshotTask) по activityFields
+func activityChanges(before, after []byte) ([]ActivityChange, error) {
+	var from, to map[string]any
+	if err := json.Unmarshal(before, &from); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &to); err != nil {
+		return nil, err
+	}
+
+	var changes []ActivityChange
+	for _, field := range activityFields {
+		if reflect.DeepEqual(from[field], to[field]) {
+			continue
+		}
+		changes = append(changes, ActivityChange{Field: field, From: from[field], To: to[field]})
+	}
+	return changes, nil
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestTaskActivityPages(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	task := domain.Task{ID: "t1", Title: "v0", Status: domain.StatusActive, Priority: domain.PriorityMedium, CreatedAt: start}
+	repo.Put(&task)
+
+	record := func(event domain.Event) {
+		t.Helper()
+		if err := recordEvents(ctx, repo, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rename := func(title string, at time.Time) {
+		task.Title = title
+		record(domain.TaskUpdated{Task: task, At: at})
+	}
+
+	record(domain.TaskCreated{Task: task, At: start})
+	rename("v1", start.Add(time.Minute))
+	// Без видимых изменений (например, учтенное время) - в истории не остается
+	rename("v1", start.Add(90*time.Second))
+	rename("v2", start.Add(2*time.Minute))
+	comment, err := domain.NewComment("t1", "me", "looks good")
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment.CreatedAt = start.Add(150 * time.Second)
+	comment.UpdatedAt = comment.CreatedAt
+	if err := repo.Comments().Save(ctx, comment); err != nil {
+		t.Fatal(err)
+	}
+	rename("v3", start.Add(3*time.Minute))
+
+	uc := NewGetTaskActivity(repo)
+	page := func(n int) []ActivityItem {
+		t.Helper()
+		out, err := uc.Execute(ctx, GetTaskActivityInput{TaskID: "t1", Page: n, PageSize: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.Total != 5 || out.Page != n || out.PageSize != 2 {
+			t.Fatalf("page %d: total %d, page %d, size %d; want 5 entries", n, out.Total, out.Page, out.PageSize)
+		}
+		return out.Items
+	}
+	titleChange := func(item ActivityItem, from, to string) {
+		t.Helper()
+		if item.Kind != ActivityKindEvent || len(item.Changes) != 1 {
+			t.Fatalf("item = %+v, want title %s -> %s", item, from, to)
+		}
+		if c := item.Changes[0]; c.Field != "title" || c.From != from || c.To != to {
+			t.Fatalf("change = %+v, want title %s -> %s", c, from, to)
+		}
+	}
+
+	// Предыдущий снапшот первой записи страницы лежит на следующей странице
+	first := page(1)
+	if len(first) != 2 {
+		t.Fatalf("page 1 = %+v", first)
+	}
+	titleChange(first[0], "v2", "v3")
+	if first[1].Kind != ActivityKindComment || first[1].Comment.ID != comment.ID || first[1].Edited {
+		t.Fatalf("page 1 second item = %+v, want the comment", first[1])
+	}
+
+	second := page(2)
+	if len(second) != 2 {
+		t.Fatalf("page 2 = %+v", second)
+	}
+	titleChange(second[0], "v1", "v2")
+	titleChange(second[1], "v0", "v1")
+
+	third := page(3)
+	if len(third) != 1 || third[0].Event != domain.EventTaskCreated || third[0].Changes != nil {
+		t.Fatalf("page 3 = %+v, want creation without changes", third)
+	}
+	if rest := page(4); len(rest) != 0 {
+		t.Fatalf("page 4 = %+v", rest)
+	}
+
+	// Outbox получает и пропущенное в истории обновление
+	if len(repo.OutboxEntries) != 5 {
+		t.Fatalf("outbox has %d entries, want 5", len(repo.OutboxEntries))
+	}
+}
@@ -8,25 +8,45 @@ import (
 )
 
 type CompleteTask struct {
-	repo domain.TaskRepository
+	repo   domain.TaskRepository
+	events domain.EventPublisher
 }
 
-func NewCompleteTask(repo domain.TaskRepository) CompleteTask {
-	return CompleteTask{repo: repo}
+func NewCompleteTask(repo domain.TaskRepository, events domain.EventPublisher) CompleteTask {
+	return CompleteTask{repo: repo, events: events}
 }
 
 type CompleteTaskInput struct {
 	ID string `json:"id"`
+	// Force завершает задачу, даже если ее блокеры еще открыты
+	Force bool `json:"force,omitempty"`
 }
 
 func (uc CompleteTask) Execute(ctx context.Context, in CompleteTaskInput) error {
-	return uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+	var event domain.Event
+
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
 		task, err := repo.GetByID(ctx, in.ID)
 		if err != nil {
 			return fmt.Errorf("get task: %w", err)
 		}
 
-		if err := task.Complete(); err != nil {
+		w, err := loadWorkflow(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		if !in.Force && !task.IsClosed(w) {
+			blockers, err := openBlockers(ctx, repo, task.ID, w)
+			if err != nil {
+				return err
+			}
+			if len(blockers) > 0 {
+				return blockedError(blockers)
+			}
+		}
+
+		if err := task.Complete(w); err != nil {
 			return fmt.Errorf("complete task: %w", err)
 		}
 
@@ -34,6 +54,14 @@ func (uc CompleteTask) Execute(ctx context.Context, in CompleteTaskInput) error
 			return fmt.Errorf("save task: %w", err)
 		}
 
-		return nil
+		event = domain.TaskCompleted{Task: task.Snapshot(), At: task.UpdatedAt}
+		return recordEvents(ctx, repo, event)
 	})
+	if err != nil {
+		return err
+	}
+
+	// Подписчики узнают о событии только после коммита
+	uc.events.Publish(ctx, event)
+	return nil
 }
\ No newline at end of file
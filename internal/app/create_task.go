@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,17 +10,34 @@ import (
 )
 
 type CreateTask struct {
-	repo domain.TaskRepository
+	repo   domain.TaskRepository
+	events domain.EventPublisher
 }
 
-func NewCreateTask(repo domain.TaskRepository) CreateTask {
-	return CreateTask{repo: repo}
+func NewCreateTask(repo domain.TaskRepository, events domain.EventPublisher) CreateTask {
+	return CreateTask{repo: repo, events: events}
 }
 
 type CreateTaskInput struct {
+	ID       string     `json:"id,omitempty"` // задается внешними клиентами (CalDAV), иначе генерируется
 	Title    string     `json:"title"`
 	Priority string     `json:"priority"`
 	DueDate  *time.Time `json:"due_date,omitempty"`
+	Project  string     `json:"project,omitempty"`
+	ParentID *string    `json:"parent_id,omitempty"`
+	Tags     []string   `json:"tags,omitempty"`
+
+	EstimateMinutes int `json:"estimate_minutes,omitempty"`
+	EstimatePoints  int `json:"estimate_points,omitempty"`
+
+	StartDate *time.Time `json:"start_date,omitempty"` // отложить задачу до этого момента
+
+	// CustomFields - значения пользовательских полей; обязательные поля проекта
+	// проверяются всегда
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+
+	// Completed завершает задачу в той же транзакции, что и создание (CalDAV)
+	Completed bool `json:"completed,omitempty"`
 }
 
 type CreateTaskOutput struct {
@@ -37,9 +55,77 @@ func (uc CreateTask) Execute(ctx context.Context, in CreateTaskInput) (CreateTas
 		return CreateTaskOutput{}, fmt.Errorf("create task: %w", err)
 	}
 
-	if err := uc.repo.Save(ctx, task); err != nil {
-		return CreateTaskOutput{}, fmt.Errorf("save task: %w", err)
+	if in.ID != "" {
+		task.ID = in.ID
+	}
+	task.Project = in.Project
+	for _, tag := range in.Tags {
+		task.AddTag(tag)
+	}
+	task.ParentID = in.ParentID
+
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return CreateTaskOutput{}, err
+	}
+	task.Status = w.InitialStatus()
+	task.EstimateMinutes = in.EstimateMinutes
+	task.EstimatePoints = in.EstimatePoints
+	task.StartDate = in.StartDate
+
+	if err := w.ValidateTask(task); err != nil {
+		return CreateTaskOutput{}, fmt.Errorf("validate task: %w", err)
 	}
 
+	fields, err := uc.repo.CustomFields().List(ctx)
+	if err != nil {
+		return CreateTaskOutput{}, fmt.Errorf("get custom fields: %w", err)
+	}
+	if err := task.SetCustomValues(fields, in.CustomFields); err != nil {
+		return CreateTaskOutput{}, err
+	}
+	if err := task.CheckRequiredFields(fields); err != nil {
+		return CreateTaskOutput{}, err
+	}
+
+	var events []domain.Event
+	err = uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if in.ID != "" {
+			if _, err := repo.GetByID(ctx, in.ID); err == nil {
+				return domain.ErrTaskExists
+			} else if !errors.Is(err, domain.ErrTaskNotFound) {
+				return fmt.Errorf("get task: %w", err)
+			}
+		}
+		if in.ParentID != nil {
+			if _, err := repo.GetByID(ctx, *in.ParentID); err != nil {
+				return fmt.Errorf("get parent task: %w", err)
+			}
+		}
+
+		if err := repo.Save(ctx, task); err != nil {
+			return fmt.Errorf("save task: %w", err)
+		}
+
+		events = append(events, domain.TaskCreated{Task: task.Snapshot(), At: task.UpdatedAt})
+
+		if in.Completed {
+			if err := task.Complete(w); err != nil {
+				return fmt.Errorf("complete task: %w", err)
+			}
+			if err := repo.Save(ctx, task); err != nil {
+				return fmt.Errorf("save task: %w", err)
+			}
+			events = append(events, domain.TaskCompleted{Task: task.Snapshot(), At: task.UpdatedAt})
+		}
+
+		return recordEvents(ctx, repo, events...)
+	})
+	if err != nil {
+		return CreateTaskOutput{}, err
+	}
+
+	uc.events.Publish(ctx, events...)
+
 	return CreateTaskOutput{ID: task.ID}, nil
 }
\ No newline at end of file
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type ListCustomFields struct {
+	repo domain.TaskRepository
+}
+
+func NewListCustomFields(repo domain.TaskRepository) ListCustomFields {
+	return ListCustomFields{repo: repo}
+}
+
+type ListCustomFieldsInput struct {
+	// Project - только поля, доступные в проекте; nil - все поля
+	Project *string `json:"project,omitempty"`
+}
+
+func (uc ListCustomFields) Execute(ctx context.Context, in ListCustomFieldsInput) ([]domain.CustomField, error) {
+	fields, err := uc.repo.CustomFields().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list custom fields: %w", err)
+	}
+	if in.Project != nil {
+		fields = slices.DeleteFunc(fields, func(f domain.CustomField) bool { return !f.AppliesTo(*in.Project) })
+	}
+	return fields, nil
+}
+
+// SaveCustomField создает поле или меняет его описание. Тип менять нельзя:
+// уже записанные значения перестали бы ему соответствовать.
+type SaveCustomField struct {
+	repo domain.TaskRepository
+}
+
+func NewSaveCustomField(repo domain.TaskRepository) SaveCustomField {
+	return SaveCustomField{repo: repo}
+}
+
+func (uc SaveCustomField) Execute(ctx context.Context, f domain.CustomField) (domain.CustomField, error) {
+	f.Key = strings.TrimSpace(f.Key)
+	f.Name = strings.TrimSpace(f.Name)
+	f.Project = strings.TrimSpace(f.Project)
+	for i, option := range f.Options {
+		f.Options[i] = strings.TrimSpace(option)
+	}
+	if err := f.IsValid(); err != nil {
+		return domain.CustomField{}, err
+	}
+
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		current, err := repo.CustomFields().Get(ctx, f.Key)
+		switch {
+		case err == nil:
+			if current.Type != f.Type {
+				return fmt.Errorf("%w: type of field %q can't be changed", domain.ErrInvalidCustomField, f.Key)
+			}
+		case !errors.Is(err, domain.ErrCustomFieldNotFound):
+			return fmt.Errorf("get custom field: %w", err)
+		}
+
+		if err := repo.CustomFields().Save(ctx, f); err != nil {
+			return fmt.Errorf("save custom field: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+
+	return f, nil
+}
+
+// DeleteCustomField удаляет поле вместе с его значениями во всех задачах, включая архив
+type DeleteCustomField struct {
+	repo   domain.TaskRepository
+	events domain.EventPublisher
+}
+
+func NewDeleteCustomField(repo domain.TaskRepository, events domain.EventPublisher) DeleteCustomField {
+	return DeleteCustomField{repo: repo, events: events}
+}
+
+type DeleteCustomFieldOutput struct {
+	Cleared int `json:"cleared"` // у скольких задач стерто значение
+}
+
+func (uc DeleteCustomField) Execute(ctx context.Context, key string) (DeleteCustomFieldOutput, error) {
+	var out DeleteCustomFieldOutput
+	var events []domain.Event
+
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if err := repo.CustomFields().Delete(ctx, key); err != nil {
+			return fmt.Errorf("delete custom field: %w", err)
+		}
+
+		// Через Save, чтобы очистка получила метку синхронизации и попала на другие устройства
+		tasks, err := repo.CustomFields().TasksWithValue(ctx, key)
+		if err != nil {
+			return fmt.Errorf("get tasks: %w", err)
+		}
+		for _, task := range tasks {
+			delete(task.CustomFields, key)
+			if err := repo.Save(ctx, task); err != nil {
+				return fmt.Errorf("save task %s: %w", task.ID, err)
+			}
+			events = append(events, domain.TaskUpdated{Task: task.Snapshot(), At: task.UpdatedAt})
+		}
+		out.Cleared = len(tasks)
+
+		return recordEvents(ctx, repo, events...)
+	})
+	if err != nil {
+		return DeleteCustomFieldOutput{}, err
+	}
+
+	uc.events.Publish(ctx, events...)
+	return out, nil
+}
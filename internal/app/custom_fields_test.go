@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestCreateTaskChecksRequiredFields(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	repo.Fields = []domain.CustomField{{Key: "sprint", Project: "work", Name: "Sprint", Type: domain.FieldTypeNumber, Required: true}}
+	uc := NewCreateTask(repo, testutil.NopPublisher{})
+
+	// Поля не переданы вовсе - обязательное все равно проверяется
+	if _, err := uc.Execute(ctx, CreateTaskInput{Title: "Plan", Project: "work"}); !errors.Is(err, domain.ErrInvalidFieldValue) {
+		t.Fatalf("create without required field: err = %v, want ErrInvalidFieldValue", err)
+	}
+	if _, err := uc.Execute(ctx, CreateTaskInput{Title: "Plan", Project: "home"}); err != nil {
+		t.Fatalf("create in project without the field: %v", err)
+	}
+	if _, err := uc.Execute(ctx, CreateTaskInput{Title: "Plan", Project: "work", CustomFields: map[string]any{"sprint": 4}}); err != nil {
+		t.Fatalf("create with required field: %v", err)
+	}
+}
+
+func TestUpdateTaskProjectChangeChecksRequiredFields(t *testing.T) {
+	ctx := context.Background()
+	w := domain.DefaultWorkflow()
+	repo := testutil.NewRepo()
+	repo.Fields = []domain.CustomField{{Key: "sprint", Project: "work", Name: "Sprint", Type: domain.FieldTypeNumber, Required: true}}
+	repo.Put(&domain.Task{ID: "task", Title: "Plan", Status: w.InitialStatus(), Priority: domain.PriorityMedium, Project: "home"})
+	uc := NewUpdateTask(repo, testutil.NopPublisher{})
+
+	work := "work"
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Project: &work}); !errors.Is(err, domain.ErrInvalidFieldValue) {
+		t.Fatalf("move to project with required field: err = %v, want ErrInvalidFieldValue", err)
+	}
+	if task, _ := repo.GetByID(ctx, "task"); task.Project != "home" {
+		t.Fatalf("project = %q after failed update, want home", task.Project)
+	}
+
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Project: &work, CustomFields: map[string]any{"sprint": 1}}); err != nil {
+		t.Fatalf("move with required field: %v", err)
+	}
+}
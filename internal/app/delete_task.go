@@ -3,32 +3,51 @@ package app
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/w0ikid/dekstop-todo-app/internal/domain"
 )
 
 type DeleteTask struct {
-	repo domain.TaskRepository
+	repo   domain.TaskRepository
+	events domain.EventPublisher
 }
 
-func NewDeleteTask(repo domain.TaskRepository) DeleteTask {
-	return DeleteTask{repo: repo}
+func NewDeleteTask(repo domain.TaskRepository, events domain.EventPublisher) DeleteTask {
+	return DeleteTask{repo: repo, events: events}
 }
 
 type DeleteTaskInput struct {
-	ID string `json:"id"`
+	ID              string `json:"id"`
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
 }
 
 func (uc DeleteTask) Execute(ctx context.Context, in DeleteTaskInput) error {
-	// Проверяем существование
-	_, err := uc.repo.GetByID(ctx, in.ID)
+	event := domain.TaskDeleted{ID: in.ID, At: time.Now()}
+
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		// Проверяем существование; строка заблокирована до конца транзакции,
+		// поэтому версия не изменится между проверкой и удалением
+		task, err := repo.GetForUpdate(ctx, in.ID)
+		if err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+
+		if in.ExpectedVersion != nil && *in.ExpectedVersion != task.Version {
+			return domain.ErrVersionConflict
+		}
+
+		if err := repo.Delete(ctx, in.ID); err != nil {
+			return fmt.Errorf("delete task: %w", err)
+		}
+
+		return recordEvents(ctx, repo, event)
+	})
 	if err != nil {
-		return fmt.Errorf("get task: %w", err)
+		return err
 	}
 
-	if err := uc.repo.Delete(ctx, in.ID); err != nil {
-		return fmt.Errorf("delete task: %w", err)
-	}
+	uc.events.Publish(ctx, event)
 
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type DeleteWebhook struct {
+	repo domain.WebhookRepository
+}
+
+func NewDeleteWebhook(repo domain.WebhookRepository) DeleteWebhook {
+	return DeleteWebhook{repo: repo}
+}
+
+// Execute удаляет подписку вместе с журналом доставок
+func (uc DeleteWebhook) Execute(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	return nil
+}
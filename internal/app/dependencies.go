@@ -0,0 +1,148 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type DependencyInput struct {
+	TaskID      string `json:"task_id"`
+	BlockedByID string `json:"blocked_by_id"`
+}
+
+// AddDependency добавляет связь "TaskID ждет BlockedByID"; граф должен остаться ацикличным
+type AddDependency struct {
+	repo domain.TaskRepository
+}
+
+func NewAddDependency(repo domain.TaskRepository) AddDependency {
+	return AddDependency{repo: repo}
+}
+
+func (uc AddDependency) Execute(ctx context.Context, in DependencyInput) error {
+	if in.TaskID == "" || in.BlockedByID == "" || in.TaskID == in.BlockedByID {
+		return domain.ErrInvalidDependency
+	}
+
+	return uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		deps := repo.Dependencies()
+		if err := deps.Lock(ctx); err != nil {
+			return fmt.Errorf("lock dependencies: %w", err)
+		}
+
+		for _, id := range []string{in.TaskID, in.BlockedByID} {
+			if _, err := repo.GetByID(ctx, id); err != nil {
+				return fmt.Errorf("get task %s: %w", id, err)
+			}
+		}
+
+		all, err := deps.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("get dependencies: %w", err)
+		}
+		if domain.NewDependencyGraph(all).WouldCycle(in.TaskID, in.BlockedByID) {
+			return domain.ErrDependencyCycle
+		}
+
+		if err := deps.Add(ctx, domain.Dependency{TaskID: in.TaskID, BlockedByID: in.BlockedByID}); err != nil {
+			return fmt.Errorf("add dependency: %w", err)
+		}
+		return nil
+	})
+}
+
+type RemoveDependency struct {
+	repo domain.TaskRepository
+}
+
+func NewRemoveDependency(repo domain.TaskRepository) RemoveDependency {
+	return RemoveDependency{repo: repo}
+}
+
+func (uc RemoveDependency) Execute(ctx context.Context, in DependencyInput) error {
+	err := uc.repo.Dependencies().Remove(ctx, domain.Dependency{TaskID: in.TaskID, BlockedByID: in.BlockedByID})
+	if err != nil {
+		return fmt.Errorf("remove dependency: %w", err)
+	}
+	return nil
+}
+
+// openBlockers - незавершенные задачи, которых ждет taskID
+func openBlockers(ctx context.Context, repo domain.TaskRepository, taskID string, w domain.Workflow) ([]*domain.Task, error) {
+	ids, err := repo.Dependencies().GetBlockers(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get blockers: %w", err)
+	}
+
+	var open []*domain.Task
+	for _, id := range ids {
+		task, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get blocker %s: %w", id, err)
+		}
+		if !task.IsClosed(w) {
+			open = append(open, task)
+		}
+	}
+	return open, nil
+}
+
+// BlockedError - задачу нельзя закрыть, пока открыты Blockers; errors.Is(err, domain.ErrTaskBlocked)
+type BlockedError struct {
+	Blockers []*domain.Task
+}
+
+func (e *BlockedError) Error() string {
+	titles := make([]string, 0, len(e.Blockers))
+	for _, t := range e.Blockers {
+		titles = append(titles, fmt.Sprintf("%q", t.Title))
+	}
+	return fmt.Sprintf("%v by %s", domain.ErrTaskBlocked, strings.Join(titles, ", "))
+}
+
+func (e *BlockedError) Unwrap() error {
+	return domain.ErrTaskBlocked
+}
+
+func blockedError(blockers []*domain.Task) error {
+	return &BlockedError{Blockers: blockers}
+}
+
+// splitByReadiness делит активные задачи на готовые к работе и ждущие незавершенных блокеров
+func splitByReadiness(ctx context.Context, repo domain.TaskRepository) (ready, blocked []*domain.Task, err error) {
+	tasks, err := repo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	deps, err := repo.Dependencies().GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	w, err := loadWorkflow(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	graph := domain.NewDependencyGraph(deps)
+	byID := make(map[string]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	ready = make([]*domain.Task, 0)
+	blocked = make([]*domain.Task, 0)
+	for _, task := range tasks {
+		if task.IsClosed(w) {
+			continue
+		}
+		if len(graph.OpenBlockers(task.ID, byID, w)) > 0 {
+			blocked = append(blocked, task)
+		} else {
+			ready = append(ready, task)
+		}
+	}
+	return ready, blocked, nil
+}
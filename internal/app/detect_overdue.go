@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// DetectOverdue публикует task.overdue для задач, срок которых уже прошел.
+// Ключ события строится из ID и срока, поэтому каждый срок дает одно событие.
+type DetectOverdue struct {
+	repo     domain.TaskRepository
+	events   domain.EventPublisher
+	lookback time.Duration
+}
+
+// lookback ограничивает, насколько старые просрочки еще сообщаются;
+// не должен превышать срок хранения доставленных записей outbox
+func NewDetectOverdue(repo domain.TaskRepository, events domain.EventPublisher, lookback time.Duration) DetectOverdue {
+	return DetectOverdue{repo: repo, events: events, lookback: lookback}
+}
+
+func (uc DetectOverdue) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	var events []domain.Event
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		tasks, err := repo.GetDueBetween(ctx, now.Add(-uc.lookback), now)
+		if err != nil {
+			return fmt.Errorf("get due tasks: %w", err)
+		}
+
+		w, err := loadWorkflow(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if !task.IsOverdue(w) {
+				continue
+			}
+
+			event := domain.TaskOverdue{Task: task.Snapshot(), At: now}
+			if err := recordEvent(ctx, repo, event); err != nil {
+				if errors.Is(err, domain.ErrDuplicateEvent) {
+					continue
+				}
+				return err
+			}
+			events = append(events, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	uc.events.Publish(ctx, events...)
+	return len(events), nil
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// DetectWakeups публикует task.woke для отложенных задач, дата начала которых наступила.
+// Как и в DetectOverdue, ключ события строится из ID и даты, поэтому повторов нет.
+type DetectWakeups struct {
+	repo     domain.TaskRepository
+	events   domain.EventPublisher
+	lookback time.Duration
+}
+
+func NewDetectWakeups(repo domain.TaskRepository, events domain.EventPublisher, lookback time.Duration) DetectWakeups {
+	return DetectWakeups{repo: repo, events: events, lookback: lookback}
+}
+
+func (uc DetectWakeups) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	var events []domain.Event
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		tasks, err := repo.GetStartingBetween(ctx, now.Add(-uc.lookback), now)
+		if err != nil {
+			return fmt.Errorf("get starting tasks: %w", err)
+		}
+
+		w, err := loadWorkflow(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			// Закрытую задачу будить незачем
+			if task.IsClosed(w) {
+				continue
+			}
+
+			event := domain.TaskWoke{Task: task.Snapshot(), At: now}
+			if err := recordEvent(ctx, repo, event); err != nil {
+				if errors.Is(err, domain.ErrDuplicateEvent) {
+					continue
+				}
+				return err
+			}
+			events = append(events, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	uc.events.Publish(ctx, events...)
+	return len(events), nil
+}
@@ -0,0 +1,386 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// SnapshotVersion - текущая версия формата JSON-бэкапа.
+// Увеличивается при любом несовместимом изменении структуры снапшота.
+// Версия 2 добавила все связанные с задачами данные и настройки.
+const SnapshotVersion = 2
+
+type Snapshot struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Tasks      []SnapshotTask `json:"tasks"`
+	// CustomFields - описания пользовательских полей, значения лежат в задачах
+	CustomFields []domain.CustomField `json:"custom_fields,omitempty"`
+
+	// Ниже - данные версии 2; в снапшоте версии 1 их нет
+	Workflow      *domain.Workflow        `json:"workflow,omitempty"`
+	Board         SnapshotBoard           `json:"board"`
+	Dependencies  []SnapshotDependency    `json:"dependencies,omitempty"`
+	TimeEntries   []SnapshotTimeEntry     `json:"time_entries,omitempty"`
+	FocusSessions []SnapshotFocusSession  `json:"focus_sessions,omitempty"`
+	Checklist     []SnapshotChecklistItem `json:"checklist,omitempty"`
+	// Attachments - только метаданные: содержимое лежит в хранилище вложений и в JSON не попадает
+	Attachments []SnapshotAttachment `json:"attachments,omitempty"`
+	Comments    []SnapshotComment    `json:"comments,omitempty"`
+	Activity    []SnapshotActivity   `json:"activity,omitempty"`
+	Webhooks    []SnapshotWebhook    `json:"webhooks,omitempty"`
+}
+
+type SnapshotTask struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status"`
+	Priority  string     `json:"priority"`
+	CreatedAt time.Time  `json:"created_at"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	Project   string     `json:"project,omitempty"`
+	ParentID  *string    `json:"parent_id,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+
+	EstimateMinutes int `json:"estimate_minutes,omitempty"`
+	EstimatePoints  int `json:"estimate_points,omitempty"`
+
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+}
+
+func newSnapshotTask(task *domain.Task) SnapshotTask {
+	return SnapshotTask{
+		ID:        task.ID,
+		Title:     task.Title,
+		Status:    string(task.Status),
+		Priority:  string(task.Priority),
+		CreatedAt: task.CreatedAt,
+		DueDate:   task.DueDate,
+		Project:   task.Project,
+		ParentID:  task.ParentID,
+		Tags:      task.Tags,
+
+		EstimateMinutes: task.EstimateMinutes,
+		EstimatePoints:  task.EstimatePoints,
+
+		CompletedAt: task.CompletedAt,
+		ArchivedAt:  task.ArchivedAt,
+		StartDate:   task.StartDate,
+
+		CustomFields: task.CustomFields,
+	}
+}
+
+func (st SnapshotTask) toDomain() *domain.Task {
+	return &domain.Task{
+		ID:        st.ID,
+		Title:     st.Title,
+		Status:    domain.TaskStatus(st.Status),
+		Priority:  domain.Priority(st.Priority),
+		CreatedAt: st.CreatedAt,
+		DueDate:   st.DueDate,
+		Project:   st.Project,
+		ParentID:  st.ParentID,
+		Tags:      st.Tags,
+
+		EstimateMinutes: st.EstimateMinutes,
+		EstimatePoints:  st.EstimatePoints,
+
+		CompletedAt: st.CompletedAt,
+		ArchivedAt:  st.ArchivedAt,
+		StartDate:   st.StartDate,
+
+		CustomFields: st.CustomFields,
+	}
+}
+
+// SnapshotBoard - порядок карточек и WIP-лимиты по группировкам доски
+type SnapshotBoard struct {
+	Positions map[domain.BoardGrouping]map[string]int `json:"positions,omitempty"`  // ID задачи -> позиция
+	WIPLimits map[domain.BoardGrouping]map[string]int `json:"wip_limits,omitempty"` // колонка -> лимит
+}
+
+type SnapshotDependency struct {
+	TaskID      string `json:"task_id"`
+	BlockedByID string `json:"blocked_by_id"`
+}
+
+type SnapshotTimeEntry struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type SnapshotFocusSession struct {
+	ID             string    `json:"id"`
+	TaskID         string    `json:"task_id"`
+	StartedAt      time.Time `json:"started_at"`
+	EndedAt        time.Time `json:"ended_at"`
+	PlannedSeconds int64     `json:"planned_seconds"`
+	Completed      bool      `json:"completed"`
+}
+
+type SnapshotChecklistItem struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	Title     string     `json:"title"`
+	Checked   bool       `json:"checked,omitempty"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+	Position  int        `json:"position"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type SnapshotAttachment struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Name      string    `json:"name"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SnapshotComment struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SnapshotActivity - запись истории задачи; Snapshot - JSON SnapshotTask на момент события
+type SnapshotActivity struct {
+	TaskID     string          `json:"task_id"`
+	EventType  string          `json:"event_type"`
+	Snapshot   json.RawMessage `json:"snapshot"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// SnapshotWebhook - вебхук вместе с секретом подписи, иначе после восстановления подпись пропадет
+type SnapshotWebhook struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types,omitempty"`
+	Filter     string    `json:"filter,omitempty"`
+	Secret     string    `json:"secret,omitempty"`
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (sw SnapshotWebhook) toDomain() *domain.Webhook {
+	return &domain.Webhook{
+		ID:         sw.ID,
+		URL:        sw.URL,
+		EventTypes: sw.EventTypes,
+		Filter:     sw.Filter,
+		Secret:     sw.Secret,
+		Enabled:    sw.Enabled,
+		CreatedAt:  sw.CreatedAt,
+		UpdatedAt:  sw.UpdatedAt,
+	}
+}
+
+type ExportBackup struct {
+	repo domain.TaskRepository
+}
+
+func NewExportBackup(repo domain.TaskRepository) ExportBackup {
+	return ExportBackup{repo: repo}
+}
+
+func (uc ExportBackup) Execute(ctx context.Context) (Snapshot, error) {
+	tasks, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("get tasks: %w", err)
+	}
+	// В бэкап попадает и архив
+	archived, err := uc.repo.Archive().List(ctx, domain.ArchiveFilter{})
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("get archived tasks: %w", err)
+	}
+	tasks = append(tasks, archived...)
+
+	fields, err := uc.repo.CustomFields().List(ctx)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("get custom fields: %w", err)
+	}
+
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		Version:      SnapshotVersion,
+		ExportedAt:   time.Now(),
+		Tasks:        make([]SnapshotTask, 0, len(tasks)),
+		CustomFields: fields,
+		Workflow:     &w,
+	}
+	for _, task := range tasks {
+		snapshot.Tasks = append(snapshot.Tasks, newSnapshotTask(task))
+	}
+
+	if err := uc.exportRelated(ctx, &snapshot); err != nil {
+		return Snapshot{}, err
+	}
+
+	return snapshot, nil
+}
+
+// exportRelated добавляет в снапшот все, что хранится рядом с задачами
+func (uc ExportBackup) exportRelated(ctx context.Context, snapshot *Snapshot) error {
+	snapshot.Board = SnapshotBoard{
+		Positions: map[domain.BoardGrouping]map[string]int{},
+		WIPLimits: map[domain.BoardGrouping]map[string]int{},
+	}
+	for _, grouping := range domain.BoardGroupings {
+		positions, err := uc.repo.Board().Positions(ctx, grouping)
+		if err != nil {
+			return fmt.Errorf("get board positions: %w", err)
+		}
+		if len(positions) > 0 {
+			snapshot.Board.Positions[grouping] = positions
+		}
+
+		limits, err := uc.repo.Board().WIPLimits(ctx, grouping)
+		if err != nil {
+			return fmt.Errorf("get wip limits: %w", err)
+		}
+		if len(limits) > 0 {
+			snapshot.Board.WIPLimits[grouping] = limits
+		}
+	}
+
+	deps, err := uc.repo.Dependencies().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get dependencies: %w", err)
+	}
+	for _, d := range deps {
+		snapshot.Dependencies = append(snapshot.Dependencies, SnapshotDependency{TaskID: d.TaskID, BlockedByID: d.BlockedByID})
+	}
+
+	entries, err := uc.repo.TimeEntries().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get time entries: %w", err)
+	}
+	for _, e := range entries {
+		snapshot.TimeEntries = append(snapshot.TimeEntries, SnapshotTimeEntry{
+			ID:        e.ID,
+			TaskID:    e.TaskID,
+			StartedAt: e.StartedAt,
+			EndedAt:   e.EndedAt,
+			Note:      e.Note,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		})
+	}
+
+	sessions, err := uc.repo.FocusSessions().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get focus sessions: %w", err)
+	}
+	for _, fs := range sessions {
+		snapshot.FocusSessions = append(snapshot.FocusSessions, SnapshotFocusSession{
+			ID:             fs.ID,
+			TaskID:         fs.TaskID,
+			StartedAt:      fs.StartedAt,
+			EndedAt:        fs.EndedAt,
+			PlannedSeconds: int64(fs.Planned / time.Second),
+			Completed:      fs.Completed,
+		})
+	}
+
+	items, err := uc.repo.Checklist().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get checklist: %w", err)
+	}
+	for _, item := range items {
+		snapshot.Checklist = append(snapshot.Checklist, SnapshotChecklistItem{
+			ID:        item.ID,
+			TaskID:    item.TaskID,
+			Title:     item.Title,
+			Checked:   item.Checked,
+			CheckedAt: item.CheckedAt,
+			Position:  item.Position,
+			CreatedAt: item.CreatedAt,
+		})
+	}
+
+	attachments, err := uc.repo.Attachments().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get attachments: %w", err)
+	}
+	for _, a := range attachments {
+		snapshot.Attachments = append(snapshot.Attachments, SnapshotAttachment{
+			ID:        a.ID,
+			TaskID:    a.TaskID,
+			Name:      a.Name,
+			MimeType:  a.MimeType,
+			Size:      a.Size,
+			Hash:      a.Hash,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+
+	comments, err := uc.repo.Comments().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get comments: %w", err)
+	}
+	for _, c := range comments {
+		snapshot.Comments = append(snapshot.Comments, SnapshotComment{
+			ID:        c.ID,
+			TaskID:    c.TaskID,
+			Author:    c.Author,
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+
+	activity, err := uc.repo.Activity().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get activity: %w", err)
+	}
+	for _, a := range activity {
+		snapshot.Activity = append(snapshot.Activity, SnapshotActivity{
+			TaskID:     a.TaskID,
+			EventType:  a.EventType,
+			Snapshot:   a.Snapshot,
+			OccurredAt: a.OccurredAt,
+		})
+	}
+
+	hooks, err := uc.repo.Webhooks().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get webhooks: %w", err)
+	}
+	for _, h := range hooks {
+		snapshot.Webhooks = append(snapshot.Webhooks, SnapshotWebhook{
+			ID:         h.ID,
+			URL:        h.URL,
+			EventTypes: h.EventTypes,
+			Filter:     h.Filter,
+			Secret:     h.Secret,
+			Enabled:    h.Enabled,
+			CreatedAt:  h.CreatedAt,
+			UpdatedAt:  h.UpdatedAt,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// Поля задачи, доступные для CSV
+const (
+	CSVFieldID        = "id"
+	CSVFieldTitle     = "title"
+	CSVFieldStatus    = "status"
+	CSVFieldPriority  = "priority"
+	CSVFieldCreatedAt = "created_at"
+	CSVFieldDueDate   = "due_date"
+	CSVFieldProject   = "project"
+	CSVFieldTags      = "tags" // теги через запятую
+
+	// CSVFieldCustomPrefix - колонка пользовательского поля: "field:<ключ>"
+	CSVFieldCustomPrefix = "field:"
+)
+
+const DefaultCSVDateFormat = "2006-01-02 15:04"
+
+var csvFields = []string{
+	CSVFieldID,
+	CSVFieldTitle,
+	CSVFieldStatus,
+	CSVFieldPriority,
+	CSVFieldCreatedAt,
+	CSVFieldDueDate,
+	CSVFieldProject,
+	CSVFieldTags,
+}
+
+var (
+	ErrUnknownCSVField = errors.New("unknown csv field")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+)
+
+func isCSVField(field string) bool {
+	for _, f := range csvFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// isCSVColumn - поле задачи или описанное пользовательское поле
+func isCSVColumn(column string, fields []domain.CustomField) bool {
+	if key, ok := strings.CutPrefix(column, CSVFieldCustomPrefix); ok {
+		_, found := domain.LookupCustomField(fields, key)
+		return found
+	}
+	return isCSVField(column)
+}
+
+// csvColumns - колонки по умолчанию: поля задачи и затем все пользовательские поля
+func csvColumns(fields []domain.CustomField) []string {
+	columns := append([]string{}, csvFields...)
+	for _, f := range fields {
+		columns = append(columns, CSVFieldCustomPrefix+f.Key)
+	}
+	return columns
+}
+
+// csvFormat - часовой пояс и формат дат, общие для экспорта и импорта
+type csvFormat struct {
+	loc    *time.Location
+	layout string
+}
+
+func newCSVFormat(timezone, dateFormat string) (csvFormat, error) {
+	f := csvFormat{loc: time.Local, layout: dateFormat}
+	if f.layout == "" {
+		f.layout = DefaultCSVDateFormat
+	}
+
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return csvFormat{}, fmt.Errorf("%w: %s", ErrInvalidTimezone, timezone)
+		}
+		f.loc = loc
+	}
+
+	return f, nil
+}
+
+func (f csvFormat) formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(f.loc).Format(f.layout)
+}
+
+func (f csvFormat) parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(f.layout, value, f.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidDate, value)
+	}
+	return &t, nil
+}
+
+type ExportCSV struct {
+	repo      domain.TaskRepository
+	listTasks ListTasks
+}
+
+func NewExportCSV(repo domain.TaskRepository) ExportCSV {
+	return ExportCSV{repo: repo, listTasks: NewListTasks(repo)}
+}
+
+type ExportCSVInput struct {
+	ListTasksInput
+	Columns    []string `json:"columns,omitempty"`  // по умолчанию все поля, включая пользовательские
+	Timezone   string   `json:"timezone,omitempty"` // IANA, по умолчанию локальный
+	DateFormat string   `json:"date_format,omitempty"`
+}
+
+type ExportCSVOutput struct {
+	Data  string `json:"data"`
+	Total int    `json:"total"`
+}
+
+func (uc ExportCSV) Execute(ctx context.Context, in ExportCSVInput) (ExportCSVOutput, error) {
+	fields, err := uc.repo.CustomFields().List(ctx)
+	if err != nil {
+		return ExportCSVOutput{}, fmt.Errorf("get custom fields: %w", err)
+	}
+
+	columns := in.Columns
+	if len(columns) == 0 {
+		columns = csvColumns(fields)
+	}
+	for _, column := range columns {
+		if !isCSVColumn(column, fields) {
+			return ExportCSVOutput{}, fmt.Errorf("%w: %s", ErrUnknownCSVField, column)
+		}
+	}
+
+	format, err := newCSVFormat(in.Timezone, in.DateFormat)
+	if err != nil {
+		return ExportCSVOutput{}, err
+	}
+
+	list, err := uc.listTasks.Execute(ctx, in.ListTasksInput)
+	if err != nil {
+		return ExportCSVOutput{}, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(columns); err != nil {
+		return ExportCSVOutput{}, fmt.Errorf("write header: %w", err)
+	}
+
+	record := make([]string, len(columns))
+	for _, task := range list.Tasks {
+		for i, column := range columns {
+			record[i] = csvValue(task, column, format)
+		}
+		if err := w.Write(record); err != nil {
+			return ExportCSVOutput{}, fmt.Errorf("write task %s: %w", task.ID, err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return ExportCSVOutput{}, fmt.Errorf("flush csv: %w", err)
+	}
+
+	return ExportCSVOutput{Data: buf.String(), Total: list.Total}, nil
+}
+
+func csvValue(task *domain.Task, field string, format csvFormat) string {
+	if key, ok := strings.CutPrefix(field, CSVFieldCustomPrefix); ok {
+		return formatCustomValue(task.CustomFields[key])
+	}
+
+	switch field {
+	case CSVFieldID:
+		return task.ID
+	case CSVFieldTitle:
+		return task.Title
+	case CSVFieldStatus:
+		return string(task.Status)
+	case CSVFieldPriority:
+		return string(task.Priority)
+	case CSVFieldCreatedAt:
+		return format.formatTime(&task.CreatedAt)
+	case CSVFieldDueDate:
+		return format.formatTime(task.DueDate)
+	case CSVFieldProject:
+		return task.Project
+	case CSVFieldTags:
+		return strings.Join(task.Tags, ", ")
+	}
+	return ""
+}
+
+// formatCustomValue - значение пользовательского поля в том виде, в каком его примет импорт
+func formatCustomValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// Группировка задач в Markdown
+const (
+	GroupByNone     = ""
+	GroupByProject  = "project"
+	GroupByPriority = "priority"
+)
+
+const (
+	markdownDateLayout     = "2006-01-02"
+	markdownDateTimeLayout = "2006-01-02 15:04"
+	markdownIndent         = "  "
+)
+
+var ErrInvalidGroupBy = errors.New("invalid group by")
+
+var priorityOrder = []domain.Priority{
+	domain.PriorityHigh,
+	domain.PriorityMedium,
+	domain.PriorityLow,
+}
+
+type ExportMarkdown struct {
+	repo      domain.TaskRepository
+	listTasks ListTasks
+}
+
+func NewExportMarkdown(repo domain.TaskRepository) ExportMarkdown {
+	return ExportMarkdown{repo: repo, listTasks: NewListTasks(repo)}
+}
+
+type ExportMarkdownInput struct {
+	ListTasksInput
+	GroupBy string `json:"group_by,omitempty"` // "project", "priority" или пусто
+}
+
+type ExportMarkdownOutput struct {
+	Data  string `json:"data"`
+	Total int    `json:"total"`
+}
+
+func (uc ExportMarkdown) Execute(ctx context.Context, in ExportMarkdownInput) (ExportMarkdownOutput, error) {
+	if in.GroupBy != GroupByNone && in.GroupBy != GroupByProject && in.GroupBy != GroupByPriority {
+		return ExportMarkdownOutput{}, fmt.Errorf("%w: %s", ErrInvalidGroupBy, in.GroupBy)
+	}
+
+	list, err := uc.listTasks.Execute(ctx, in.ListTasksInput)
+	if err != nil {
+		return ExportMarkdownOutput{}, err
+	}
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return ExportMarkdownOutput{}, err
+	}
+
+	return ExportMarkdownOutput{
+		Data:  RenderMarkdown(list.Tasks, in.GroupBy, w),
+		Total: list.Total,
+	}, nil
+}
+
+// RenderMarkdown рендерит задачи как GitHub-чеклист.
+// Подзадачи, чей родитель есть в списке, выводятся с отступом под ним.
+// Отмечены [x] задачи в закрытых статусах workflow.
+func RenderMarkdown(tasks []*domain.Task, groupBy string, w domain.Workflow) string {
+	inList := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		inList[task.ID] = true
+	}
+
+	children := make(map[string][]*domain.Task)
+	roots := make([]*domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.ParentID != nil && inList[*task.ParentID] {
+			children[*task.ParentID] = append(children[*task.ParentID], task)
+			continue
+		}
+		roots = append(roots, task)
+	}
+
+	var b strings.Builder
+	// implied - приоритет, который парсер назначит пункту без пометки
+	writeTree := func(list []*domain.Task, implied domain.Priority) {
+		for _, task := range list {
+			writeMarkdownTask(&b, task, children, 0, implied, w)
+		}
+	}
+
+	switch groupBy {
+	case GroupByProject:
+		groups := make(map[string][]*domain.Task)
+		for _, task := range roots {
+			groups[task.Project] = append(groups[task.Project], task)
+		}
+
+		// Задачи без проекта идут первыми, без заголовка
+		writeTree(groups[""], domain.PriorityMedium)
+
+		projects := make([]string, 0, len(groups))
+		for project := range groups {
+			if project != "" {
+				projects = append(projects, project)
+			}
+		}
+		sort.Strings(projects)
+
+		for _, project := range projects {
+			writeMarkdownHeading(&b, project)
+			writeTree(groups[project], domain.PriorityMedium)
+		}
+
+	case GroupByPriority:
+		groups := make(map[domain.Priority][]*domain.Task)
+		for _, task := range roots {
+			groups[task.Priority] = append(groups[task.Priority], task)
+		}
+
+		for _, priority := range priorityOrder {
+			if len(groups[priority]) == 0 {
+				continue
+			}
+			writeMarkdownHeading(&b, priorityHeading(priority))
+			writeTree(groups[priority], priority)
+		}
+
+	default:
+		writeTree(roots, domain.PriorityMedium)
+	}
+
+	return b.String()
+}
+
+func writeMarkdownHeading(b *strings.Builder, title string) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("## " + title + "\n\n")
+}
+
+func writeMarkdownTask(b *strings.Builder, task *domain.Task, children map[string][]*domain.Task, depth int, implied domain.Priority, w domain.Workflow) {
+	b.WriteString(strings.Repeat(markdownIndent, depth))
+
+	if task.IsClosed(w) {
+		b.WriteString("- [x] ")
+	} else {
+		b.WriteString("- [ ] ")
+	}
+	b.WriteString(task.Title)
+
+	// Приоритет по умолчанию или из заголовка группы не пишем; подзадачи
+	// с другим приоритетом под заголовком группы получают пометку
+	if task.Priority != implied {
+		b.WriteString(" !" + string(task.Priority))
+	}
+
+	if task.DueDate != nil {
+		b.WriteString(" (due: " + formatMarkdownDate(*task.DueDate) + ")")
+	}
+	b.WriteString("\n")
+
+	for _, child := range children[task.ID] {
+		writeMarkdownTask(b, child, children, depth+1, implied, w)
+	}
+}
+
+func priorityHeading(priority domain.Priority) string {
+	p := string(priority)
+	return strings.ToUpper(p[:1]) + p[1:] + " priority"
+}
+
+func formatMarkdownDate(t time.Time) string {
+	t = t.Local()
+	if t.Hour() == 0 && t.Minute() == 0 {
+		return t.Format(markdownDateLayout)
+	}
+	return t.Format(markdownDateTimeLayout)
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// За сколько дней дашборд показывает статистику фокуса
+const focusStatsDays = 7
+
+// LogFocusSession пишет рабочую фазу помидора в журнал
+type LogFocusSession struct {
+	repo domain.TaskRepository
+}
+
+func NewLogFocusSession(repo domain.TaskRepository) LogFocusSession {
+	return LogFocusSession{repo: repo}
+}
+
+// Execute пропускает сессию, если задачу удалили, пока шел помидор
+func (uc LogFocusSession) Execute(ctx context.Context, session *domain.FocusSession) error {
+	return uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if _, err := repo.GetByID(ctx, session.TaskID); err != nil {
+			if errors.Is(err, domain.ErrTaskNotFound) {
+				return nil
+			}
+			return fmt.Errorf("get task: %w", err)
+		}
+
+		if err := repo.FocusSessions().Add(ctx, session); err != nil {
+			return fmt.Errorf("add focus session: %w", err)
+		}
+		return nil
+	})
+}
+
+// PomodoroState - снимок цикла для UI; Previous заполнен при смене фазы
+type PomodoroState struct {
+	TaskID           string               `json:"task_id,omitempty"`
+	Phase            domain.PomodoroPhase `json:"phase"`
+	Previous         domain.PomodoroPhase `json:"previous,omitempty"`
+	Cycle            int                  `json:"cycle"`
+	StartedAt        *time.Time           `json:"started_at,omitempty"`
+	EndsAt           *time.Time           `json:"ends_at,omitempty"`
+	RemainingSeconds int64                `json:"remaining_seconds"`
+}
+
+type FocusStats struct {
+	TodaySeconds  int64            `json:"today_seconds"`
+	TodaySessions int              `json:"today_sessions"` // только завершенные помидоры
+	ByDay         []FocusDayStats  `json:"by_day"`
+	ByTask        []FocusTaskStats `json:"by_task"`
+}
+
+type FocusDayStats struct {
+	Date     string `json:"date"` // 2006-01-02
+	Seconds  int64  `json:"seconds"`
+	Sessions int    `json:"sessions"`
+}
+
+type FocusTaskStats struct {
+	TaskID   string `json:"task_id"`
+	Title    string `json:"title"`
+	Seconds  int64  `json:"seconds"`
+	Sessions int    `json:"sessions"`
+}
+
+// getFocusStats - фокус за последние focusStatsDays дней, включая сегодня
+func getFocusStats(ctx context.Context, repo domain.TaskRepository, tasks []*domain.Task, now time.Time) (FocusStats, error) {
+	today := startOfDay(now)
+	from := today.AddDate(0, 0, -(focusStatsDays - 1))
+
+	sessions, err := repo.FocusSessions().ListBetween(ctx, from, today.AddDate(0, 0, 1))
+	if err != nil {
+		return FocusStats{}, err
+	}
+
+	stats := FocusStats{ByDay: make([]FocusDayStats, 0, focusStatsDays)}
+	dayIndex := make(map[string]int, focusStatsDays)
+	for day := from; !day.After(today); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		dayIndex[key] = len(stats.ByDay)
+		stats.ByDay = append(stats.ByDay, FocusDayStats{Date: key})
+	}
+
+	titles := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		titles[task.ID] = task.Title
+	}
+	// Названия задач не из списка (например, уже закрытых) догружаются по одной
+	for _, s := range sessions {
+		if _, ok := titles[s.TaskID]; ok {
+			continue
+		}
+		task, err := repo.GetByID(ctx, s.TaskID)
+		switch {
+		case err == nil:
+			titles[s.TaskID] = task.Title
+		case errors.Is(err, domain.ErrTaskNotFound):
+			titles[s.TaskID] = ""
+		default:
+			return FocusStats{}, err
+		}
+	}
+
+	byTask := map[string]*FocusTaskStats{}
+	for _, s := range sessions {
+		seconds := int64(s.Duration() / time.Second)
+		completed := 0
+		if s.Completed {
+			completed = 1
+		}
+
+		if i, ok := dayIndex[s.StartedAt.Format("2006-01-02")]; ok {
+			stats.ByDay[i].Seconds += seconds
+			stats.ByDay[i].Sessions += completed
+		}
+
+		t, ok := byTask[s.TaskID]
+		if !ok {
+			t = &FocusTaskStats{TaskID: s.TaskID, Title: titles[s.TaskID]}
+			byTask[s.TaskID] = t
+		}
+		t.Seconds += seconds
+		t.Sessions += completed
+	}
+
+	last := stats.ByDay[len(stats.ByDay)-1]
+	stats.TodaySeconds = last.Seconds
+	stats.TodaySessions = last.Sessions
+
+	stats.ByTask = make([]FocusTaskStats, 0, len(byTask))
+	for _, t := range byTask {
+		stats.ByTask = append(stats.ByTask, *t)
+	}
+	sort.Slice(stats.ByTask, func(i, j int) bool {
+		if stats.ByTask[i].Seconds != stats.ByTask[j].Seconds {
+			return stats.ByTask[i].Seconds > stats.ByTask[j].Seconds
+		}
+		return stats.ByTask[i].TaskID < stats.ByTask[j].TaskID
+	})
+
+	return stats, nil
+}
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const defaultStaleDays = 14
+
+// GenerateReview собирает обзор недели: что закрыто, что просрочено,
+// что давно не менялось и что ждет на следующей неделе. Неделя начинается в воскресенье.
+type GenerateReview struct {
+	repo      domain.TaskRepository
+	staleDays int
+}
+
+func NewGenerateReview(repo domain.TaskRepository, staleDays int) GenerateReview {
+	if staleDays <= 0 {
+		staleDays = defaultStaleDays
+	}
+	return GenerateReview{repo: repo, staleDays: staleDays}
+}
+
+type GenerateReviewInput struct {
+	Date      time.Time `json:"date"`                 // любой день недели обзора, по умолчанию - сегодня
+	StaleDays int       `json:"stale_days,omitempty"` // по умолчанию из настроек
+}
+
+type GenerateReviewOutput struct {
+	WeekStart   time.Time      `json:"week_start"`
+	WeekEnd     time.Time      `json:"week_end"` // не включительно
+	StaleDays   int            `json:"stale_days"`
+	Completed   []*domain.Task `json:"completed"`
+	Slipped     []*domain.Task `json:"slipped"` // открытые с прошедшим сроком
+	Stale       []*domain.Task `json:"stale"`   // открытые без изменений StaleDays дней
+	DueNextWeek []*domain.Task `json:"due_next_week"`
+	Markdown    string         `json:"markdown"`
+	HTML        string         `json:"html"`
+}
+
+func (uc GenerateReview) Execute(ctx context.Context, in GenerateReviewInput) (GenerateReviewOutput, error) {
+	now := time.Now()
+	date := in.Date
+	if date.IsZero() {
+		date = now
+	}
+	staleDays := in.StaleDays
+	if staleDays <= 0 {
+		staleDays = uc.staleDays
+	}
+
+	out := GenerateReviewOutput{
+		WeekStart: startOfWeek(date),
+		StaleDays: staleDays,
+	}
+	out.WeekEnd = out.WeekStart.AddDate(0, 0, 7)
+
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return GenerateReviewOutput{}, err
+	}
+
+	if out.Completed, err = uc.repo.GetCompletedBetween(ctx, out.WeekStart, out.WeekEnd); err != nil {
+		return GenerateReviewOutput{}, fmt.Errorf("get completed tasks: %w", err)
+	}
+
+	open, err := uc.repo.GetByStatus(ctx, w.InCategory(domain.CategoryOpen)...)
+	if err != nil {
+		return GenerateReviewOutput{}, fmt.Errorf("get open tasks: %w", err)
+	}
+
+	// Для прошлых недель "сейчас" - конец той недели
+	at := minTime(now, out.WeekEnd)
+	staleBefore := at.AddDate(0, 0, -staleDays)
+	nextWeekEnd := out.WeekEnd.AddDate(0, 0, 7)
+
+	out.Slipped, out.Stale, out.DueNextWeek = []*domain.Task{}, []*domain.Task{}, []*domain.Task{}
+	for _, task := range open {
+		switch {
+		case task.DueDate == nil:
+		case task.DueDate.Before(at):
+			out.Slipped = append(out.Slipped, task)
+		case !task.DueDate.Before(out.WeekEnd) && task.DueDate.Before(nextWeekEnd):
+			out.DueNextWeek = append(out.DueNextWeek, task)
+		}
+		if task.UpdatedAt.Before(staleBefore) {
+			out.Stale = append(out.Stale, task)
+		}
+	}
+
+	sortByDue(out.Slipped)
+	sortByDue(out.DueNextWeek)
+	sort.SliceStable(out.Stale, func(i, j int) bool { return out.Stale[i].UpdatedAt.Before(out.Stale[j].UpdatedAt) })
+
+	out.Markdown = renderReviewMarkdown(out, at)
+	if out.HTML, err = renderReviewHTML(out, at); err != nil {
+		return GenerateReviewOutput{}, err
+	}
+	return out, nil
+}
+
+func sortByDue(tasks []*domain.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(*tasks[j].DueDate) })
+}
+
+// reviewLine - строка задачи в обзоре, общая для Markdown и HTML
+type reviewLine struct {
+	Title  string
+	Detail string
+}
+
+type reviewSection struct {
+	Title string
+	Lines []reviewLine
+}
+
+func reviewSections(out GenerateReviewOutput, at time.Time) []reviewSection {
+	line := func(task *domain.Task, detail string) reviewLine {
+		var parts []string
+		if task.Project != "" {
+			parts = append(parts, task.Project)
+		}
+		if task.Priority != domain.PriorityMedium {
+			parts = append(parts, string(task.Priority))
+		}
+		parts = append(parts, detail)
+		return reviewLine{Title: task.Title, Detail: strings.Join(parts, " · ")}
+	}
+
+	sections := []reviewSection{
+		{Title: fmt.Sprintf("Completed (%d)", len(out.Completed))},
+		{Title: fmt.Sprintf("Slipped (%d)", len(out.Slipped))},
+		{Title: fmt.Sprintf("Untouched for %d+ days (%d)", out.StaleDays, len(out.Stale))},
+		{Title: fmt.Sprintf("Due next week (%d)", len(out.DueNextWeek))},
+	}
+	for _, task := range out.Completed {
+		sections[0].Lines = append(sections[0].Lines, line(task, "done "+formatMarkdownDate(*task.CompletedAt)))
+	}
+	for _, task := range out.Slipped {
+		late := int(at.Sub(*task.DueDate).Hours() / 24)
+		sections[1].Lines = append(sections[1].Lines, line(task, fmt.Sprintf("due %s, %d days late", formatMarkdownDate(*task.DueDate), late)))
+	}
+	for _, task := range out.Stale {
+		sections[2].Lines = append(sections[2].Lines, line(task, "last change "+formatMarkdownDate(task.UpdatedAt)))
+	}
+	for _, task := range out.DueNextWeek {
+		sections[3].Lines = append(sections[3].Lines, line(task, "due "+formatMarkdownDate(*task.DueDate)))
+	}
+	return sections
+}
+
+func reviewTitle(out GenerateReviewOutput) string {
+	return fmt.Sprintf("Weekly review: %s – %s",
+		out.WeekStart.Format(markdownDateLayout), out.WeekEnd.AddDate(0, 0, -1).Format(markdownDateLayout))
+}
+
+func renderReviewMarkdown(out GenerateReviewOutput, at time.Time) string {
+	var b strings.Builder
+	b.WriteString("# " + reviewTitle(out) + "\n")
+
+	for _, section := range reviewSections(out, at) {
+		writeMarkdownHeading(&b, section.Title)
+		if len(section.Lines) == 0 {
+			b.WriteString("_Nothing_\n")
+		}
+		for _, l := range section.Lines {
+			b.WriteString("- " + l.Title + " — " + l.Detail + "\n")
+		}
+	}
+	return b.String()
+}
+
+var reviewHTMLTemplate = template.Must(template.New("review").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 720px; margin: 2em auto; }
+.detail { color: #666; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Sections}}<h2>{{.Title}}</h2>
+{{if .Lines}}<ul>
+{{range .Lines}}<li>{{.Title}} <span class="detail">— {{.Detail}}</span></li>
+{{end}}</ul>
+{{else}}<p><em>Nothing</em></p>
+{{end}}{{end}}</body>
+</html>
+`))
+
+func renderReviewHTML(out GenerateReviewOutput, at time.Time) (string, error) {
+	var b strings.Builder
+	err := reviewHTMLTemplate.Execute(&b, struct {
+		Title    string
+		Sections []reviewSection
+	}{reviewTitle(out), reviewSections(out, at)})
+	if err != nil {
+		return "", fmt.Errorf("render html: %w", err)
+	}
+	return b.String(), nil
+}
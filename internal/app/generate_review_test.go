@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestGenerateReviewDigest(t *testing.T) {
+	at := func(month time.Month, day, hour, minute int) *time.Time {
+		t := time.Date(2026, month, day, hour, minute, 0, 0, time.Local)
+		return &t
+	}
+	open := func(id, title string, due *time.Time, updated *time.Time) *domain.Task {
+		return &domain.Task{ID: id, Title: title, Status: domain.StatusActive, Priority: domain.PriorityMedium, DueDate: due, UpdatedAt: *updated}
+	}
+	done := func(id, title string, completed *time.Time) *domain.Task {
+		return &domain.Task{ID: id, Title: title, Status: domain.StatusCompleted, Priority: domain.PriorityMedium, CompletedAt: completed, UpdatedAt: *completed}
+	}
+
+	repo := testutil.NewRepo()
+	ship := done("ship", "Ship", at(3, 3, 14, 0))
+	ship.Project, ship.Priority = "work", domain.PriorityHigh
+	archived := done("archived", "Archived", at(3, 7, 23, 0))
+	archived.ArchivedAt = at(3, 7, 23, 30)
+	taxes := open("taxes", "Taxes", at(2, 20, 18, 0), at(2, 1, 0, 0))
+	taxes.Project, taxes.Priority = "home", domain.PriorityLow
+	repo.Put(
+		ship, archived,
+		done("before", "Last week", at(2, 28, 23, 0)),
+		open("report", "Report", at(3, 5, 0, 0), at(3, 1, 9, 0)),
+		taxes,
+		open("idea", "Old idea", nil, at(2, 10, 12, 30)),
+		open("edge", "Edge", at(3, 8, 0, 0), at(3, 2, 0, 0)), // ровно конец недели обзора - уже следующая
+		open("demo", "Demo", at(3, 9, 10, 0), at(3, 2, 0, 0)),
+		open("far", "Far", at(3, 15, 0, 0), at(3, 2, 0, 0)),
+	)
+
+	out, err := NewGenerateReview(repo, 0).Execute(context.Background(), GenerateReviewInput{Date: *at(3, 4, 12, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# Weekly review: 2026-03-01 – 2026-03-07\n" +
+		"\n## Completed (2)\n\n" +
+		"- Ship — work · high · done 2026-03-03 14:00\n" +
+		"- Archived — done 2026-03-07 23:00\n" +
+		"\n## Slipped (2)\n\n" +
+		"- Taxes — home · low · due 2026-02-20 18:00, 15 days late\n" +
+		"- Report — due 2026-03-05, 3 days late\n" +
+		"\n## Untouched for 14+ days (2)\n\n" +
+		"- Taxes — home · low · last change 2026-02-01\n" +
+		"- Old idea — last change 2026-02-10 12:30\n" +
+		"\n## Due next week (2)\n\n" +
+		"- Edge — due 2026-03-08\n" +
+		"- Demo — due 2026-03-09 10:00\n"
+	if out.Markdown != want {
+		t.Fatalf("markdown:\n%s\nwant:\n%s", out.Markdown, want)
+	}
+	if !out.WeekStart.Equal(*at(3, 1, 0, 0)) || !out.WeekEnd.Equal(*at(3, 8, 0, 0)) || out.StaleDays != defaultStaleDays {
+		t.Fatalf("week %v - %v, stale days %d", out.WeekStart, out.WeekEnd, out.StaleDays)
+	}
+
+	// Порог давности из запроса важнее настроек
+	out, err = NewGenerateReview(repo, 0).Execute(context.Background(), GenerateReviewInput{Date: *at(3, 4, 12, 0), StaleDays: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Stale) != 1 || out.Stale[0].ID != "taxes" || !strings.Contains(out.Markdown, "## Untouched for 30+ days (1)") {
+		t.Fatalf("stale with 30 days = %+v", out.Stale)
+	}
+}
+
+func TestRenderReviewHTML(t *testing.T) {
+	weekStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	completed := weekStart.Add(34 * time.Hour)
+	out := GenerateReviewOutput{
+		WeekStart: weekStart,
+		WeekEnd:   weekStart.AddDate(0, 0, 7),
+		StaleDays: 14,
+		Completed: []*domain.Task{{Title: "Fix <b>bold</b> & co", Priority: domain.PriorityMedium, CompletedAt: &completed}},
+	}
+
+	html, err := renderReviewHTML(out, out.WeekEnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<title>Weekly review: 2026-03-01 – 2026-03-07</title>",
+		"<h2>Completed (1)</h2>",
+		`<li>Fix &lt;b&gt;bold&lt;/b&gt; &amp; co <span class="detail">— done 2026-03-02 10:00</span></li>`,
+		"<h2>Due next week (0)</h2>\n<p><em>Nothing</em></p>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html has no %q:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "<b>bold") {
+		t.Error("task title is not escaped")
+	}
+	if md := renderReviewMarkdown(out, out.WeekEnd); !strings.Contains(md, "## Slipped (0)\n\n_Nothing_\n") {
+		t.Errorf("markdown empty section:\n%s", md)
+	}
+}
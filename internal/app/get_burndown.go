@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const (
+	defaultBurndownPeriod = 14 * 24 * time.Hour
+	maxBurndownDays       = 366
+)
+
+// Единицы объема для burndown
+const (
+	BurnUnitTasks  = "tasks"
+	BurnUnitPoints = "points"
+)
+
+var ErrInvalidBurnUnit = errors.New("invalid burndown unit")
+
+// GetBurndown восстанавливает по дням объем работы: сколько задач существовало
+// к концу дня (created_at) и сколько из них было закрыто (completed_at).
+// Вновь открытая задача считается открытой за весь период.
+type GetBurndown struct {
+	repo domain.TaskRepository
+}
+
+func NewGetBurndown(repo domain.TaskRepository) GetBurndown {
+	return GetBurndown{repo: repo}
+}
+
+type GetBurndownInput struct {
+	From    time.Time `json:"from"` // по умолчанию - 14 дней до To
+	To      time.Time `json:"to"`   // по умолчанию - сейчас
+	Project *string   `json:"project,omitempty"`
+	Tag     string    `json:"tag,omitempty"`
+	Unit    string    `json:"unit,omitempty"`  // "tasks" (по умолчанию) или "points"
+	Ideal   bool      `json:"ideal,omitempty"` // добавить идеальную линию сгорания
+}
+
+// GetBurndownOutput - параллельные ряды, по точке на день. Open - для burndown,
+// Scope и Completed - для burnup.
+type GetBurndownOutput struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Unit      string    `json:"unit"`
+	Dates     []string  `json:"dates"` // 2006-01-02
+	Scope     []int     `json:"scope"`
+	Completed []int     `json:"completed"`
+	Open      []int     `json:"open"`
+	// Ideal - от открытого объема первого дня до нуля в последний день
+	Ideal []float64 `json:"ideal,omitempty"`
+}
+
+func (uc GetBurndown) Execute(ctx context.Context, in GetBurndownInput) (GetBurndownOutput, error) {
+	now := time.Now()
+	from, to := in.From, in.To
+	if to.IsZero() {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultBurndownPeriod)
+	}
+	if !from.Before(to) || to.Sub(from) > maxBurndownDays*24*time.Hour {
+		return GetBurndownOutput{}, domain.ErrInvalidTimeRange
+	}
+
+	unit := in.Unit
+	if unit == "" {
+		unit = BurnUnitTasks
+	}
+	if unit != BurnUnitTasks && unit != BurnUnitPoints {
+		return GetBurndownOutput{}, fmt.Errorf("%w: %s", ErrInvalidBurnUnit, in.Unit)
+	}
+
+	tasks, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return GetBurndownOutput{}, fmt.Errorf("get tasks: %w", err)
+	}
+	// Из архива нужны только закрытые внутри периода: закрытые раньше
+	// одинаково сдвигают объем и выполненное и на Open не влияют
+	completed, err := uc.repo.GetCompletedBetween(ctx, from, to)
+	if err != nil {
+		return GetBurndownOutput{}, fmt.Errorf("get completed tasks: %w", err)
+	}
+	for _, task := range completed {
+		if task.ArchivedAt != nil {
+			tasks = append(tasks, task)
+		}
+	}
+
+	filtered := tasks[:0:0]
+	for _, task := range tasks {
+		if in.Project != nil && task.Project != *in.Project {
+			continue
+		}
+		if in.Tag != "" && !task.HasTag(in.Tag) {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+
+	out := burndown(filtered, from, to, unit)
+	if in.Ideal {
+		out.Ideal = idealLine(out.Open)
+	}
+	return out, nil
+}
+
+func burndown(tasks []*domain.Task, from, to time.Time, unit string) GetBurndownOutput {
+	out := GetBurndownOutput{
+		From: from, To: to, Unit: unit,
+		Dates: []string{}, Scope: []int{}, Completed: []int{}, Open: []int{},
+	}
+
+	weight := func(task *domain.Task) int {
+		if unit == BurnUnitPoints {
+			return task.EstimatePoints
+		}
+		return 1
+	}
+
+	for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		// Состояние на конец дня, для последнего дня - на конец периода
+		end := minTime(day.AddDate(0, 0, 1), to)
+
+		var scope, completed int
+		for _, task := range tasks {
+			if task.CreatedAt.After(end) {
+				continue
+			}
+			scope += weight(task)
+			if task.CompletedAt != nil && !task.CompletedAt.After(end) {
+				completed += weight(task)
+			}
+		}
+
+		out.Dates = append(out.Dates, statsDayKey(day))
+		out.Scope = append(out.Scope, scope)
+		out.Completed = append(out.Completed, completed)
+		out.Open = append(out.Open, scope-completed)
+	}
+	return out
+}
+
+func idealLine(open []int) []float64 {
+	ideal := make([]float64, len(open))
+	if len(open) == 0 {
+		return ideal
+	}
+
+	start := float64(open[0])
+	steps := float64(len(open) - 1)
+	for i := range ideal {
+		if steps == 0 {
+			ideal[i] = start
+			continue
+		}
+		ideal[i] = start * (1 - float64(i)/steps)
+	}
+	return ideal
+}
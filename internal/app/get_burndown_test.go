@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func burndownTasks() []*domain.Task {
+	at := func(day, hour int) time.Time { return time.Date(2026, 3, day, hour, 0, 0, 0, time.Local) }
+	done := func(day, hour int) *time.Time { t := at(day, hour); return &t }
+	return []*domain.Task{
+		{ID: "t1", EstimatePoints: 2, CreatedAt: at(1, 10), CompletedAt: done(3, 12)},
+		{ID: "t2", EstimatePoints: 3, CreatedAt: at(2, 15)},
+		{ID: "t3", EstimatePoints: 1, CreatedAt: at(4, 9), CompletedAt: done(4, 10)},
+		{ID: "t4", EstimatePoints: 5, CreatedAt: at(1, 10)}, // открыта заново - открыта весь период
+	}
+}
+
+func TestBurndownSeries(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		unit                   string
+		scope, completed, open []int
+	}{
+		{BurnUnitTasks, []int{3, 3, 4}, []int{0, 1, 2}, []int{3, 2, 2}},
+		{BurnUnitPoints, []int{10, 10, 11}, []int{0, 2, 3}, []int{10, 8, 8}},
+	}
+	for _, tt := range tests {
+		out := burndown(burndownTasks(), from, to, tt.unit)
+		if !slices.Equal(out.Dates, []string{"2026-03-02", "2026-03-03", "2026-03-04"}) {
+			t.Fatalf("%s: dates = %v", tt.unit, out.Dates)
+		}
+		if !slices.Equal(out.Scope, tt.scope) || !slices.Equal(out.Completed, tt.completed) || !slices.Equal(out.Open, tt.open) {
+			t.Errorf("%s: scope %v, completed %v, open %v; want %v, %v, %v",
+				tt.unit, out.Scope, out.Completed, out.Open, tt.scope, tt.completed, tt.open)
+		}
+	}
+}
+
+func TestBurndownLastDayEndsAtPeriodEnd(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 3, 4, 9, 30, 0, 0, time.Local)
+
+	// t3 уже создана к 9:30, но закрыта только в 10:00
+	out := burndown(burndownTasks(), from, to, BurnUnitTasks)
+	if !slices.Equal(out.Open, []int{3, 2, 3}) {
+		t.Fatalf("open = %v, want [3 2 3]", out.Open)
+	}
+}
+
+func TestIdealLine(t *testing.T) {
+	if got := idealLine([]int{10, 8, 8}); !slices.Equal(got, []float64{10, 5, 0}) {
+		t.Errorf("ideal = %v, want [10 5 0]", got)
+	}
+	if got := idealLine([]int{4}); !slices.Equal(got, []float64{4}) {
+		t.Errorf("single day = %v, want [4]", got)
+	}
+	if got := idealLine(nil); len(got) != 0 {
+		t.Errorf("empty = %v", got)
+	}
+}
+
+func TestGetBurndownRejectsBadInput(t *testing.T) {
+	uc := NewGetBurndown(testutil.NewRepo())
+	now := time.Now()
+
+	if _, err := uc.Execute(context.Background(), GetBurndownInput{From: now, To: now.Add(-time.Hour)}); !errors.Is(err, domain.ErrInvalidTimeRange) {
+		t.Errorf("reversed range: err = %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), GetBurndownInput{From: now.AddDate(-2, 0, 0), To: now}); !errors.Is(err, domain.ErrInvalidTimeRange) {
+		t.Errorf("too long range: err = %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), GetBurndownInput{Unit: "hours"}); !errors.Is(err, ErrInvalidBurnUnit) {
+		t.Errorf("unknown unit: err = %v", err)
+	}
+}
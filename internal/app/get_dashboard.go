@@ -3,36 +3,79 @@ package app
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/w0ikid/dekstop-todo-app/internal/domain"
 )
 
 type GetDashboard struct {
-	repo domain.TaskRepository
+	repo     domain.TaskRepository
+	forecast ForecastSettings
 }
 
-func NewGetDashboard(repo domain.TaskRepository) GetDashboard {
-	return GetDashboard{repo: repo}
+func NewGetDashboard(repo domain.TaskRepository, forecast ForecastSettings) GetDashboard {
+	return GetDashboard{repo: repo, forecast: forecast}
 }
 
 type GetDashboardOutput struct {
 	ActiveCount    int            `json:"active_count"`
-	CompletedCount int            `json:"completed_count"`
+	CompletedCount int            `json:"completed_count"` // без архива
+	ArchivedCount  int            `json:"archived_count"`
 	OverdueCount   int            `json:"overdue_count"`
+	DeferredCount  int            `json:"deferred_count"` // открытые с датой начала в будущем
 	DueToday       []*domain.Task `json:"due_today"`
 	DueThisWeek    []*domain.Task `json:"due_this_week"`
 	RecentTasks    []*domain.Task `json:"recent_tasks"`
+	Focus          FocusStats     `json:"focus"`
+	Forecast       ForecastStats  `json:"forecast"`
+	ByStatus       []StatusCount  `json:"by_status"`
+}
+
+// StatusCount - число задач в статусе workflow, в порядке колонок
+type StatusCount struct {
+	Status   string `json:"status"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// ForecastStats - выжимка прогноза загрузки для дашборда
+type ForecastStats struct {
+	OverCapacityDays []ForecastDay  `json:"over_capacity_days"`
+	AtRisk           []ForecastTask `json:"at_risk"`
+	Unestimated      int            `json:"unestimated"`
 }
 
 func (uc GetDashboard) Execute(ctx context.Context) (GetDashboardOutput, error) {
-	activeTasks, err := uc.repo.GetByStatus(ctx, domain.StatusActive)
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return GetDashboardOutput{}, err
+	}
+
+	// Активные и завершенные - по категориям статусов workflow
+	activeTasks, err := uc.repo.GetByStatus(ctx, w.InCategory(domain.CategoryOpen)...)
 	if err != nil {
 		return GetDashboardOutput{}, fmt.Errorf("get active tasks: %w", err)
 	}
 
-	completedTasks, err := uc.repo.GetByStatus(ctx, domain.StatusCompleted)
+	// Закрытые задачи только считаются, без загрузки
+	counts, err := uc.repo.CountByStatus(ctx)
 	if err != nil {
-		return GetDashboardOutput{}, fmt.Errorf("get completed tasks: %w", err)
+		return GetDashboardOutput{}, fmt.Errorf("count tasks: %w", err)
+	}
+	var completedCount int
+	for _, status := range w.InCategory(domain.CategoryClosed) {
+		completedCount += counts[status]
+	}
+
+	byStatus := make([]StatusCount, 0, len(w.Statuses))
+	for _, s := range w.Sorted() {
+		byStatus = append(byStatus, StatusCount{
+			Status:   string(s.Key),
+			Name:     s.Name,
+			Category: string(s.Category),
+			Count:    counts[s.Key],
+		})
 	}
 
 	listUC := NewListTasks(uc.repo)
@@ -52,18 +95,53 @@ func (uc GetDashboard) Execute(ctx context.Context) (GetDashboardOutput, error)
 		return GetDashboardOutput{}, fmt.Errorf("get overdue: %w", err)
 	}
 
-	// Последние 5 активных задач
-	recent := activeTasks
-	if len(recent) > 5 {
-		recent = recent[:5]
+	archived, err := uc.repo.Archive().Count(ctx, domain.ArchiveFilter{})
+	if err != nil {
+		return GetDashboardOutput{}, fmt.Errorf("count archive: %w", err)
+	}
+
+	// Последние 5 активных задач, отложенные не показываем
+	now := time.Now()
+	var deferred int
+	recent := make([]*domain.Task, 0, 5)
+	for _, task := range activeTasks {
+		if task.IsDeferred(now) {
+			deferred++
+		} else if len(recent) < 5 {
+			recent = append(recent, task)
+		}
+	}
+
+	// Статистика помидоров по дням и задачам
+	focus, err := getFocusStats(ctx, uc.repo, activeTasks, now)
+	if err != nil {
+		return GetDashboardOutput{}, fmt.Errorf("get focus stats: %w", err)
+	}
+
+	// Перегруженные дни и задачи, которые не успеют к сроку
+	forecast := uc.forecast.forecast(activeTasks, now)
+	forecastStats := ForecastStats{
+		OverCapacityDays: []ForecastDay{},
+		AtRisk:           forecast.AtRisk,
+		Unestimated:      forecast.Unestimated,
+	}
+	for _, day := range forecast.Days {
+		if day.OverCapacity {
+			forecastStats.OverCapacityDays = append(forecastStats.OverCapacityDays, day)
+		}
 	}
 
 	return GetDashboardOutput{
 		ActiveCount:    len(activeTasks),
-		CompletedCount: len(completedTasks),
+		CompletedCount: completedCount,
+		ArchivedCount:  archived,
 		OverdueCount:   len(overdue),
+		DeferredCount:  deferred,
 		DueToday:       dueToday,
 		DueThisWeek:    dueWeek,
 		RecentTasks:    recent,
+		Focus:          focus,
+		Forecast:       forecastStats,
+		ByStatus:       byStatus,
 	}, nil
 }
\ No newline at end of file
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// GetDependencyGraph возвращает задачи со связями blocked_by для визуализации
+// и порядок, в котором их можно выполнять
+type GetDependencyGraph struct {
+	repo domain.TaskRepository
+}
+
+func NewGetDependencyGraph(repo domain.TaskRepository) GetDependencyGraph {
+	return GetDependencyGraph{repo: repo}
+}
+
+type DependencyNode struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority string `json:"priority"`
+	Blocked  bool   `json:"blocked"` // есть незавершенные блокеры
+}
+
+// DependencyEdge направлено от блокера к задаче, которая его ждет
+type DependencyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type GetDependencyGraphOutput struct {
+	Nodes []DependencyNode `json:"nodes"`
+	Edges []DependencyEdge `json:"edges"`
+	Order []string         `json:"order"` // топологический порядок: блокеры раньше
+}
+
+// Execute включает в граф только задачи, у которых есть связи
+func (uc GetDependencyGraph) Execute(ctx context.Context) (GetDependencyGraphOutput, error) {
+	deps, err := uc.repo.Dependencies().GetAll(ctx)
+	if err != nil {
+		return GetDependencyGraphOutput{}, fmt.Errorf("get dependencies: %w", err)
+	}
+	tasks, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return GetDependencyGraphOutput{}, fmt.Errorf("get tasks: %w", err)
+	}
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return GetDependencyGraphOutput{}, err
+	}
+
+	byID := make(map[string]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	graph := domain.NewDependencyGraph(deps)
+	out := GetDependencyGraphOutput{
+		Nodes: make([]DependencyNode, 0),
+		Edges: make([]DependencyEdge, 0, len(deps)),
+	}
+
+	inGraph := map[string]bool{}
+	var ids []string
+	for _, d := range deps {
+		for _, id := range []string{d.TaskID, d.BlockedByID} {
+			if !inGraph[id] {
+				inGraph[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	// GetAll не видит архив: архивные концы связей догружаются по одной,
+	// чтобы у каждого ребра были оба узла
+	nodeIDs := ids[:0:0]
+	for _, id := range ids {
+		if _, ok := byID[id]; !ok {
+			task, err := uc.repo.GetByID(ctx, id)
+			switch {
+			case err == nil:
+				byID[id] = task
+			case errors.Is(err, domain.ErrTaskNotFound):
+				inGraph[id] = false
+				continue
+			default:
+				return GetDependencyGraphOutput{}, fmt.Errorf("get task %s: %w", id, err)
+			}
+		}
+		nodeIDs = append(nodeIDs, id)
+	}
+
+	for _, d := range deps {
+		if inGraph[d.TaskID] && inGraph[d.BlockedByID] {
+			out.Edges = append(out.Edges, DependencyEdge{From: d.BlockedByID, To: d.TaskID})
+		}
+	}
+
+	for _, id := range nodeIDs {
+		task := byID[id]
+		out.Nodes = append(out.Nodes, DependencyNode{
+			ID:       task.ID,
+			Title:    task.Title,
+			Status:   string(task.Status),
+			Priority: string(task.Priority),
+			Blocked:  len(graph.OpenBlockers(task.ID, byID, w)) > 0,
+		})
+	}
+
+	out.Order = graph.TopologicalOrder(nodeIDs)
+	return out, nil
+}
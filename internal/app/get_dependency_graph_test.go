@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestDependencyGraphIncludesArchivedBlockers(t *testing.T) {
+	ctx := context.Background()
+	w := domain.DefaultWorkflow()
+	repo := testutil.NewRepo()
+	closedAt := time.Now().AddDate(0, -2, 0)
+	repo.Put(
+		&domain.Task{ID: "release", Title: "Release", Status: w.InitialStatus(), Priority: domain.PriorityHigh},
+		&domain.Task{ID: "spec", Title: "Spec", Status: w.DoneStatus(), Priority: domain.PriorityMedium, CompletedAt: &closedAt, ArchivedAt: &closedAt},
+		&domain.Task{ID: "tests", Title: "Tests", Status: w.InitialStatus(), Priority: domain.PriorityMedium},
+	)
+	repo.Deps = []domain.Dependency{
+		{TaskID: "release", BlockedByID: "spec"},
+		{TaskID: "release", BlockedByID: "tests"},
+		{TaskID: "release", BlockedByID: "ghost"}, // задачи уже нет
+	}
+
+	out, err := NewGetDependencyGraph(repo).Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes := map[string]DependencyNode{}
+	for _, n := range out.Nodes {
+		nodes[n.ID] = n
+	}
+	if len(nodes) != 3 || nodes["spec"].Title != "Spec" || !nodes["release"].Blocked {
+		t.Fatalf("nodes = %+v; want release, spec and tests with release blocked by tests", out.Nodes)
+	}
+	for _, e := range out.Edges {
+		if _, ok := nodes[e.From]; !ok {
+			t.Errorf("edge %s -> %s points outside the graph", e.From, e.To)
+		}
+	}
+	if len(out.Edges) != 2 {
+		t.Errorf("edges = %+v, want 2", out.Edges)
+	}
+	if !slices.Equal(out.Order, []string{"spec", "tests", "release"}) {
+		t.Errorf("order = %v", out.Order)
+	}
+}
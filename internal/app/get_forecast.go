@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// ForecastSettings - дневная емкость и правила перевода оценок во время
+type ForecastSettings struct {
+	DailyCapacity   time.Duration
+	PointDuration   time.Duration // сколько стоит один story point
+	DefaultEstimate time.Duration // для задач без оценки
+	Days            int           // горизонт прогноза
+}
+
+// GetForecast раскладывает активные задачи по ближайшим дням: раньше срок,
+// затем выше приоритет. Остаток оценки - оценка минус уже учтенное время.
+type GetForecast struct {
+	repo     domain.TaskRepository
+	settings ForecastSettings
+}
+
+func NewGetForecast(repo domain.TaskRepository, settings ForecastSettings) GetForecast {
+	return GetForecast{repo: repo, settings: settings}
+}
+
+type ForecastDay struct {
+	Date            time.Time `json:"date"`
+	CapacityMinutes int       `json:"capacity_minutes"`
+	PlannedMinutes  int       `json:"planned_minutes"`
+	DueMinutes      int       `json:"due_minutes"` // работа со сроком в этот день
+	// OverCapacity - работы со сроком до конца дня больше, чем емкости до него
+	OverCapacity bool     `json:"over_capacity"`
+	TaskIDs      []string `json:"task_ids"`
+}
+
+type ForecastTask struct {
+	ID               string     `json:"id"`
+	Title            string     `json:"title"`
+	Priority         string     `json:"priority"`
+	DueDate          *time.Time `json:"due_date,omitempty"`
+	RemainingMinutes int        `json:"remaining_minutes"`
+	Estimated        bool       `json:"estimated"` // false - взята оценка по умолчанию
+	Finish           time.Time  `json:"finish"`    // день, в который задача будет закончена
+	Late             bool       `json:"late"`      // Finish позже DueDate
+}
+
+type GetForecastOutput struct {
+	Days             []ForecastDay  `json:"days"`
+	Tasks            []ForecastTask `json:"tasks"`
+	AtRisk           []ForecastTask `json:"at_risk"`
+	OverCapacityDays int            `json:"over_capacity_days"`
+	Unestimated      int            `json:"unestimated"`
+}
+
+func (uc GetForecast) Execute(ctx context.Context) (GetForecastOutput, error) {
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return GetForecastOutput{}, err
+	}
+
+	tasks, err := uc.repo.GetByStatus(ctx, w.InCategory(domain.CategoryOpen)...)
+	if err != nil {
+		return GetForecastOutput{}, fmt.Errorf("get active tasks: %w", err)
+	}
+	return uc.settings.forecast(tasks, time.Now()), nil
+}
+
+func (s ForecastSettings) forecast(tasks []*domain.Task, now time.Time) GetForecastOutput {
+	today := startOfDay(now)
+	horizon := max(s.Days, 1)
+	capacity := s.DailyCapacity
+	if capacity <= 0 {
+		capacity = 8 * time.Hour
+	}
+
+	days := make([]ForecastDay, horizon)
+	for i := range days {
+		days[i] = ForecastDay{
+			Date:            today.AddDate(0, 0, i),
+			CapacityMinutes: int(capacity / time.Minute),
+			TaskIDs:         []string{},
+		}
+	}
+
+	queue := make([]*domain.Task, len(tasks))
+	copy(queue, tasks)
+	sort.SliceStable(queue, func(i, j int) bool {
+		a, b := queue[i], queue[j]
+		if (a.DueDate == nil) != (b.DueDate == nil) {
+			return a.DueDate != nil
+		}
+		if a.DueDate != nil {
+			if da, db := startOfDay(*a.DueDate), startOfDay(*b.DueDate); !da.Equal(db) {
+				return da.Before(db)
+			}
+		}
+		if ra, rb := priorityRank(a.Priority), priorityRank(b.Priority); ra != rb {
+			return ra > rb
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+
+	out := GetForecastOutput{Days: days, Tasks: make([]ForecastTask, 0, len(queue)), AtRisk: []ForecastTask{}}
+
+	// Жадно заполняем дни по порядку; за горизонтом дни считаются без вывода
+	day, used := 0, time.Duration(0)
+	for _, task := range queue {
+		effort, estimated := task.Effort(s.PointDuration)
+		if !estimated {
+			effort = s.DefaultEstimate
+			out.Unestimated++
+		}
+		remaining := max(effort-task.TrackedTime, 0)
+
+		ft := ForecastTask{
+			ID:               task.ID,
+			Title:            task.Title,
+			Priority:         string(task.Priority),
+			DueDate:          task.DueDate,
+			RemainingMinutes: int(remaining / time.Minute),
+			Estimated:        estimated,
+		}
+
+		for left := remaining; ; {
+			if used >= capacity {
+				day, used = day+1, 0
+			}
+			chunk := min(left, capacity-used)
+			used += chunk
+			left -= chunk
+			if day < horizon {
+				days[day].PlannedMinutes += int(chunk / time.Minute)
+				days[day].TaskIDs = append(days[day].TaskIDs, task.ID)
+			}
+			if left <= 0 {
+				break
+			}
+		}
+
+		ft.Finish = today.AddDate(0, 0, day)
+		if task.DueDate != nil {
+			due := startOfDay(*task.DueDate)
+			ft.Late = ft.Finish.After(due)
+
+			// Просроченная работа ложится на сегодня
+			dueDay := max(int((due.Sub(today)+12*time.Hour)/(24*time.Hour)), 0)
+			if dueDay < horizon {
+				days[dueDay].DueMinutes += int(remaining / time.Minute)
+			}
+		}
+
+		out.Tasks = append(out.Tasks, ft)
+		if ft.Late {
+			out.AtRisk = append(out.AtRisk, ft)
+		}
+	}
+
+	var dueTotal, capacityTotal int
+	for i := range days {
+		dueTotal += days[i].DueMinutes
+		capacityTotal += days[i].CapacityMinutes
+		if dueTotal > capacityTotal {
+			days[i].OverCapacity = true
+			out.OverCapacityDays++
+		}
+	}
+
+	return out
+}
+
+func priorityRank(priority domain.Priority) int {
+	switch priority {
+	case domain.PriorityHigh:
+		return 2
+	case domain.PriorityMedium:
+		return 1
+	}
+	return 0
+}
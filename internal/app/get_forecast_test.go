@@ -0,0 +1,87 @@
+package app
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+func TestForecastFillsDaysByDueDateAndPriority(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	day := func(offset int) *time.Time {
+		d := time.Date(2026, 3, 2+offset, 18, 0, 0, 0, time.Local)
+		return &d
+	}
+
+	tasks := []*domain.Task{
+		{ID: "c", Priority: domain.PriorityHigh}, // без срока и оценки - в конец, оценка по умолчанию
+		{ID: "a", Priority: domain.PriorityLow, DueDate: day(0), EstimateMinutes: 180, TrackedTime: time.Hour},
+		{ID: "b", Priority: domain.PriorityHigh, DueDate: day(1), EstimatePoints: 3},
+		{ID: "d", Priority: domain.PriorityHigh, DueDate: day(0), EstimateMinutes: 60},
+		{ID: "e", Priority: domain.PriorityMedium, DueDate: day(-1), EstimateMinutes: 30}, // просрочена
+	}
+	settings := ForecastSettings{
+		DailyCapacity:   4 * time.Hour,
+		PointDuration:   2 * time.Hour,
+		DefaultEstimate: time.Hour,
+		Days:            3,
+	}
+
+	out := settings.forecast(tasks, now)
+
+	var order []string
+	for _, ft := range out.Tasks {
+		order = append(order, ft.ID)
+	}
+	if !slices.Equal(order, []string{"e", "d", "a", "b", "c"}) {
+		t.Fatalf("order = %v", order)
+	}
+
+	wantDays := []struct {
+		planned, due int
+		over         bool
+		tasks        []string
+	}{
+		{240, 30 + 60 + 120, false, []string{"e", "d", "a", "b"}},
+		{240, 360, true, []string{"b"}}, // 570 минут к концу дня против 480 емкости
+		{150, 0, false, []string{"b", "c"}},
+	}
+	for i, want := range wantDays {
+		got := out.Days[i]
+		if got.PlannedMinutes != want.planned || got.DueMinutes != want.due || got.OverCapacity != want.over || !slices.Equal(got.TaskIDs, want.tasks) {
+			t.Errorf("day %d = planned %d, due %d, over %v, tasks %v; want %+v",
+				i, got.PlannedMinutes, got.DueMinutes, got.OverCapacity, got.TaskIDs, want)
+		}
+	}
+	if out.OverCapacityDays != 1 || out.Unestimated != 1 {
+		t.Errorf("over capacity days %d, unestimated %d; want 1 and 1", out.OverCapacityDays, out.Unestimated)
+	}
+
+	var atRisk []string
+	for _, ft := range out.AtRisk {
+		atRisk = append(atRisk, ft.ID)
+	}
+	if !slices.Equal(atRisk, []string{"e", "b"}) {
+		t.Errorf("at risk = %v, want [e b]", atRisk)
+	}
+	if a := out.Tasks[2]; a.RemainingMinutes != 120 || !a.Estimated {
+		t.Errorf("a remaining %d, estimated %v; want 120 minus tracked time", a.RemainingMinutes, a.Estimated)
+	}
+}
+
+func TestForecastBeyondHorizon(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	tasks := []*domain.Task{{ID: "big", Priority: domain.PriorityMedium, EstimateMinutes: 600}}
+
+	out := ForecastSettings{DailyCapacity: 4 * time.Hour, Days: 1}.forecast(tasks, now)
+
+	if len(out.Days) != 1 || out.Days[0].PlannedMinutes != 240 {
+		t.Fatalf("days = %+v, want one full day", out.Days)
+	}
+	// Остаток уходит за горизонт: 10 часов по 4 - закончится на третий день
+	if want := startOfDay(now).AddDate(0, 0, 2); !out.Tasks[0].Finish.Equal(want) {
+		t.Fatalf("finish = %v, want %v", out.Tasks[0].Finish, want)
+	}
+}
@@ -0,0 +1,217 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const defaultStatsPeriod = 30 * 24 * time.Hour
+
+// GetStats - статистика завершенных задач за период: по дням и неделям, время выполнения,
+// доля закрытых в срок, разбивка по приоритетам и серии дней с закрытыми задачами
+type GetStats struct {
+	repo domain.TaskRepository
+}
+
+func NewGetStats(repo domain.TaskRepository) GetStats {
+	return GetStats{repo: repo}
+}
+
+type GetStatsInput struct {
+	From time.Time `json:"from"` // по умолчанию - 30 дней до To
+	To   time.Time `json:"to"`   // по умолчанию - сейчас
+}
+
+type StatsBucket struct {
+	Start     time.Time `json:"start"` // начало дня или недели (воскресенье)
+	Completed int       `json:"completed"`
+}
+
+type PriorityStats struct {
+	Priority         string   `json:"priority"`
+	Completed        int      `json:"completed"`
+	AvgLeadTimeHours float64  `json:"avg_lead_time_hours"`
+	OnTimeRate       *float64 `json:"on_time_rate,omitempty"` // nil - ни у одной задачи не было срока
+}
+
+type GetStatsOutput struct {
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Completed int           `json:"completed"`
+	PerDay    []StatsBucket `json:"per_day"`
+	PerWeek   []StatsBucket `json:"per_week"`
+	// Время от создания до закрытия
+	AvgLeadTimeHours float64         `json:"avg_lead_time_hours"`
+	WithDueDate      int             `json:"with_due_date"`
+	OnTime           int             `json:"on_time"`
+	OnTimeRate       *float64        `json:"on_time_rate,omitempty"`
+	ByPriority       []PriorityStats `json:"by_priority"`
+	// Серии считаются по всей истории: дни подряд, в которые закрыта хотя бы одна задача.
+	// Текущая серия не прерывается, пока сегодня еще ничего не закрыто.
+	CurrentStreak int `json:"current_streak"`
+	LongestStreak int `json:"longest_streak"`
+}
+
+func (uc GetStats) Execute(ctx context.Context, in GetStatsInput) (GetStatsOutput, error) {
+	now := time.Now()
+	from, to := in.From, in.To
+	if to.IsZero() {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultStatsPeriod)
+	}
+	if !from.Before(to) {
+		return GetStatsOutput{}, domain.ErrInvalidTimeRange
+	}
+
+	// Вся история нужна для серий
+	completed, err := uc.repo.GetCompletedBetween(ctx, time.Time{}, maxTime(to, now))
+	if err != nil {
+		return GetStatsOutput{}, fmt.Errorf("get completed tasks: %w", err)
+	}
+
+	return completionStats(completed, from, to, now), nil
+}
+
+func completionStats(completed []*domain.Task, from, to, now time.Time) GetStatsOutput {
+	out := GetStatsOutput{From: from, To: to, PerDay: []StatsBucket{}, PerWeek: []StatsBucket{}}
+
+	dayIndex := map[string]int{}
+	for day := startOfDay(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		dayIndex[statsDayKey(day)] = len(out.PerDay)
+		out.PerDay = append(out.PerDay, StatsBucket{Start: day})
+	}
+	weekIndex := map[string]int{}
+	for week := startOfWeek(from); week.Before(to); week = week.AddDate(0, 0, 7) {
+		weekIndex[statsDayKey(week)] = len(out.PerWeek)
+		out.PerWeek = append(out.PerWeek, StatsBucket{Start: week})
+	}
+
+	type acc struct {
+		count, withDue, onTime int
+		lead                   time.Duration
+	}
+	var total acc
+	priorities := map[domain.Priority]*acc{}
+	for _, p := range priorityOrder {
+		priorities[p] = &acc{}
+	}
+
+	days := map[string]bool{}
+	for _, task := range completed {
+		if task.CompletedAt == nil {
+			continue
+		}
+		at := *task.CompletedAt
+		days[statsDayKey(at)] = true
+		if at.Before(from) || !at.Before(to) {
+			continue
+		}
+
+		if i, ok := dayIndex[statsDayKey(at)]; ok {
+			out.PerDay[i].Completed++
+		}
+		if i, ok := weekIndex[statsDayKey(startOfWeek(at))]; ok {
+			out.PerWeek[i].Completed++
+		}
+
+		for _, a := range []*acc{&total, priorities[task.Priority]} {
+			if a == nil {
+				continue
+			}
+			a.count++
+			a.lead += max(at.Sub(task.CreatedAt), 0)
+			if task.DueDate != nil {
+				a.withDue++
+				if completedOnTime(task) {
+					a.onTime++
+				}
+			}
+		}
+	}
+
+	out.Completed = total.count
+	out.AvgLeadTimeHours = avgHours(total.lead, total.count)
+	out.WithDueDate, out.OnTime = total.withDue, total.onTime
+	out.OnTimeRate = rate(total.onTime, total.withDue)
+
+	for _, p := range priorityOrder {
+		a := priorities[p]
+		out.ByPriority = append(out.ByPriority, PriorityStats{
+			Priority:         string(p),
+			Completed:        a.count,
+			AvgLeadTimeHours: avgHours(a.lead, a.count),
+			OnTimeRate:       rate(a.onTime, a.withDue),
+		})
+	}
+
+	out.CurrentStreak, out.LongestStreak = streaks(days, now)
+	return out
+}
+
+// completedOnTime - закрыта не позже срока; срок без времени (00:00) действует до конца дня
+func completedOnTime(task *domain.Task) bool {
+	deadline := task.DueDate.Local()
+	if deadline.Hour() == 0 && deadline.Minute() == 0 && deadline.Second() == 0 {
+		deadline = deadline.AddDate(0, 0, 1)
+	} else {
+		deadline = deadline.Add(time.Nanosecond)
+	}
+	return task.CompletedAt.Before(deadline)
+}
+
+// streaks - текущая и самая длинная серия дней подряд
+func streaks(days map[string]bool, now time.Time) (current, longest int) {
+	has := func(day time.Time, offset int) bool {
+		return days[statsDayKey(day.AddDate(0, 0, offset))]
+	}
+
+	for key := range days {
+		day, _ := time.Parse(time.DateOnly, key)
+		if has(day, -1) {
+			continue // не начало серии
+		}
+		length := 1
+		for has(day, length) {
+			length++
+		}
+		longest = max(longest, length)
+	}
+
+	day := startOfDay(now)
+	if !has(day, 0) {
+		day = day.AddDate(0, 0, -1)
+	}
+	for has(day, -current) {
+		current++
+	}
+	return current, longest
+}
+
+// statsDayKey - день как строка: ключи не зависят от часового пояса значения
+func statsDayKey(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+func startOfWeek(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, -int(t.Weekday()))
+}
+
+func avgHours(total time.Duration, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return total.Hours() / float64(n)
+}
+
+func rate(part, whole int) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	r := float64(part) / float64(whole)
+	return &r
+}
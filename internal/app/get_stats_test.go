@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func completedOn(day time.Time, hour int) *domain.Task {
+	at := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.Local)
+	return &domain.Task{ID: at.Format(time.DateTime), Priority: domain.PriorityMedium, CreatedAt: at.Add(-time.Hour), CompletedAt: &at}
+}
+
+func TestStreaks(t *testing.T) {
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.Local)
+	day := func(offset int) time.Time { return now.AddDate(0, 0, offset) }
+
+	tests := []struct {
+		name             string
+		days             []time.Time
+		current, longest int
+	}{
+		{"nothing", nil, 0, 0},
+		{"today only", []time.Time{day(0)}, 1, 1},
+		// Сегодня еще ничего не закрыто - серия до вчера не прерывается
+		{"through yesterday", []time.Time{day(-1), day(-2)}, 2, 2},
+		{"gap yesterday", []time.Time{day(0), day(-2), day(-3), day(-4)}, 1, 3},
+		{"broken two days ago", []time.Time{day(-2), day(-3)}, 0, 2},
+		// 28 февраля -> 1 марта 2026 и 31 декабря -> 1 января
+		{"across month end", []time.Time{day(-2), day(-1), day(0)}, 3, 3},
+		{"across year end", []time.Time{
+			time.Date(2025, 12, 30, 12, 0, 0, 0, time.Local),
+			time.Date(2025, 12, 31, 12, 0, 0, 0, time.Local),
+			time.Date(2026, 1, 1, 12, 0, 0, 0, time.Local),
+			time.Date(2026, 1, 2, 12, 0, 0, 0, time.Local),
+		}, 0, 4},
+	}
+	for _, tt := range tests {
+		var tasks []*domain.Task
+		for _, d := range tt.days {
+			// Две задачи в один день серию не удлиняют
+			tasks = append(tasks, completedOn(d, 9), completedOn(d, 23))
+		}
+		out := completionStats(tasks, now.AddDate(0, 0, -30), now, now)
+		if out.CurrentStreak != tt.current || out.LongestStreak != tt.longest {
+			t.Errorf("%s: current %d, longest %d; want %d, %d", tt.name, out.CurrentStreak, out.LongestStreak, tt.current, tt.longest)
+		}
+	}
+}
+
+func TestCompletionStatsBoundaries(t *testing.T) {
+	// Среда 4 марта 2026; неделя начинается в воскресенье 1 марта
+	from := time.Date(2026, 2, 28, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local)
+	now := to.Add(10 * time.Hour)
+	at := func(day, hour, minute int) *time.Time {
+		t := time.Date(2026, 3, day, hour, minute, 0, 0, time.Local)
+		return &t
+	}
+
+	tasks := []*domain.Task{
+		{ID: "at-from", Priority: domain.PriorityHigh, CreatedAt: *at(-2, 0, 0), CompletedAt: &from},
+		{ID: "before-from", Priority: domain.PriorityHigh, CreatedAt: *at(-5, 0, 0), CompletedAt: at(-1, 23, 59)},
+		{ID: "at-to", Priority: domain.PriorityHigh, CreatedAt: *at(1, 0, 0), CompletedAt: &to},
+		// Срок без времени действует до конца дня
+		{ID: "date-due", Priority: domain.PriorityLow, CreatedAt: *at(1, 10, 0), CompletedAt: at(2, 23, 59), DueDate: at(2, 0, 0)},
+		// Срок со временем - до минуты
+		{ID: "late", Priority: domain.PriorityLow, CreatedAt: *at(2, 10, 0), CompletedAt: at(3, 14, 31), DueDate: at(3, 14, 30)},
+		{ID: "exact", Priority: domain.PriorityMedium, CreatedAt: *at(3, 8, 0), CompletedAt: at(3, 14, 30), DueDate: at(3, 14, 30)},
+		{ID: "open", Priority: domain.PriorityMedium, CreatedAt: *at(1, 0, 0)},
+	}
+
+	out := completionStats(tasks, from, to, now)
+
+	if out.Completed != 4 {
+		t.Fatalf("completed = %d, want 4: from is inclusive, to is exclusive", out.Completed)
+	}
+	wantDays := []int{1, 0, 1, 2} // 28 фев, 1, 2, 3 марта
+	if len(out.PerDay) != len(wantDays) {
+		t.Fatalf("per day = %+v", out.PerDay)
+	}
+	for i, want := range wantDays {
+		if out.PerDay[i].Completed != want {
+			t.Errorf("day %s: %d, want %d", statsDayKey(out.PerDay[i].Start), out.PerDay[i].Completed, want)
+		}
+	}
+	// Суббота 28 февраля - еще прошлая неделя
+	if len(out.PerWeek) != 2 || statsDayKey(out.PerWeek[0].Start) != "2026-02-22" ||
+		out.PerWeek[0].Completed != 1 || out.PerWeek[1].Completed != 3 {
+		t.Fatalf("per week = %+v", out.PerWeek)
+	}
+
+	if out.WithDueDate != 3 || out.OnTime != 2 || out.OnTimeRate == nil || *out.OnTimeRate != 2.0/3 {
+		t.Fatalf("on time %d of %d, rate %v", out.OnTime, out.WithDueDate, out.OnTimeRate)
+	}
+	// (48 + 37:59 + 28:31 + 6:30) / 4
+	if want := (48*60 + 37*60 + 59 + 28*60 + 31 + 6*60 + 30) / 60.0 / 4; out.AvgLeadTimeHours != want {
+		t.Fatalf("avg lead time = %v, want %v", out.AvgLeadTimeHours, want)
+	}
+
+	byPriority := map[string]PriorityStats{}
+	for _, p := range out.ByPriority {
+		byPriority[p.Priority] = p
+	}
+	if high := byPriority[string(domain.PriorityHigh)]; high.Completed != 1 || high.OnTimeRate != nil {
+		t.Errorf("high = %+v, want one task without due dates", high)
+	}
+	if low := byPriority[string(domain.PriorityLow)]; low.Completed != 2 || low.OnTimeRate == nil || *low.OnTimeRate != 0.5 {
+		t.Errorf("low = %+v, want half on time", low)
+	}
+	if medium := byPriority[string(domain.PriorityMedium)]; medium.Completed != 1 || medium.OnTimeRate == nil || *medium.OnTimeRate != 1 {
+		t.Errorf("medium = %+v, want closed exactly at the deadline on time", medium)
+	}
+}
+
+func TestGetStatsRejectsReversedRange(t *testing.T) {
+	now := time.Now()
+	_, err := NewGetStats(testutil.NewRepo()).Execute(context.Background(), GetStatsInput{From: now, To: now})
+	if !errors.Is(err, domain.ErrInvalidTimeRange) {
+		t.Fatalf("err = %v", err)
+	}
+}
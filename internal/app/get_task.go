@@ -20,7 +20,9 @@ type GetTaskInput struct {
 }
 
 type GetTaskOutput struct {
-	Task *domain.Task `json:"task"`
+	Task        *domain.Task            `json:"task"`
+	Checklist   []*domain.ChecklistItem `json:"checklist"`
+	Attachments []*domain.Attachment    `json:"attachments"`
 }
 
 func (uc GetTask) Execute(ctx context.Context, in GetTaskInput) (GetTaskOutput, error) {
@@ -29,5 +31,15 @@ func (uc GetTask) Execute(ctx context.Context, in GetTaskInput) (GetTaskOutput,
 		return GetTaskOutput{}, fmt.Errorf("get task: %w", err)
 	}
 
-	return GetTaskOutput{Task: task}, nil
+	checklist, err := uc.repo.Checklist().ListByTask(ctx, in.ID)
+	if err != nil {
+		return GetTaskOutput{}, fmt.Errorf("list checklist: %w", err)
+	}
+
+	attachments, err := uc.repo.Attachments().ListByTask(ctx, in.ID)
+	if err != nil {
+		return GetTaskOutput{}, fmt.Errorf("list attachments: %w", err)
+	}
+
+	return GetTaskOutput{Task: task, Checklist: checklist, Attachments: attachments}, nil
 }
\ No newline at end of file
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const defaultTimeReportPeriod = 7 * 24 * time.Hour
+
+// GetTimeReport суммирует учтенное время за период по дням, проектам, приоритетам и задачам.
+// Записи на границе периода или дня учитываются только своей частью.
+type GetTimeReport struct {
+	repo domain.TaskRepository
+}
+
+func NewGetTimeReport(repo domain.TaskRepository) GetTimeReport {
+	return GetTimeReport{repo: repo}
+}
+
+type GetTimeReportInput struct {
+	From time.Time `json:"from"` // по умолчанию - неделя до To
+	To   time.Time `json:"to"`   // по умолчанию - сейчас
+}
+
+type TimeReportRow struct {
+	Key     string `json:"key"` // день (2006-01-02), проект, приоритет или ID задачи
+	Label   string `json:"label,omitempty"`
+	Seconds int64  `json:"seconds"`
+}
+
+type GetTimeReportOutput struct {
+	From         time.Time       `json:"from"`
+	To           time.Time       `json:"to"`
+	TotalSeconds int64           `json:"total_seconds"`
+	ByDay        []TimeReportRow `json:"by_day"`
+	ByProject    []TimeReportRow `json:"by_project"`
+	ByPriority   []TimeReportRow `json:"by_priority"`
+	ByTask       []TimeReportRow `json:"by_task"`
+}
+
+func (uc GetTimeReport) Execute(ctx context.Context, in GetTimeReportInput) (GetTimeReportOutput, error) {
+	now := time.Now()
+	from, to := in.From, in.To
+	if to.IsZero() {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultTimeReportPeriod)
+	}
+	if !from.Before(to) {
+		return GetTimeReportOutput{}, domain.ErrInvalidTimeRange
+	}
+
+	entries, err := uc.repo.TimeEntries().ListBetween(ctx, from, to)
+	if err != nil {
+		return GetTimeReportOutput{}, fmt.Errorf("list time entries: %w", err)
+	}
+
+	tasks, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return GetTimeReportOutput{}, fmt.Errorf("get tasks: %w", err)
+	}
+	byID := make(map[string]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	// Архивные задачи в GetAll не входят, их записи дочитываем по ID
+	for _, entry := range entries {
+		if _, ok := byID[entry.TaskID]; ok {
+			continue
+		}
+		task, err := uc.repo.GetByID(ctx, entry.TaskID)
+		if err != nil {
+			return GetTimeReportOutput{}, fmt.Errorf("get task %s: %w", entry.TaskID, err)
+		}
+		byID[task.ID] = task
+	}
+
+	days := map[string]time.Duration{}
+	projects := map[string]time.Duration{}
+	priorities := map[string]time.Duration{}
+	perTask := map[string]time.Duration{}
+	var total time.Duration
+
+	for _, entry := range entries {
+		d := entry.Overlap(from, to, now)
+		if d == 0 {
+			continue
+		}
+		total += d
+		perTask[entry.TaskID] += d
+
+		if task, ok := byID[entry.TaskID]; ok {
+			projects[task.Project] += d
+			priorities[string(task.Priority)] += d
+		}
+
+		// Разбиваем по локальным дням
+		for day := startOfDay(maxTime(entry.StartedAt, from)); day.Before(to); day = day.AddDate(0, 0, 1) {
+			part := entry.Overlap(maxTime(day, from), minTime(day.AddDate(0, 0, 1), to), now)
+			if part > 0 {
+				days[day.Format("2006-01-02")] += part
+			}
+			if entry.EndedAt != nil && !day.AddDate(0, 0, 1).Before(*entry.EndedAt) {
+				break
+			}
+		}
+	}
+
+	out := GetTimeReportOutput{
+		From:         from,
+		To:           to,
+		TotalSeconds: int64(total / time.Second),
+		ByDay:        timeReportRows(days, nil),
+		ByProject:    timeReportRows(projects, nil),
+		ByPriority:   timeReportRows(priorities, nil),
+		ByTask: timeReportRows(perTask, func(id string) string {
+			if task, ok := byID[id]; ok {
+				return task.Title
+			}
+			return ""
+		}),
+	}
+
+	// Дни - по порядку, остальное - по убыванию времени
+	sort.Slice(out.ByDay, func(i, j int) bool { return out.ByDay[i].Key < out.ByDay[j].Key })
+	return out, nil
+}
+
+func timeReportRows(totals map[string]time.Duration, label func(key string) string) []TimeReportRow {
+	rows := make([]TimeReportRow, 0, len(totals))
+	for key, d := range totals {
+		row := TimeReportRow{Key: key, Seconds: int64(d / time.Second)}
+		if label != nil {
+			row.Label = label(key)
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Seconds != rows[j].Seconds {
+			return rows[i].Seconds > rows[j].Seconds
+		}
+		return rows[i].Key < rows[j].Key
+	})
+	return rows
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
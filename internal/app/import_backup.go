@@ -0,0 +1,464 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type ImportMode string
+
+const (
+	// ImportMerge - задачи из снапшота добавляются, совпадающие по ID перезаписываются
+	ImportMerge ImportMode = "merge"
+	// ImportReplace - все существующие задачи удаляются перед загрузкой снапшота;
+	// workflow, WIP-лимиты и вебхуки заменяются, если они есть в снапшоте (версия 2)
+	ImportReplace ImportMode = "replace"
+)
+
+var (
+	ErrInvalidImportMode          = errors.New("invalid import mode")
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+)
+
+type ImportBackup struct {
+	repo domain.TaskRepository
+}
+
+func NewImportBackup(repo domain.TaskRepository) ImportBackup {
+	return ImportBackup{repo: repo}
+}
+
+type ImportBackupInput struct {
+	Snapshot Snapshot   `json:"snapshot"`
+	Mode     ImportMode `json:"mode"`
+}
+
+type ImportBackupOutput struct {
+	Imported int `json:"imported"`
+}
+
+func (uc ImportBackup) Execute(ctx context.Context, in ImportBackupInput) (ImportBackupOutput, error) {
+	if in.Mode == "" {
+		in.Mode = ImportMerge
+	}
+	if in.Mode != ImportMerge && in.Mode != ImportReplace {
+		return ImportBackupOutput{}, ErrInvalidImportMode
+	}
+
+	if in.Snapshot.Version < 1 || in.Snapshot.Version > SnapshotVersion {
+		return ImportBackupOutput{}, fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, in.Snapshot.Version)
+	}
+
+	local, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return ImportBackupOutput{}, err
+	}
+	w, err := importedWorkflow(local, in.Snapshot.Workflow, in.Mode)
+	if err != nil {
+		return ImportBackupOutput{}, err
+	}
+
+	// Описания полей из снапшота заменяют одноименные локальные
+	fields, err := uc.repo.CustomFields().List(ctx)
+	if err != nil {
+		return ImportBackupOutput{}, fmt.Errorf("get custom fields: %w", err)
+	}
+	for _, f := range in.Snapshot.CustomFields {
+		if err := f.IsValid(); err != nil {
+			return ImportBackupOutput{}, err
+		}
+		fields = slices.DeleteFunc(fields, func(other domain.CustomField) bool { return other.Key == f.Key })
+		fields = append(fields, f)
+	}
+
+	// Валидируем весь снапшот до начала транзакции
+	tasks := make([]*domain.Task, 0, len(in.Snapshot.Tasks))
+	for i, st := range in.Snapshot.Tasks {
+		task := st.toDomain()
+		fitWorkflow(w, task)
+		task.MarkCompletion(w, time.Now())
+		if err := w.ValidateTask(task); err != nil {
+			return ImportBackupOutput{}, fmt.Errorf("task #%d (%s): %w", i+1, st.ID, err)
+		}
+		if err := task.NormalizeCustomValues(fields); err != nil {
+			return ImportBackupOutput{}, fmt.Errorf("task #%d (%s): %w", i+1, st.ID, err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := validateRelated(in.Snapshot); err != nil {
+		return ImportBackupOutput{}, err
+	}
+
+	err = uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if in.Mode == ImportReplace {
+			if err := repo.DeleteAll(ctx); err != nil {
+				return fmt.Errorf("delete tasks: %w", err)
+			}
+			if in.Snapshot.Version >= 2 {
+				if err := clearSettings(ctx, repo); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Статусы нужны до задач, которые на них ссылаются
+		if in.Snapshot.Workflow != nil {
+			if err := restoreWorkflow(ctx, repo, w); err != nil {
+				return err
+			}
+		}
+
+		for _, f := range in.Snapshot.CustomFields {
+			if err := repo.CustomFields().Save(ctx, f); err != nil {
+				return fmt.Errorf("save custom field %s: %w", f.Key, err)
+			}
+		}
+
+		for _, task := range tasks {
+			archivedAt := task.ArchivedAt
+			if err := repo.Save(ctx, task); err != nil {
+				return fmt.Errorf("save task %s: %w", task.ID, err)
+			}
+			if archivedAt != nil && task.IsClosed(w) {
+				if err := repo.Archive().Restore(ctx, task.ID, *archivedAt); err != nil {
+					return fmt.Errorf("archive task %s: %w", task.ID, err)
+				}
+			}
+		}
+
+		return restoreRelated(ctx, repo, in.Snapshot)
+	})
+	if err != nil {
+		return ImportBackupOutput{}, err
+	}
+
+	return ImportBackupOutput{Imported: len(tasks)}, nil
+}
+
+// importedWorkflow - workflow, по которому проверяются задачи снапшота.
+// Replace берет workflow снапшота целиком; merge оставляет локальный и только
+// добавляет недостающие статусы, не делая их начальными.
+func importedWorkflow(local domain.Workflow, snapshot *domain.Workflow, mode ImportMode) (domain.Workflow, error) {
+	if snapshot == nil {
+		return local, nil
+	}
+	if err := snapshot.IsValid(); err != nil {
+		return domain.Workflow{}, fmt.Errorf("snapshot workflow: %w", err)
+	}
+	if mode == ImportReplace {
+		return *snapshot, nil
+	}
+
+	merged := domain.Workflow{
+		Statuses:    slices.Clone(local.Statuses),
+		Transitions: local.Transitions,
+	}
+	for _, status := range snapshot.Statuses {
+		if _, exists := local.Status(status.Key); exists {
+			continue
+		}
+		status.Initial = false
+		status.Position = len(merged.Statuses)
+		merged.Statuses = append(merged.Statuses, status)
+	}
+	return merged, nil
+}
+
+// validateRelated проверяет, что связанные данные ссылаются только на задачи снапшота
+func validateRelated(snapshot Snapshot) error {
+	ids := make(map[string]bool, len(snapshot.Tasks))
+	for _, st := range snapshot.Tasks {
+		ids[st.ID] = true
+	}
+	check := func(kind, id, taskID string) error {
+		if !ids[taskID] {
+			return fmt.Errorf("%s %s: unknown task %s", kind, id, taskID)
+		}
+		return nil
+	}
+
+	for grouping, positions := range snapshot.Board.Positions {
+		if err := grouping.IsValid(); err != nil {
+			return err
+		}
+		for taskID := range positions {
+			if err := check("board position", string(grouping), taskID); err != nil {
+				return err
+			}
+		}
+	}
+	for grouping := range snapshot.Board.WIPLimits {
+		if err := grouping.IsValid(); err != nil {
+			return err
+		}
+	}
+	for _, d := range snapshot.Dependencies {
+		if err := check("dependency", d.TaskID, d.TaskID); err != nil {
+			return err
+		}
+		if err := check("dependency", d.TaskID, d.BlockedByID); err != nil {
+			return err
+		}
+		if d.TaskID == d.BlockedByID {
+			return fmt.Errorf("%w: task %s blocks itself", domain.ErrInvalidDependency, d.TaskID)
+		}
+	}
+	for _, e := range snapshot.TimeEntries {
+		if err := check("time entry", e.ID, e.TaskID); err != nil {
+			return err
+		}
+	}
+	for _, fs := range snapshot.FocusSessions {
+		if err := check("focus session", fs.ID, fs.TaskID); err != nil {
+			return err
+		}
+	}
+	for _, item := range snapshot.Checklist {
+		if err := check("checklist item", item.ID, item.TaskID); err != nil {
+			return err
+		}
+	}
+	for _, a := range snapshot.Attachments {
+		if err := check("attachment", a.ID, a.TaskID); err != nil {
+			return err
+		}
+	}
+	for _, c := range snapshot.Comments {
+		if err := check("comment", c.ID, c.TaskID); err != nil {
+			return err
+		}
+	}
+	for _, a := range snapshot.Activity {
+		if err := check("activity", a.EventType, a.TaskID); err != nil {
+			return err
+		}
+	}
+	for _, h := range snapshot.Webhooks {
+		hook := h.toDomain()
+		if err := hook.IsValid(); err != nil {
+			return fmt.Errorf("webhook %s: %w", h.ID, err)
+		}
+	}
+	return nil
+}
+
+// clearSettings удаляет WIP-лимиты и вебхуки перед заменой их из снапшота
+func clearSettings(ctx context.Context, repo domain.TaskRepository) error {
+	for _, grouping := range domain.BoardGroupings {
+		limits, err := repo.Board().WIPLimits(ctx, grouping)
+		if err != nil {
+			return fmt.Errorf("get wip limits: %w", err)
+		}
+		for column := range limits {
+			if err := repo.Board().SetWIPLimit(ctx, grouping, column, 0); err != nil {
+				return fmt.Errorf("clear wip limit: %w", err)
+			}
+		}
+	}
+
+	hooks, err := repo.Webhooks().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get webhooks: %w", err)
+	}
+	for _, hook := range hooks {
+		if err := repo.Webhooks().Delete(ctx, hook.ID); err != nil {
+			return fmt.Errorf("delete webhook %s: %w", hook.ID, err)
+		}
+	}
+	return nil
+}
+
+// restoreWorkflow приводит статусы и переходы к next. Статусы, которых нет в next,
+// удаляются: при replace задачи к этому моменту уже удалены, а merge статусы только добавляет.
+func restoreWorkflow(ctx context.Context, repo domain.TaskRepository, next domain.Workflow) error {
+	if err := repo.Workflow().Lock(ctx); err != nil {
+		return fmt.Errorf("lock workflow: %w", err)
+	}
+	current, err := repo.Workflow().Get(ctx)
+	if err != nil {
+		return fmt.Errorf("get workflow: %w", err)
+	}
+
+	for _, status := range next.Statuses {
+		if err := repo.Workflow().SaveStatus(ctx, status); err != nil {
+			return fmt.Errorf("save status %s: %w", status.Key, err)
+		}
+	}
+	for _, old := range current.Statuses {
+		if _, kept := next.Status(old.Key); kept {
+			continue
+		}
+		if err := repo.Workflow().DeleteStatus(ctx, old.Key); err != nil {
+			return fmt.Errorf("delete status %s: %w", old.Key, err)
+		}
+	}
+
+	if err := repo.Workflow().SetTransitions(ctx, next.Transitions); err != nil {
+		return fmt.Errorf("save transitions: %w", err)
+	}
+	return nil
+}
+
+// restoreRelated записывает связанные с задачами данные снапшота с исходными ID
+func restoreRelated(ctx context.Context, repo domain.TaskRepository, snapshot Snapshot) error {
+	for grouping, positions := range snapshot.Board.Positions {
+		ids := make([]string, 0, len(positions))
+		for id := range positions {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			if positions[ids[i]] != positions[ids[j]] {
+				return positions[ids[i]] < positions[ids[j]]
+			}
+			return ids[i] < ids[j]
+		})
+		if err := repo.Board().SetPositions(ctx, grouping, ids); err != nil {
+			return fmt.Errorf("set board positions: %w", err)
+		}
+	}
+	for grouping, limits := range snapshot.Board.WIPLimits {
+		for column, limit := range limits {
+			if err := repo.Board().SetWIPLimit(ctx, grouping, column, limit); err != nil {
+				return fmt.Errorf("set wip limit: %w", err)
+			}
+		}
+	}
+
+	if len(snapshot.Dependencies) > 0 {
+		if err := repo.Dependencies().Lock(ctx); err != nil {
+			return fmt.Errorf("lock dependencies: %w", err)
+		}
+		deps, err := repo.Dependencies().GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("get dependencies: %w", err)
+		}
+		for _, d := range snapshot.Dependencies {
+			dep := domain.Dependency{TaskID: d.TaskID, BlockedByID: d.BlockedByID}
+			if slices.Contains(deps, dep) {
+				continue
+			}
+			// При merge связи снапшота могут замкнуть цикл с локальными
+			if domain.NewDependencyGraph(deps).WouldCycle(dep.TaskID, dep.BlockedByID) {
+				return fmt.Errorf("%w: %s -> %s", domain.ErrDependencyCycle, dep.TaskID, dep.BlockedByID)
+			}
+			if err := repo.Dependencies().Add(ctx, dep); err != nil {
+				return fmt.Errorf("add dependency: %w", err)
+			}
+			deps = append(deps, dep)
+		}
+	}
+
+	for _, e := range snapshot.TimeEntries {
+		entry := &domain.TimeEntry{
+			ID:        e.ID,
+			TaskID:    e.TaskID,
+			StartedAt: e.StartedAt,
+			EndedAt:   e.EndedAt,
+			Note:      e.Note,
+			CreatedAt: e.CreatedAt,
+			UpdatedAt: e.UpdatedAt,
+		}
+		// Запущенным может быть только один таймер: если уже идет другой,
+		// таймер из бэкапа останавливается на моменте экспорта
+		if entry.EndedAt == nil {
+			running, err := repo.TimeEntries().GetRunning(ctx)
+			switch {
+			case err == nil && running.ID != entry.ID:
+				endedAt := snapshot.ExportedAt
+				if endedAt.Before(entry.StartedAt) {
+					endedAt = entry.StartedAt
+				}
+				entry.EndedAt = &endedAt
+			case err != nil && !errors.Is(err, domain.ErrNoRunningTimer):
+				return fmt.Errorf("get running timer: %w", err)
+			}
+		}
+		if err := repo.TimeEntries().Save(ctx, entry); err != nil {
+			return fmt.Errorf("save time entry %s: %w", e.ID, err)
+		}
+	}
+
+	for _, fs := range snapshot.FocusSessions {
+		session := &domain.FocusSession{
+			ID:        fs.ID,
+			TaskID:    fs.TaskID,
+			StartedAt: fs.StartedAt,
+			EndedAt:   fs.EndedAt,
+			Planned:   time.Duration(fs.PlannedSeconds) * time.Second,
+			Completed: fs.Completed,
+		}
+		if err := repo.FocusSessions().Add(ctx, session); err != nil {
+			return fmt.Errorf("add focus session %s: %w", fs.ID, err)
+		}
+	}
+
+	for _, ci := range snapshot.Checklist {
+		item := &domain.ChecklistItem{
+			ID:        ci.ID,
+			TaskID:    ci.TaskID,
+			Title:     ci.Title,
+			Checked:   ci.Checked,
+			CheckedAt: ci.CheckedAt,
+			Position:  ci.Position,
+			CreatedAt: ci.CreatedAt,
+		}
+		if err := repo.Checklist().Save(ctx, item); err != nil {
+			return fmt.Errorf("save checklist item %s: %w", ci.ID, err)
+		}
+	}
+
+	for _, a := range snapshot.Attachments {
+		attachment := &domain.Attachment{
+			ID:        a.ID,
+			TaskID:    a.TaskID,
+			Name:      a.Name,
+			MimeType:  a.MimeType,
+			Size:      a.Size,
+			Hash:      a.Hash,
+			CreatedAt: a.CreatedAt,
+		}
+		if err := repo.Attachments().Save(ctx, attachment); err != nil {
+			return fmt.Errorf("save attachment %s: %w", a.ID, err)
+		}
+	}
+
+	for _, c := range snapshot.Comments {
+		comment := &domain.Comment{
+			ID:        c.ID,
+			TaskID:    c.TaskID,
+			Author:    c.Author,
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		}
+		if err := repo.Comments().Save(ctx, comment); err != nil {
+			return fmt.Errorf("save comment %s: %w", c.ID, err)
+		}
+	}
+
+	for _, a := range snapshot.Activity {
+		entry := &domain.ActivityEntry{
+			TaskID:     a.TaskID,
+			EventType:  a.EventType,
+			Snapshot:   a.Snapshot,
+			OccurredAt: a.OccurredAt,
+		}
+		if err := repo.Activity().Restore(ctx, entry); err != nil {
+			return fmt.Errorf("restore activity of %s: %w", a.TaskID, err)
+		}
+	}
+
+	for _, h := range snapshot.Webhooks {
+		if err := repo.Webhooks().Save(ctx, h.toDomain()); err != nil {
+			return fmt.Errorf("save webhook %s: %w", h.ID, err)
+		}
+	}
+
+	return nil
+}
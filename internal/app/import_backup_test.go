@@ -0,0 +1,70 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+func TestImportedWorkflow(t *testing.T) {
+	local := domain.DefaultWorkflow()
+	snapshot := domain.Workflow{Statuses: []domain.WorkflowStatus{
+		{Key: "todo", Name: "To do", Category: domain.CategoryOpen, Position: 0, Initial: true},
+		{Key: domain.StatusActive, Name: "In progress", Category: domain.CategoryOpen, Position: 1},
+		{Key: "done", Name: "Done", Category: domain.CategoryClosed, Position: 2},
+	}}
+
+	replaced, err := importedWorkflow(local, &snapshot, ImportReplace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replaced.InitialStatus() != "todo" || len(replaced.Statuses) != 3 {
+		t.Fatalf("replace: statuses = %v, want snapshot workflow", replaced.Statuses)
+	}
+
+	merged, err := importedWorkflow(local, &snapshot, ImportMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.InitialStatus() != domain.StatusActive {
+		t.Fatalf("merge: initial = %q, want local %q", merged.InitialStatus(), domain.StatusActive)
+	}
+	if active, _ := merged.Status(domain.StatusActive); active.Name != "Active" {
+		t.Fatalf("merge: local status renamed to %q", active.Name)
+	}
+	if _, ok := merged.Status("done"); !ok || len(merged.Statuses) != 4 {
+		t.Fatalf("merge: statuses = %v, want local plus todo and done", merged.Statuses)
+	}
+	if err := merged.IsValid(); err != nil {
+		t.Fatalf("merge: invalid workflow: %v", err)
+	}
+
+	unchanged, err := importedWorkflow(local, nil, ImportReplace)
+	if err != nil || len(unchanged.Statuses) != len(local.Statuses) {
+		t.Fatalf("version 1 snapshot: workflow = %v, err = %v; want local", unchanged.Statuses, err)
+	}
+}
+
+func TestValidateRelatedRejectsUnknownTasks(t *testing.T) {
+	base := Snapshot{Tasks: []SnapshotTask{{ID: "a"}, {ID: "b"}}}
+
+	valid := base
+	valid.Dependencies = []SnapshotDependency{{TaskID: "b", BlockedByID: "a"}}
+	valid.Comments = []SnapshotComment{{ID: "c1", TaskID: "a", Body: "ok"}}
+	if err := validateRelated(valid); err != nil {
+		t.Fatalf("valid snapshot: %v", err)
+	}
+
+	unknown := base
+	unknown.Checklist = []SnapshotChecklistItem{{ID: "i1", TaskID: "missing", Title: "x"}}
+	if err := validateRelated(unknown); err == nil {
+		t.Fatal("checklist item of unknown task accepted")
+	}
+
+	self := base
+	self.Dependencies = []SnapshotDependency{{TaskID: "a", BlockedByID: "a"}}
+	if err := validateRelated(self); !errors.Is(err, domain.ErrInvalidDependency) {
+		t.Fatalf("self dependency: err = %v, want ErrInvalidDependency", err)
+	}
+}
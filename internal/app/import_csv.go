@@ -0,0 +1,236 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+var ErrTitleNotMapped = errors.New("title column is not mapped")
+
+type ImportCSV struct {
+	repo domain.TaskRepository
+}
+
+func NewImportCSV(repo domain.TaskRepository) ImportCSV {
+	return ImportCSV{repo: repo}
+}
+
+type ImportCSVInput struct {
+	Data string `json:"data"`
+	// Mapping - заголовок CSV -> поле задачи (id, title, status, priority, created_at, due_date, project, tags)
+	// или пользовательское поле "field:<ключ>". Если пусто, колонки сопоставляются по совпадению имени.
+	// Строка с id существующей задачи - ошибка строки, импорт задачи не перезаписывает.
+	Mapping    map[string]string `json:"mapping,omitempty"`
+	Timezone   string            `json:"timezone,omitempty"`
+	DateFormat string            `json:"date_format,omitempty"`
+	DryRun     bool              `json:"dry_run"`
+}
+
+type CSVRowResult struct {
+	Row   int          `json:"row"` // номер строки в файле, заголовок - строка 1
+	Task  *domain.Task `json:"task,omitempty"`
+	Error string       `json:"error,omitempty"`
+}
+
+type ImportCSVOutput struct {
+	Rows     []CSVRowResult `json:"rows"`
+	Imported int            `json:"imported"`
+	Failed   int            `json:"failed"`
+	DryRun   bool           `json:"dry_run"`
+}
+
+func (uc ImportCSV) Execute(ctx context.Context, in ImportCSVInput) (ImportCSVOutput, error) {
+	format, err := newCSVFormat(in.Timezone, in.DateFormat)
+	if err != nil {
+		return ImportCSVOutput{}, err
+	}
+
+	r := csv.NewReader(strings.NewReader(in.Data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return ImportCSVOutput{}, fmt.Errorf("read header: %w", err)
+	}
+
+	fields, err := uc.repo.CustomFields().List(ctx)
+	if err != nil {
+		return ImportCSVOutput{}, fmt.Errorf("get custom fields: %w", err)
+	}
+
+	columns, err := mapCSVColumns(header, in.Mapping, fields)
+	if err != nil {
+		return ImportCSVOutput{}, err
+	}
+
+	// Колонка status может содержать любой статус workflow
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return ImportCSVOutput{}, err
+	}
+
+	out := ImportCSVOutput{Rows: make([]CSVRowResult, 0), DryRun: in.DryRun}
+	valid := make([]*domain.Task, 0)
+	seen := make(map[string]bool)
+
+	for row := 2; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ImportCSVOutput{}, fmt.Errorf("read row %d: %w", row, err)
+		}
+
+		result := CSVRowResult{Row: row}
+		task, err := parseCSVTask(record, columns, format, fields)
+		if err == nil {
+			fitWorkflow(w, task)
+			err = w.ValidateTask(task)
+		}
+		// Явный id не должен перезаписать существующую задачу или строку выше
+		if err == nil {
+			err = uc.checkNewID(ctx, task.ID, seen)
+			if err != nil && !errors.Is(err, domain.ErrTaskExists) {
+				return ImportCSVOutput{}, err
+			}
+		}
+		if err != nil {
+			result.Error = err.Error()
+			out.Failed++
+		} else {
+			result.Task = task
+			valid = append(valid, task)
+		}
+		out.Rows = append(out.Rows, result)
+	}
+
+	if in.DryRun || len(valid) == 0 {
+		return out, nil
+	}
+
+	if err := saveTasks(ctx, uc.repo, valid); err != nil {
+		return ImportCSVOutput{}, err
+	}
+
+	out.Imported = len(valid)
+	return out, nil
+}
+
+func (uc ImportCSV) checkNewID(ctx context.Context, id string, seen map[string]bool) error {
+	if seen[id] {
+		return fmt.Errorf("%w: %s is repeated in the file", domain.ErrTaskExists, id)
+	}
+	_, err := uc.repo.GetByID(ctx, id)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: %s", domain.ErrTaskExists, id)
+	case !errors.Is(err, domain.ErrTaskNotFound):
+		return fmt.Errorf("get task %s: %w", id, err)
+	}
+	seen[id] = true
+	return nil
+}
+
+// mapCSVColumns возвращает поле задачи для каждого индекса колонки ("" - колонка пропускается)
+func mapCSVColumns(header []string, mapping map[string]string, fields []domain.CustomField) ([]string, error) {
+	columns := make([]string, len(header))
+	hasTitle := false
+
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+
+		field := strings.ToLower(name)
+		if len(mapping) > 0 {
+			field = mapping[name]
+			if field == "" {
+				continue
+			}
+			if !isCSVColumn(field, fields) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownCSVField, field)
+			}
+		} else if !isCSVColumn(field, fields) {
+			continue
+		}
+
+		columns[i] = field
+		if field == CSVFieldTitle {
+			hasTitle = true
+		}
+	}
+
+	if !hasTitle {
+		return nil, ErrTitleNotMapped
+	}
+
+	return columns, nil
+}
+
+func parseCSVTask(record []string, columns []string, format csvFormat, fields []domain.CustomField) (*domain.Task, error) {
+	values := make(map[string]string, len(columns))
+	custom := make(map[string]any)
+	for i, field := range columns {
+		if field == "" || i >= len(record) {
+			continue
+		}
+		if key, ok := strings.CutPrefix(field, CSVFieldCustomPrefix); ok {
+			custom[key] = strings.TrimSpace(record[i])
+			continue
+		}
+		values[field] = strings.TrimSpace(record[i])
+	}
+
+	priority := domain.Priority(strings.ToLower(values[CSVFieldPriority]))
+	if priority == "" {
+		priority = domain.PriorityMedium
+	}
+
+	dueDate, err := format.parseTime(values[CSVFieldDueDate])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", CSVFieldDueDate, err)
+	}
+
+	task, err := domain.NewTask(values[CSVFieldTitle], priority, dueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if id := values[CSVFieldID]; id != "" {
+		task.ID = id
+	}
+	task.Project = values[CSVFieldProject]
+	for _, tag := range strings.Split(values[CSVFieldTags], ",") {
+		task.AddTag(tag)
+	}
+	if status := values[CSVFieldStatus]; status != "" {
+		task.Status = domain.TaskStatus(strings.ToLower(status))
+	}
+	// Как и при создании: строка без обязательного поля - ошибка строки
+	if err := task.SetCustomValues(fields, custom); err != nil {
+		return nil, err
+	}
+	if err := task.CheckRequiredFields(fields); err != nil {
+		return nil, err
+	}
+
+	createdAt, err := format.parseTime(values[CSVFieldCreatedAt])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", CSVFieldCreatedAt, err)
+	}
+	if createdAt != nil {
+		task.CreatedAt = *createdAt
+	}
+
+	if err := task.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
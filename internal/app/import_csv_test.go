@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestImportCSVRejectsExistingID(t *testing.T) {
+	ctx := context.Background()
+	w := domain.DefaultWorkflow()
+	repo := testutil.NewRepo()
+	repo.Put(&domain.Task{ID: "t1", Title: "Keep me", Status: w.InitialStatus(), Priority: domain.PriorityMedium})
+
+	data := "id,title\nt1,Overwrite\nt2,New\nt2,Twice\n"
+	out, err := NewImportCSV(repo).Execute(ctx, ImportCSVInput{Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Imported != 1 || out.Failed != 2 {
+		t.Fatalf("imported %d, failed %d; want 1 and 2", out.Imported, out.Failed)
+	}
+	for _, row := range []int{0, 2} {
+		if !strings.Contains(out.Rows[row].Error, domain.ErrTaskExists.Error()) {
+			t.Errorf("row %d error = %q, want task exists", out.Rows[row].Row, out.Rows[row].Error)
+		}
+	}
+
+	if task, _ := repo.GetByID(ctx, "t1"); task.Title != "Keep me" {
+		t.Fatalf("existing task overwritten: title %q", task.Title)
+	}
+	if task, err := repo.GetByID(ctx, "t2"); err != nil || task.Title != "New" {
+		t.Fatalf("t2 = %v, %v; want the first row", task, err)
+	}
+}
+
+func TestImportCSVChecksRequiredFields(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	repo.Fields = []domain.CustomField{{Key: "sprint", Project: "work", Name: "Sprint", Type: domain.FieldTypeNumber, Required: true}}
+
+	data := "title,project,field:sprint\nNo sprint,work,\nWith sprint,work,4\nHome,home,\n"
+	out, err := NewImportCSV(repo).Execute(ctx, ImportCSVInput{Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Imported != 2 || out.Failed != 1 {
+		t.Fatalf("imported %d, failed %d; want 2 and 1", out.Imported, out.Failed)
+	}
+	if row := out.Rows[0]; !strings.Contains(row.Error, "sprint is required") {
+		t.Fatalf("row %d error = %q, want required field", row.Row, row.Error)
+	}
+}
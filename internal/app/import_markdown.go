@@ -0,0 +1,155 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+var (
+	markdownHeadingRe  = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+	markdownItemRe     = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	markdownDueRe      = regexp.MustCompile(`\(due:\s*([^)]+)\)`)
+	markdownPriorityRe = regexp.MustCompile(`(^|\s)!(low|medium|high)\b`)
+	priorityHeadingRe  = regexp.MustCompile(`(?i)^(low|medium|high) priority$`)
+)
+
+type ImportMarkdown struct {
+	repo domain.TaskRepository
+}
+
+func NewImportMarkdown(repo domain.TaskRepository) ImportMarkdown {
+	return ImportMarkdown{repo: repo}
+}
+
+type ImportMarkdownInput struct {
+	Data string `json:"data"`
+	// Project - проект для пунктов до первого заголовка
+	Project string `json:"project,omitempty"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+type ImportMarkdownOutput struct {
+	Tasks    []*domain.Task `json:"tasks"`
+	Imported int            `json:"imported"`
+}
+
+func (uc ImportMarkdown) Execute(ctx context.Context, in ImportMarkdownInput) (ImportMarkdownOutput, error) {
+	tasks, err := ParseMarkdown(in.Data, in.Project)
+	if err != nil {
+		return ImportMarkdownOutput{}, err
+	}
+
+	out := ImportMarkdownOutput{Tasks: tasks}
+	if in.DryRun || len(tasks) == 0 {
+		return out, nil
+	}
+
+	// Родители идут в документе раньше подзадач, поэтому порядок сохранения корректен
+	if err := saveTasks(ctx, uc.repo, tasks); err != nil {
+		return ImportMarkdownOutput{}, err
+	}
+
+	out.Imported = len(tasks)
+	return out, nil
+}
+
+// ParseMarkdown разбирает чеклист: вложенность -> подзадачи, [x] -> выполнено,
+// заголовки "## <проект>" и "## High priority" задают проект и приоритет для пунктов ниже.
+func ParseMarkdown(data, project string) ([]*domain.Task, error) {
+	type level struct {
+		indent int
+		task   *domain.Task
+	}
+
+	var (
+		tasks    = make([]*domain.Task, 0)
+		stack    []level
+		priority = domain.PriorityMedium
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if m := markdownHeadingRe.FindStringSubmatch(text); m != nil {
+			if p := priorityHeadingRe.FindStringSubmatch(m[1]); p != nil {
+				priority = domain.Priority(strings.ToLower(p[1]))
+			} else {
+				project = m[1]
+				priority = domain.PriorityMedium
+			}
+			stack = stack[:0]
+			continue
+		}
+
+		m := markdownItemRe.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+
+		indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+		task, err := parseMarkdownItem(m[3], priority)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		task.Project = project
+		if m[2] != " " {
+			task.Status = domain.StatusCompleted
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) > 0 {
+			parentID := stack[len(stack)-1].task.ID
+			task.ParentID = &parentID
+		}
+		stack = append(stack, level{indent: indent, task: task})
+
+		if err := task.IsValid(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read markdown: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func parseMarkdownItem(text string, priority domain.Priority) (*domain.Task, error) {
+	var dueDate *time.Time
+	if m := markdownDueRe.FindStringSubmatch(text); m != nil {
+		due, err := parseMarkdownDate(strings.TrimSpace(m[1]))
+		if err != nil {
+			return nil, err
+		}
+		dueDate = &due
+		text = markdownDueRe.ReplaceAllString(text, "")
+	}
+
+	if m := markdownPriorityRe.FindStringSubmatch(text); m != nil {
+		priority = domain.Priority(m[2])
+		text = markdownPriorityRe.ReplaceAllString(text, "$1")
+	}
+
+	return domain.NewTask(strings.TrimSpace(text), priority, dueDate)
+}
+
+func parseMarkdownDate(value string) (time.Time, error) {
+	for _, layout := range []string{markdownDateTimeLayout, markdownDateLayout} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", domain.ErrInvalidDate, value)
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func markdownTasks() []*domain.Task {
+	releaseDue := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	changelogDue := time.Date(2026, 3, 9, 14, 30, 0, 0, time.Local)
+	release := "release"
+	return []*domain.Task{
+		{ID: "plan", Title: "Plan", Status: domain.StatusActive, Priority: domain.PriorityMedium},
+		{ID: "release", Title: "Release", Status: domain.StatusActive, Priority: domain.PriorityHigh, Project: "work", DueDate: &releaseDue},
+		{ID: "changelog", Title: "Changelog", Status: domain.StatusCompleted, Priority: domain.PriorityMedium, Project: "work", ParentID: &release, DueDate: &changelogDue},
+		{ID: "tag", Title: "Tag", Status: domain.StatusActive, Priority: domain.PriorityLow, Project: "work", ParentID: &release},
+		{ID: "groceries", Title: "Groceries", Status: domain.StatusActive, Priority: domain.PriorityMedium, Project: "home"},
+	}
+}
+
+func TestMarkdownRoundTripByProject(t *testing.T) {
+	w := domain.DefaultWorkflow()
+	data := RenderMarkdown(markdownTasks(), GroupByProject, w)
+
+	want := "- [ ] Plan\n" +
+		"\n## home\n\n" +
+		"- [ ] Groceries\n" +
+		"\n## work\n\n" +
+		"- [ ] Release !high (due: 2026-03-10)\n" +
+		"  - [x] Changelog (due: 2026-03-09 14:30)\n" +
+		"  - [ ] Tag !low\n"
+	if data != want {
+		t.Fatalf("markdown:\n%s\nwant:\n%s", data, want)
+	}
+
+	parsed, err := ParseMarkdown(data, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTitle := make(map[string]*domain.Task, len(parsed))
+	for _, task := range parsed {
+		byTitle[task.Title] = task
+	}
+
+	for _, orig := range markdownTasks() {
+		got, ok := byTitle[orig.Title]
+		if !ok {
+			t.Errorf("%s: lost in round trip", orig.Title)
+			continue
+		}
+		if got.Project != orig.Project || got.Priority != orig.Priority || got.Status != orig.Status {
+			t.Errorf("%s: project %q, priority %s, status %s; want %q, %s, %s",
+				orig.Title, got.Project, got.Priority, got.Status, orig.Project, orig.Priority, orig.Status)
+		}
+		if (got.DueDate == nil) != (orig.DueDate == nil) || got.DueDate != nil && !got.DueDate.Equal(*orig.DueDate) {
+			t.Errorf("%s: due %v, want %v", orig.Title, got.DueDate, orig.DueDate)
+		}
+		if (got.ParentID == nil) != (orig.ParentID == nil) {
+			t.Errorf("%s: parent %v, want %v", orig.Title, got.ParentID, orig.ParentID)
+		}
+	}
+	release := byTitle["Release"]
+	for _, title := range []string{"Changelog", "Tag"} {
+		if parent := byTitle[title].ParentID; parent == nil || *parent != release.ID {
+			t.Errorf("%s: parent %v, want the parsed Release %s", title, parent, release.ID)
+		}
+	}
+}
+
+func TestMarkdownRoundTripByPriority(t *testing.T) {
+	data := RenderMarkdown(markdownTasks(), GroupByPriority, domain.DefaultWorkflow())
+
+	parsed, err := ParseMarkdown(data, "inbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 5 {
+		t.Fatalf("parsed %d tasks, want 5:\n%s", len(parsed), data)
+	}
+	want := make(map[string]domain.Priority)
+	for _, task := range markdownTasks() {
+		want[task.Title] = task.Priority
+	}
+	for _, task := range parsed {
+		// Tag (low) стоит под Release в разделе High priority и сохраняет свой приоритет
+		if task.Priority != want[task.Title] {
+			t.Errorf("%s: priority %s, want %s", task.Title, task.Priority, want[task.Title])
+		}
+		if task.Project != "inbox" {
+			t.Errorf("%s: project %q, want inbox", task.Title, task.Project)
+		}
+	}
+}
+
+func TestImportMarkdownSavesSubtasks(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	data := "## work\n\n- [ ] Release\n  - [x] Changelog\n    - [ ] Typos\n- [ ] Retro\n"
+
+	out, err := NewImportMarkdown(repo).Execute(ctx, ImportMarkdownInput{Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Imported != 4 {
+		t.Fatalf("imported %d, want 4", out.Imported)
+	}
+
+	saved := make(map[string]*domain.Task)
+	for _, task := range out.Tasks {
+		stored, err := repo.GetByID(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("%s not saved: %v", task.Title, err)
+		}
+		saved[stored.Title] = stored
+	}
+	if saved["Changelog"].CompletedAt == nil || saved["Release"].CompletedAt != nil {
+		t.Fatal("[x] must close the task and keep its parent open")
+	}
+	parentOf := func(title string) string {
+		if p := saved[title].ParentID; p != nil {
+			return *p
+		}
+		return ""
+	}
+	if parentOf("Changelog") != saved["Release"].ID || parentOf("Typos") != saved["Changelog"].ID || parentOf("Retro") != "" {
+		t.Fatalf("parents: changelog %q, typos %q, retro %q", parentOf("Changelog"), parentOf("Typos"), parentOf("Retro"))
+	}
+}
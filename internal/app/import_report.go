@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// ImportNote - запись отчета об импорте из сторонних форматов
+type ImportNote struct {
+	Ref    string `json:"ref"`             // идентификатор записи в исходном файле
+	Field  string `json:"field,omitempty"` // пусто, если пропущена вся запись
+	Value  string `json:"value,omitempty"`
+	Reason string `json:"reason"`
+}
+
+type ImportReport struct {
+	Tasks       []*domain.Task `json:"tasks"`
+	Imported    int            `json:"imported"`
+	Skipped     []ImportNote   `json:"skipped"`
+	Transformed []ImportNote   `json:"transformed"`
+	DryRun      bool           `json:"dry_run"`
+}
+
+func newImportReport(dryRun bool) *ImportReport {
+	return &ImportReport{
+		Tasks:       make([]*domain.Task, 0),
+		Skipped:     make([]ImportNote, 0),
+		Transformed: make([]ImportNote, 0),
+		DryRun:      dryRun,
+	}
+}
+
+func (r *ImportReport) skip(ref, field, value, reason string) {
+	r.Skipped = append(r.Skipped, ImportNote{Ref: ref, Field: field, Value: value, Reason: reason})
+}
+
+func (r *ImportReport) transform(ref, field, value, reason string) {
+	r.Transformed = append(r.Transformed, ImportNote{Ref: ref, Field: field, Value: value, Reason: reason})
+}
+
+// saveTasks сохраняет импортированные задачи одной транзакцией;
+// active/completed из импорта переводятся в статусы workflow
+func saveTasks(ctx context.Context, repo domain.TaskRepository, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	return repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		w, err := loadWorkflow(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			fitWorkflow(w, task)
+			// Время закрытия из источника сохраняется, без него - момент импорта
+			task.MarkCompletion(w, time.Now())
+			if err := w.ValidateTask(task); err != nil {
+				return fmt.Errorf("validate task %s: %w", task.ID, err)
+			}
+			if err := repo.Save(ctx, task); err != nil {
+				return fmt.Errorf("save task %s: %w", task.ID, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const taskwarriorTimeLayout = "20060102T150405Z"
+
+// taskwarriorTask - запись из `task export`
+type taskwarriorTask struct {
+	UUID        string            `json:"uuid"`
+	Description string            `json:"description"`
+	Status      string            `json:"status"`
+	Entry       string            `json:"entry"`
+	Due         string            `json:"due"`
+	End         string            `json:"end"`
+	Priority    string            `json:"priority"`
+	Project     string            `json:"project"`
+	Tags        []string          `json:"tags"`
+	Wait        string            `json:"wait"`
+	Scheduled   string            `json:"scheduled"`
+	Until       string            `json:"until"`
+	Recur       string            `json:"recur"`
+	Depends     json.RawMessage   `json:"depends"`
+	Annotations []json.RawMessage `json:"annotations"`
+}
+
+type ImportTaskwarrior struct {
+	repo domain.TaskRepository
+}
+
+func NewImportTaskwarrior(repo domain.TaskRepository) ImportTaskwarrior {
+	return ImportTaskwarrior{repo: repo}
+}
+
+type ImportTaskwarriorInput struct {
+	Data   string `json:"data"`
+	DryRun bool   `json:"dry_run"`
+}
+
+func (uc ImportTaskwarrior) Execute(ctx context.Context, in ImportTaskwarriorInput) (ImportReport, error) {
+	var items []taskwarriorTask
+	if err := json.Unmarshal([]byte(in.Data), &items); err != nil {
+		return ImportReport{}, fmt.Errorf("parse taskwarrior export: %w", err)
+	}
+
+	report := newImportReport(in.DryRun)
+	for i, item := range items {
+		ref := item.UUID
+		if ref == "" {
+			ref = fmt.Sprintf("#%d", i+1)
+		}
+
+		task, err := convertTaskwarriorTask(ref, item, report)
+		if err != nil {
+			report.skip(ref, "", item.Description, err.Error())
+			continue
+		}
+		if task != nil {
+			report.Tasks = append(report.Tasks, task)
+		}
+	}
+
+	if in.DryRun {
+		return *report, nil
+	}
+
+	if err := saveTasks(ctx, uc.repo, report.Tasks); err != nil {
+		return ImportReport{}, err
+	}
+	report.Imported = len(report.Tasks)
+
+	return *report, nil
+}
+
+// convertTaskwarriorTask возвращает nil без ошибки, если запись пропущена намеренно
+func convertTaskwarriorTask(ref string, item taskwarriorTask, report *ImportReport) (*domain.Task, error) {
+	status := domain.StatusActive
+	switch item.Status {
+	case "pending", "":
+	case "waiting":
+		report.transform(ref, "status", item.Status, "imported as active, deferred until wait")
+	case "completed":
+		status = domain.StatusCompleted
+	case "deleted":
+		report.skip(ref, "", item.Description, "deleted task")
+		return nil, nil
+	case "recurring":
+		report.skip(ref, "", item.Description, "recurring template")
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, item.Status)
+	}
+
+	priority := domain.PriorityMedium
+	switch item.Priority {
+	case "H":
+		priority = domain.PriorityHigh
+	case "M", "":
+	case "L":
+		priority = domain.PriorityLow
+	default:
+		report.transform(ref, "priority", item.Priority, "unknown priority, imported as medium")
+	}
+
+	var dueDate *time.Time
+	if item.Due != "" {
+		due, err := time.Parse(taskwarriorTimeLayout, item.Due)
+		if err != nil {
+			return nil, fmt.Errorf("due: %w: %q", domain.ErrInvalidDate, item.Due)
+		}
+		dueDate = &due
+	}
+
+	task, err := domain.NewTask(item.Description, priority, dueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Стабильный ID позволяет повторно импортировать тот же экспорт без дублей
+	if item.UUID != "" {
+		task.ID = "tw_" + item.UUID
+	}
+	task.Status = status
+	task.Project = item.Project
+
+	if item.Entry != "" {
+		if entry, err := time.Parse(taskwarriorTimeLayout, item.Entry); err == nil {
+			task.CreatedAt = entry
+		} else {
+			report.skip(ref, "entry", item.Entry, "invalid date")
+		}
+	}
+
+	if item.End != "" && status == domain.StatusCompleted {
+		if end, err := time.Parse(taskwarriorTimeLayout, item.End); err == nil {
+			task.CompletedAt = &end
+		} else {
+			report.skip(ref, "end", item.End, "invalid date")
+		}
+	}
+
+	// wait в Taskwarrior - та же отсрочка, что и дата начала
+	if item.Wait != "" {
+		if wait, err := time.Parse(taskwarriorTimeLayout, item.Wait); err == nil {
+			task.StartDate = &wait
+		} else {
+			report.skip(ref, "wait", item.Wait, "invalid date")
+		}
+	}
+
+	for _, tag := range item.Tags {
+		task.AddTag(tag)
+	}
+
+	// Поля Taskwarrior, у которых нет аналога в модели
+	if item.Scheduled != "" {
+		report.skip(ref, "scheduled", item.Scheduled, "not supported")
+	}
+	if item.Until != "" {
+		report.skip(ref, "until", item.Until, "not supported")
+	}
+	if item.Recur != "" {
+		report.skip(ref, "recur", item.Recur, "not supported")
+	}
+	if len(item.Depends) > 0 && string(item.Depends) != "null" {
+		report.skip(ref, "depends", strings.Trim(string(item.Depends), `"`), "not supported")
+	}
+	if len(item.Annotations) > 0 {
+		report.skip(ref, "annotations", fmt.Sprintf("%d", len(item.Annotations)), "not supported")
+	}
+
+	if err := task.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+const taskwarriorExport = `[
+  {"uuid":"a1","description":"Write docs","status":"pending","entry":"20260301T090000Z","due":"20260310T170000Z",
+   "priority":"H","project":"work","tags":["docs","urgent"],"scheduled":"20260305T000000Z",
+   "annotations":[{"entry":"20260301T100000Z","description":"outline"}],"depends":"b2"},
+  {"uuid":"b2","description":"Ship","status":"completed","end":"20260302T120000Z","priority":"L"},
+  {"uuid":"c3","description":"Old","status":"deleted"},
+  {"uuid":"d4","description":"Template","status":"recurring","recur":"weekly"},
+  {"uuid":"e5","description":"Later","status":"waiting","wait":"20260401T000000Z","priority":"X"},
+  {"description":"Broken","status":"weird"},
+  {"uuid":"g7","description":"Bad due","status":"pending","due":"tomorrow"}
+]`
+
+// hasNote - есть ли в отчете запись для ref и поля
+func hasNote(notes []ImportNote, ref, field string) bool {
+	return slices.ContainsFunc(notes, func(n ImportNote) bool { return n.Ref == ref && n.Field == field })
+}
+
+func TestImportTaskwarrior(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+
+	report, err := NewImportTaskwarrior(repo).Execute(ctx, ImportTaskwarriorInput{Data: taskwarriorExport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Imported != 3 || len(report.Tasks) != 3 {
+		t.Fatalf("imported %d of %d tasks, want 3", report.Imported, len(report.Tasks))
+	}
+
+	docs, err := repo.GetByID(ctx, "tw_a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDue := time.Date(2026, 3, 10, 17, 0, 0, 0, time.UTC)
+	if docs.Priority != domain.PriorityHigh || docs.Project != "work" || !slices.Equal(docs.Tags, []string{"docs", "urgent"}) ||
+		docs.DueDate == nil || !docs.DueDate.Equal(wantDue) || !docs.CreatedAt.Equal(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("pending task = %+v", docs)
+	}
+
+	ship, _ := repo.GetByID(ctx, "tw_b2")
+	if !ship.IsClosed(repo.Flow) || ship.CompletedAt == nil || !ship.CompletedAt.Equal(time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("completed task: status %q, completed_at %v; want end time kept", ship.Status, ship.CompletedAt)
+	}
+	if ship.Priority != domain.PriorityLow {
+		t.Fatalf("L priority imported as %s", ship.Priority)
+	}
+
+	later, _ := repo.GetByID(ctx, "tw_e5")
+	if later.IsClosed(repo.Flow) || later.StartDate == nil || later.Priority != domain.PriorityMedium {
+		t.Fatalf("waiting task: status %q, start %v, priority %s; want open, deferred to wait, medium", later.Status, later.StartDate, later.Priority)
+	}
+
+	for _, skipped := range []struct{ ref, field string }{
+		{"a1", "scheduled"}, {"a1", "depends"}, {"a1", "annotations"},
+		{"c3", ""}, {"d4", ""}, {"#6", ""}, {"g7", ""},
+	} {
+		if !hasNote(report.Skipped, skipped.ref, skipped.field) {
+			t.Errorf("skipped %s/%s is missing from %+v", skipped.ref, skipped.field, report.Skipped)
+		}
+	}
+	if len(report.Skipped) != 7 {
+		t.Errorf("skipped = %+v, want 7 notes", report.Skipped)
+	}
+	if !hasNote(report.Transformed, "e5", "status") || !hasNote(report.Transformed, "e5", "priority") || len(report.Transformed) != 2 {
+		t.Errorf("transformed = %+v, want status and priority of e5", report.Transformed)
+	}
+}
+
+func TestImportTaskwarriorDryRunSavesNothing(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+
+	report, err := NewImportTaskwarrior(repo).Execute(ctx, ImportTaskwarriorInput{Data: taskwarriorExport, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun || report.Imported != 0 || len(report.Tasks) != 3 {
+		t.Fatalf("dry run: imported %d, tasks %d", report.Imported, len(report.Tasks))
+	}
+	if all, _ := repo.GetAll(ctx); len(all) != 0 {
+		t.Fatalf("dry run saved %d tasks", len(all))
+	}
+
+	if _, err := NewImportTaskwarrior(repo).Execute(ctx, ImportTaskwarriorInput{Data: `{"not":"an array"}`}); err == nil {
+		t.Fatal("malformed export: want error")
+	}
+}
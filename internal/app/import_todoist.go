@@ -0,0 +1,418 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const (
+	TodoistFormatCSV  = "csv"
+	TodoistFormatJSON = "json"
+)
+
+var ErrInvalidImportFormat = errors.New("invalid import format")
+
+var todoistLabelRe = regexp.MustCompile(`(^|\s)@([\p{L}\p{N}_-]+)`)
+
+var todoistDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+type ImportTodoist struct {
+	repo domain.TaskRepository
+}
+
+func NewImportTodoist(repo domain.TaskRepository) ImportTodoist {
+	return ImportTodoist{repo: repo}
+}
+
+type ImportTodoistInput struct {
+	Data   string `json:"data"`
+	Format string `json:"format"` // "csv" (шаблон проекта) или "json" (Sync/REST API)
+	// Project - имя проекта для CSV: экспорт Todoist в CSV делается по одному проекту
+	Project string `json:"project,omitempty"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+func (uc ImportTodoist) Execute(ctx context.Context, in ImportTodoistInput) (ImportReport, error) {
+	report := newImportReport(in.DryRun)
+
+	var err error
+	switch in.Format {
+	case TodoistFormatCSV:
+		err = parseTodoistCSV(in.Data, in.Project, report)
+	case TodoistFormatJSON:
+		err = parseTodoistJSON(in.Data, in.Project, report)
+	default:
+		return ImportReport{}, fmt.Errorf("%w: %s", ErrInvalidImportFormat, in.Format)
+	}
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	if in.DryRun {
+		return *report, nil
+	}
+
+	if err := saveTasks(ctx, uc.repo, report.Tasks); err != nil {
+		return ImportReport{}, err
+	}
+	report.Imported = len(report.Tasks)
+
+	return *report, nil
+}
+
+// Todoist p1..p4 -> наши три приоритета; p4 - приоритет по умолчанию
+func todoistPriority(ref string, level int, report *ImportReport) domain.Priority {
+	switch level {
+	case 1:
+		return domain.PriorityHigh
+	case 2:
+		report.transform(ref, "priority", "p2", "imported as high")
+		return domain.PriorityHigh
+	case 3:
+		report.transform(ref, "priority", "p3", "imported as medium")
+		return domain.PriorityMedium
+	case 4:
+		return domain.PriorityMedium
+	}
+	report.transform(ref, "priority", strconv.Itoa(level), "unknown priority, imported as medium")
+	return domain.PriorityMedium
+}
+
+func parseTodoistDate(value string) (*time.Time, bool) {
+	for _, layout := range todoistDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, true
+		}
+	}
+	return nil, false
+}
+
+// extractTodoistLabels вырезает @метки из текста задачи
+func extractTodoistLabels(content string) (string, []string) {
+	var labels []string
+	for _, m := range todoistLabelRe.FindAllStringSubmatch(content, -1) {
+		labels = append(labels, m[2])
+	}
+	return strings.TrimSpace(todoistLabelRe.ReplaceAllString(content, "$1")), labels
+}
+
+// ------ CSV -------
+
+func parseTodoistCSV(data, project string, report *ImportReport) error {
+	r := csv.NewReader(strings.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("read header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"TYPE", "CONTENT"} {
+		if _, ok := index[required]; !ok {
+			return fmt.Errorf("%w: missing %s column", ErrInvalidImportFormat, required)
+		}
+	}
+
+	get := func(record []string, column string) string {
+		i, ok := index[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var (
+		section string
+		parents []*domain.Task // parents[i] - последняя задача с отступом i+1
+	)
+
+	for row := 2; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read row %d: %w", row, err)
+		}
+
+		ref := "row " + strconv.Itoa(row)
+		content := get(record, "CONTENT")
+
+		switch strings.ToLower(get(record, "TYPE")) {
+		case "task":
+		case "section":
+			section = content
+			parents = parents[:0]
+			continue
+		case "note":
+			report.skip(ref, "", content, "comments are not supported")
+			continue
+		case "":
+			continue
+		default:
+			report.skip(ref, "", content, "unknown row type "+get(record, "TYPE"))
+			continue
+		}
+
+		title, labels := extractTodoistLabels(content)
+
+		level := 4
+		if p := get(record, "PRIORITY"); p != "" {
+			if level, err = strconv.Atoi(p); err != nil {
+				level = 0
+			}
+		}
+		priority := todoistPriority(ref, level, report)
+
+		var dueDate *time.Time
+		if date := get(record, "DATE"); date != "" {
+			var ok bool
+			if dueDate, ok = parseTodoistDate(date); !ok {
+				report.skip(ref, "DATE", date, "natural language and recurring dates are not supported")
+			}
+		}
+
+		task, err := domain.NewTask(title, priority, dueDate)
+		if err != nil {
+			report.skip(ref, "", content, err.Error())
+			continue
+		}
+		task.Project = project
+
+		for _, label := range labels {
+			task.AddTag(label)
+		}
+		if len(labels) > 0 {
+			report.transform(ref, "CONTENT", strings.Join(labels, ", "), "labels imported as tags")
+		}
+		if section != "" {
+			task.AddTag(section)
+			report.transform(ref, "section", section, "imported as tag")
+		}
+		if d := get(record, "DESCRIPTION"); d != "" {
+			report.skip(ref, "DESCRIPTION", d, "not supported")
+		}
+		if a := get(record, "RESPONSIBLE"); a != "" {
+			report.skip(ref, "RESPONSIBLE", a, "not supported")
+		}
+
+		indent, _ := strconv.Atoi(get(record, "INDENT"))
+		if indent < 1 {
+			indent = 1
+		}
+		if indent > len(parents)+1 {
+			indent = len(parents) + 1
+		}
+		parents = parents[:indent-1]
+		if len(parents) > 0 {
+			parentID := parents[len(parents)-1].ID
+			task.ParentID = &parentID
+		}
+		parents = append(parents, task)
+
+		report.Tasks = append(report.Tasks, task)
+	}
+
+	return nil
+}
+
+// ------ JSON -------
+
+type todoistExport struct {
+	Items    []todoistItem   `json:"items"`
+	Projects []todoistEntity `json:"projects"`
+	Sections []todoistEntity `json:"sections"`
+}
+
+type todoistEntity struct {
+	ID   todoistID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// todoistID - старые версии API отдают ID числом, новые строкой
+type todoistID string
+
+func (id *todoistID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = todoistID(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*id = todoistID(n.String())
+	return nil
+}
+
+type todoistItem struct {
+	ID          todoistID `json:"id"`
+	Content     string    `json:"content"`
+	Description string    `json:"description"`
+	ProjectID   todoistID `json:"project_id"`
+	SectionID   todoistID `json:"section_id"`
+	ParentID    todoistID `json:"parent_id"`
+	Priority    int       `json:"priority"` // в API 4 - наивысший (p1)
+	Labels      []string  `json:"labels"`
+	Checked     bool      `json:"checked"`
+	IsCompleted bool      `json:"is_completed"`
+	IsDeleted   bool      `json:"is_deleted"`
+	AddedAt     string    `json:"added_at"`
+	CreatedAt   string    `json:"created_at"`
+	CompletedAt string    `json:"completed_at"`
+	Due         *struct {
+		Date        string `json:"date"`
+		Datetime    string `json:"datetime"`
+		IsRecurring bool   `json:"is_recurring"`
+		String      string `json:"string"`
+	} `json:"due"`
+}
+
+func parseTodoistJSON(data, project string, report *ImportReport) error {
+	var export todoistExport
+
+	// Sync API отдает объект с items/projects/sections, REST API - массив задач
+	trimmed := strings.TrimSpace(data)
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &export.Items); err != nil {
+			return fmt.Errorf("parse todoist export: %w", err)
+		}
+	} else if err := json.Unmarshal([]byte(trimmed), &export); err != nil {
+		return fmt.Errorf("parse todoist export: %w", err)
+	}
+
+	projects := make(map[todoistID]string, len(export.Projects))
+	for _, p := range export.Projects {
+		projects[p.ID] = p.Name
+	}
+	sections := make(map[todoistID]string, len(export.Sections))
+	for _, s := range export.Sections {
+		sections[s.ID] = s.Name
+	}
+
+	// Родитель может идти в экспорте после подзадачи, связи проставляем в конце
+	imported := make(map[todoistID]bool, len(export.Items))
+	parentOf := make(map[*domain.Task]todoistID)
+
+	for _, item := range export.Items {
+		ref := string(item.ID)
+		if item.IsDeleted {
+			report.skip(ref, "", item.Content, "deleted task")
+			continue
+		}
+
+		title, labels := extractTodoistLabels(item.Content)
+
+		// API: 4 = p1 ... 1 = p4
+		level := 4
+		if item.Priority != 0 {
+			level = 5 - item.Priority
+		}
+		priority := todoistPriority(ref, level, report)
+
+		var dueDate *time.Time
+		if item.Due != nil {
+			value := item.Due.Datetime
+			if value == "" {
+				value = item.Due.Date
+			}
+			var ok bool
+			if dueDate, ok = parseTodoistDate(value); !ok {
+				report.skip(ref, "due", value, "invalid date")
+			}
+			if item.Due.IsRecurring {
+				report.transform(ref, "due", item.Due.String, "recurrence dropped, next date kept")
+			}
+		}
+
+		task, err := domain.NewTask(title, priority, dueDate)
+		if err != nil {
+			report.skip(ref, "", item.Content, err.Error())
+			continue
+		}
+
+		task.ID = "td_" + ref
+		if item.Checked || item.IsCompleted {
+			task.Status = domain.StatusCompleted
+			if t, ok := parseTodoistDate(item.CompletedAt); ok {
+				task.CompletedAt = t
+			}
+		}
+
+		task.Project = project
+		if name, ok := projects[item.ProjectID]; ok {
+			task.Project = name
+		}
+
+		created := item.AddedAt
+		if created == "" {
+			created = item.CreatedAt
+		}
+		if created != "" {
+			if t, ok := parseTodoistDate(created); ok {
+				task.CreatedAt = *t
+			}
+		}
+
+		for _, label := range append(item.Labels, labels...) {
+			task.AddTag(label)
+		}
+		if len(labels) > 0 {
+			report.transform(ref, "content", strings.Join(labels, ", "), "labels imported as tags")
+		}
+		if name, ok := sections[item.SectionID]; ok {
+			task.AddTag(name)
+			report.transform(ref, "section_id", name, "imported as tag")
+		}
+		if item.Description != "" {
+			report.skip(ref, "description", item.Description, "not supported")
+		}
+
+		if err := task.IsValid(); err != nil {
+			report.skip(ref, "", item.Content, err.Error())
+			continue
+		}
+
+		imported[item.ID] = true
+		if item.ParentID != "" {
+			parentOf[task] = item.ParentID
+		}
+		report.Tasks = append(report.Tasks, task)
+	}
+
+	for task, parentID := range parentOf {
+		if !imported[parentID] {
+			report.skip(strings.TrimPrefix(task.ID, "td_"), "parent_id", string(parentID), "parent task is not imported")
+			continue
+		}
+		id := "td_" + string(parentID)
+		task.ParentID = &id
+	}
+
+	return nil
+}
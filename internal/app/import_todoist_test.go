@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestImportTodoistCSV(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	data := "\ufeffTYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+		"task,Plan sprint @work @q1,,1,1,Me,,2026-03-10,en,UTC\n" +
+		"task,Estimate,,3,2,Me,,,en,UTC\n" +
+		"section,Backlog,,,,,,,,\n" +
+		"task,Refactor,Some details,4,1,Me,Alice,every monday,en,UTC\n" +
+		"note,Remember the demo,,,,,,,,\n" +
+		"task,,,4,1,Me,,,en,UTC\n"
+
+	report, err := NewImportTodoist(repo).Execute(ctx, ImportTodoistInput{Data: data, Format: TodoistFormatCSV, Project: "team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Imported != 3 {
+		t.Fatalf("imported %d, want 3; skipped %+v", report.Imported, report.Skipped)
+	}
+
+	byTitle := make(map[string]*domain.Task)
+	for _, task := range report.Tasks {
+		saved, err := repo.GetByID(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("%s not saved: %v", task.Title, err)
+		}
+		byTitle[saved.Title] = saved
+	}
+
+	plan := byTitle["Plan sprint"]
+	if plan == nil || plan.Priority != domain.PriorityHigh || plan.Project != "team" || !slices.Equal(plan.Tags, []string{"work", "q1"}) || plan.DueDate == nil {
+		t.Fatalf("labelled task = %+v", plan)
+	}
+	if est := byTitle["Estimate"]; est.ParentID == nil || *est.ParentID != plan.ID || est.Priority != domain.PriorityMedium {
+		t.Fatalf("indented task: parent %v, priority %s; want child of Plan sprint, medium", est.ParentID, est.Priority)
+	}
+	// Раздел сбрасывает вложенность и становится тегом
+	if ref := byTitle["Refactor"]; ref.ParentID != nil || !slices.Equal(ref.Tags, []string{"Backlog"}) || ref.DueDate != nil {
+		t.Fatalf("task in section = %+v", ref)
+	}
+
+	for _, skipped := range []struct{ ref, field string }{
+		{"row 5", "DATE"}, {"row 5", "DESCRIPTION"}, {"row 5", "RESPONSIBLE"}, {"row 6", ""}, {"row 7", ""},
+	} {
+		if !hasNote(report.Skipped, skipped.ref, skipped.field) {
+			t.Errorf("skipped %s/%s is missing from %+v", skipped.ref, skipped.field, report.Skipped)
+		}
+	}
+	for _, transformed := range []struct{ ref, field string }{
+		{"row 2", "CONTENT"}, {"row 3", "priority"}, {"row 5", "section"},
+	} {
+		if !hasNote(report.Transformed, transformed.ref, transformed.field) {
+			t.Errorf("transformed %s/%s is missing from %+v", transformed.ref, transformed.field, report.Transformed)
+		}
+	}
+}
+
+func TestImportTodoistJSON(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	data := `{
+	  "projects": [{"id": "p1", "name": "Personal"}],
+	  "sections": [{"id": 7, "name": "Errands"}],
+	  "items": [
+	    {"id": "100", "content": "Child @home", "project_id": "p1", "parent_id": 200, "priority": 1},
+	    {"id": 200, "content": "Parent", "project_id": "p1", "section_id": 7, "priority": 4, "description": "why",
+	     "due": {"date": "2026-03-10", "is_recurring": true, "string": "every day"}},
+	    {"id": "300", "content": "Orphan", "parent_id": "999", "checked": true, "completed_at": "2026-03-01T10:00:00Z"},
+	    {"id": "400", "content": "Gone", "is_deleted": true}
+	  ]
+	}`
+
+	report, err := NewImportTodoist(repo).Execute(ctx, ImportTodoistInput{Data: data, Format: TodoistFormatJSON, Project: "fallback"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Imported != 3 {
+		t.Fatalf("imported %d, want 3", report.Imported)
+	}
+
+	child, _ := repo.GetByID(ctx, "td_100")
+	parent, _ := repo.GetByID(ctx, "td_200")
+	orphan, _ := repo.GetByID(ctx, "td_300")
+	// Родитель идет в экспорте после подзадачи
+	if child.ParentID == nil || *child.ParentID != "td_200" || child.Project != "Personal" || !child.HasTag("home") {
+		t.Fatalf("child = %+v", child)
+	}
+	if parent.Priority != domain.PriorityHigh || parent.DueDate == nil || !parent.HasTag("Errands") {
+		t.Fatalf("parent = %+v", parent)
+	}
+	if orphan.ParentID != nil || !orphan.IsClosed(repo.Flow) || orphan.CompletedAt == nil || orphan.Project != "fallback" {
+		t.Fatalf("orphan = %+v", orphan)
+	}
+
+	for _, note := range []struct{ ref, field string }{{"200", "description"}, {"300", "parent_id"}, {"400", ""}} {
+		if !hasNote(report.Skipped, note.ref, note.field) {
+			t.Errorf("skipped %s/%s is missing from %+v", note.ref, note.field, report.Skipped)
+		}
+	}
+	for _, note := range []struct{ ref, field string }{{"100", "content"}, {"200", "due"}, {"200", "section_id"}} {
+		if !hasNote(report.Transformed, note.ref, note.field) {
+			t.Errorf("transformed %s/%s is missing from %+v", note.ref, note.field, report.Transformed)
+		}
+	}
+}
+
+func TestImportTodoistRESTArrayAndFormat(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+
+	report, err := NewImportTodoist(repo).Execute(ctx, ImportTodoistInput{
+		Data:   `[{"id": "1", "content": "From REST", "priority": 3}]`,
+		Format: TodoistFormatJSON,
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// priority 3 в API - это p2
+	if len(report.Tasks) != 1 || report.Tasks[0].Priority != domain.PriorityHigh || !hasNote(report.Transformed, "1", "priority") {
+		t.Fatalf("REST array: tasks %+v, transformed %+v", report.Tasks, report.Transformed)
+	}
+
+	if _, err := NewImportTodoist(repo).Execute(ctx, ImportTodoistInput{Data: "[]", Format: "xml"}); !errors.Is(err, ErrInvalidImportFormat) {
+		t.Fatalf("unknown format: err = %v", err)
+	}
+}
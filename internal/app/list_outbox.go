@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const defaultOutboxListLimit = 100
+
+type ListOutbox struct {
+	repo domain.TaskRepository
+}
+
+func NewListOutbox(repo domain.TaskRepository) ListOutbox {
+	return ListOutbox{repo: repo}
+}
+
+type ListOutboxInput struct {
+	Status string `json:"status"` // по умолчанию dead - очередь недоставленных
+	Limit  int    `json:"limit,omitempty"`
+}
+
+type OutboxEntryOutput struct {
+	ID            int64      `json:"id"`
+	EventType     string     `json:"event_type"`
+	TaskID        string     `json:"task_id"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
+
+func (uc ListOutbox) Execute(ctx context.Context, in ListOutboxInput) ([]OutboxEntryOutput, error) {
+	status := domain.OutboxStatus(in.Status)
+	if status == "" {
+		status = domain.OutboxDead
+	}
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultOutboxListLimit
+	}
+
+	entries, err := uc.repo.Outbox().ListByStatus(ctx, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list outbox: %w", err)
+	}
+
+	out := make([]OutboxEntryOutput, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, OutboxEntryOutput{
+			ID:            e.ID,
+			EventType:     e.EventType,
+			TaskID:        e.TaskID,
+			Status:        string(e.Status),
+			Attempts:      e.Attempts,
+			LastError:     e.LastError,
+			CreatedAt:     e.CreatedAt,
+			NextAttemptAt: e.NextAttemptAt,
+			DeliveredAt:   e.DeliveredAt,
+		})
+	}
+
+	return out, nil
+}
+
+// RetryOutboxEntry возвращает запись из dead-letter в очередь
+type RetryOutboxEntry struct {
+	repo domain.TaskRepository
+}
+
+func NewRetryOutboxEntry(repo domain.TaskRepository) RetryOutboxEntry {
+	return RetryOutboxEntry{repo: repo}
+}
+
+func (uc RetryOutboxEntry) Execute(ctx context.Context, id int64) error {
+	if err := uc.repo.Outbox().Requeue(ctx, id, time.Now()); err != nil {
+		return fmt.Errorf("requeue outbox entry: %w", err)
+	}
+	return nil
+}
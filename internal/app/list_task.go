@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 	"errors"
 	"github.com/w0ikid/dekstop-todo-app/internal/domain"
@@ -17,9 +18,23 @@ func NewListTasks(repo domain.TaskRepository) ListTasks {
 }
 
 type ListTasksInput struct {
-	Status   *string `json:"status,omitempty"`
+	Status   *string `json:"status,omitempty"` // ключ статуса или категория "open"/"closed"
 	Priority *string `json:"priority,omitempty"`
-	Filter   *string `json:"filter,omitempty"` // "today", "week", "overdue"
+	Filter   *string `json:"filter,omitempty"` // "today", "week", "overdue", "ready", "blocked", "deferred"
+	// IncludeDeferred - показать и отложенные задачи; фильтр "deferred" показывает только их
+	IncludeDeferred bool `json:"include_deferred,omitempty"`
+	// FieldFilters - условия по пользовательским полям, должны выполняться все
+	FieldFilters []FieldFilter `json:"field_filters,omitempty"`
+	// SortField - ключ пользовательского поля для сортировки; задачи без значения в конце
+	SortField string `json:"sort_field,omitempty"`
+	SortDesc  bool   `json:"sort_desc,omitempty"`
+}
+
+// FieldFilter - условие по пользовательскому полю, Op - один из domain.FieldOp*
+type FieldFilter struct {
+	Key   string `json:"key"`
+	Op    string `json:"op"`
+	Value any    `json:"value,omitempty"`
 }
 
 type ListTasksOutput struct {
@@ -40,12 +55,20 @@ func (uc ListTasks) Execute(ctx context.Context, in ListTasksInput) (ListTasksOu
 			tasks, err = uc.getTasksDueThisWeek(ctx)
 		case "overdue":
 			tasks, err = uc.getOverdueTasks(ctx)
+		case "ready":
+			tasks, _, err = splitByReadiness(ctx, uc.repo)
+		case "blocked":
+			_, tasks, err = splitByReadiness(ctx, uc.repo)
+		case "deferred":
+			tasks, err = uc.repo.GetAll(ctx)
 		default:
 			return ListTasksOutput{}, errors.New("invalid filter")
 		}
+	} else if len(in.FieldFilters) > 0 {
+		// Условия по полям отбираются в БД, точная проверка - ниже в applyCustomFields
+		tasks, err = uc.findByCustomFields(ctx, in)
 	} else if in.Status != nil {
-		status := domain.TaskStatus(*in.Status)
-		tasks, err = uc.repo.GetByStatus(ctx, status)
+		tasks, err = uc.getByStatusOrCategory(ctx, *in.Status)
 	} else {
 		tasks, err = uc.repo.GetAll(ctx)
 	}
@@ -54,6 +77,18 @@ func (uc ListTasks) Execute(ctx context.Context, in ListTasksInput) (ListTasksOu
 		return ListTasksOutput{}, fmt.Errorf("get tasks: %w", err)
 	}
 
+	// Отложенные задачи скрыты, пока не наступит их дата начала
+	if deferredOnly := in.Filter != nil && *in.Filter == "deferred"; deferredOnly || !in.IncludeDeferred {
+		now := time.Now()
+		filtered := make([]*domain.Task, 0, len(tasks))
+		for _, task := range tasks {
+			if task.IsDeferred(now) == deferredOnly {
+				filtered = append(filtered, task)
+			}
+		}
+		tasks = filtered
+	}
+
 	// Фильтр по приоритету
 	if in.Priority != nil {
 		priority := domain.Priority(*in.Priority)
@@ -66,12 +101,126 @@ func (uc ListTasks) Execute(ctx context.Context, in ListTasksInput) (ListTasksOu
 		tasks = filtered
 	}
 
+	if len(in.FieldFilters) > 0 || in.SortField != "" {
+		if tasks, err = uc.applyCustomFields(ctx, tasks, in); err != nil {
+			return ListTasksOutput{}, err
+		}
+	}
+
 	return ListTasksOutput{
 		Tasks: tasks,
 		Total: len(tasks),
 	}, nil
 }
 
+// applyCustomFields фильтрует и сортирует задачи по значениям пользовательских полей
+func (uc ListTasks) applyCustomFields(ctx context.Context, tasks []*domain.Task, in ListTasksInput) ([]*domain.Task, error) {
+	fields, err := uc.repo.CustomFields().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get custom fields: %w", err)
+	}
+	lookup := func(key string) (domain.CustomField, error) {
+		f, ok := domain.LookupCustomField(fields, key)
+		if !ok {
+			return f, fmt.Errorf("%w: %s", domain.ErrCustomFieldNotFound, key)
+		}
+		return f, nil
+	}
+
+	for _, filter := range in.FieldFilters {
+		f, err := lookup(filter.Key)
+		if err != nil {
+			return nil, err
+		}
+		filtered := make([]*domain.Task, 0, len(tasks))
+		for _, task := range tasks {
+			ok, err := f.Matches(task.CustomFields[f.Key], filter.Op, filter.Value)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				filtered = append(filtered, task)
+			}
+		}
+		tasks = filtered
+	}
+
+	if in.SortField != "" {
+		f, err := lookup(in.SortField)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortStableFunc(tasks, func(a, b *domain.Task) int {
+			x, y := a.CustomFields[f.Key], b.CustomFields[f.Key]
+			switch {
+			case x == nil && y == nil:
+				return 0
+			case x == nil:
+				return 1
+			case y == nil:
+				return -1
+			}
+			if in.SortDesc {
+				return f.Compare(y, x)
+			}
+			return f.Compare(x, y)
+		})
+	}
+
+	return tasks, nil
+}
+
+// findByCustomFields отбирает задачи по статусу и условиям на поля запросом к БД
+func (uc ListTasks) findByCustomFields(ctx context.Context, in ListTasksInput) ([]*domain.Task, error) {
+	fields, err := uc.repo.CustomFields().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get custom fields: %w", err)
+	}
+
+	var q domain.CustomFieldQuery
+	if in.Status != nil {
+		if q.Statuses, err = uc.statusesOf(ctx, *in.Status); err != nil {
+			return nil, err
+		}
+		// Пустая категория не должна превратиться в "любой статус"
+		if len(q.Statuses) == 0 {
+			return []*domain.Task{}, nil
+		}
+	}
+	for _, filter := range in.FieldFilters {
+		f, ok := domain.LookupCustomField(fields, filter.Key)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrCustomFieldNotFound, filter.Key)
+		}
+		if err := q.Narrow(f, filter.Op, filter.Value); err != nil {
+			return nil, err
+		}
+	}
+	return uc.repo.CustomFields().FindTasks(ctx, q)
+}
+
+func (uc ListTasks) getByStatusOrCategory(ctx context.Context, status string) ([]*domain.Task, error) {
+	statuses, err := uc.statusesOf(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.GetByStatus(ctx, statuses...)
+}
+
+// statusesOf - статус или все статусы категории "open"/"closed"
+func (uc ListTasks) statusesOf(ctx context.Context, status string) ([]domain.TaskStatus, error) {
+	category := domain.StatusCategory(status)
+	if category != domain.CategoryOpen && category != domain.CategoryClosed {
+		return []domain.TaskStatus{domain.TaskStatus(status)}, nil
+	}
+
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return nil, err
+	}
+	return w.InCategory(category), nil
+}
+
 func (uc ListTasks) getTasksDueToday(ctx context.Context) ([]*domain.Task, error) {
 	now := time.Now()
 	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
@@ -97,11 +246,15 @@ func (uc ListTasks) getOverdueTasks(ctx context.Context) ([]*domain.Task, error)
 	if err != nil {
 		return nil, err
 	}
+	w, err := loadWorkflow(ctx, uc.repo)
+	if err != nil {
+		return nil, err
+	}
 
-	// Только активные просроченные задачи
+	// Только открытые просроченные задачи
 	overdue := make([]*domain.Task, 0)
 	for _, task := range tasks {
-		if task.Status == domain.StatusActive && task.IsOverdue() {
+		if task.IsOverdue(w) {
 			overdue = append(overdue, task)
 		}
 	}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const defaultDeliveryListLimit = 50
+
+type ListWebhooks struct {
+	repo domain.WebhookRepository
+}
+
+func NewListWebhooks(repo domain.WebhookRepository) ListWebhooks {
+	return ListWebhooks{repo: repo}
+}
+
+func (uc ListWebhooks) Execute(ctx context.Context) ([]WebhookOutput, error) {
+	hooks, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks: %w", err)
+	}
+
+	out := make([]WebhookOutput, 0, len(hooks))
+	for _, hook := range hooks {
+		out = append(out, newWebhookOutput(hook))
+	}
+	return out, nil
+}
+
+// ListWebhookDeliveries - журнал доставок вебхука, новые сверху
+type ListWebhookDeliveries struct {
+	repo domain.WebhookRepository
+}
+
+func NewListWebhookDeliveries(repo domain.WebhookRepository) ListWebhookDeliveries {
+	return ListWebhookDeliveries{repo: repo}
+}
+
+type ListWebhookDeliveriesInput struct {
+	WebhookID string `json:"webhook_id"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
+type WebhookDeliveryOutput struct {
+	ID         int64     `json:"id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	Success    bool      `json:"success"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newWebhookDeliveryOutput(d *domain.WebhookDelivery) WebhookDeliveryOutput {
+	return WebhookDeliveryOutput{
+		ID:         d.ID,
+		EventID:    d.EventID,
+		EventType:  d.EventType,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		DurationMs: d.Duration.Milliseconds(),
+		Success:    d.Succeeded(),
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func (uc ListWebhookDeliveries) Execute(ctx context.Context, in ListWebhookDeliveriesInput) ([]WebhookDeliveryOutput, error) {
+	if _, err := uc.repo.GetByID(ctx, in.WebhookID); err != nil {
+		return nil, fmt.Errorf("get webhook: %w", err)
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultDeliveryListLimit
+	}
+
+	deliveries, err := uc.repo.ListDeliveries(ctx, in.WebhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries: %w", err)
+	}
+
+	out := make([]WebhookDeliveryOutput, 0, len(deliveries))
+	for _, d := range deliveries {
+		out = append(out, newWebhookDeliveryOutput(d))
+	}
+	return out, nil
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// EventMessage - тело события для внешних систем
+type EventMessage struct {
+	ID         string        `json:"id"` // совпадает с ключом идемпотентности
+	Type       string        `json:"type"`
+	TaskID     string        `json:"task_id"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Version    int64         `json:"version,omitempty"`
+	Task       *SnapshotTask `json:"task,omitempty"` // нет у task.deleted
+}
+
+// TaskValue восстанавливает задачу из сообщения; nil для task.deleted
+func (m EventMessage) TaskValue() *domain.Task {
+	if m.Task == nil {
+		return nil
+	}
+	task := m.Task.toDomain()
+	task.Version = m.Version
+	return task
+}
+
+func newEventMessage(key string, event domain.Event) EventMessage {
+	msg := EventMessage{
+		ID:         key,
+		Type:       event.EventName(),
+		TaskID:     event.TaskID(),
+		OccurredAt: event.OccurredAt(),
+	}
+
+	var task domain.Task
+	switch e := event.(type) {
+	case domain.TaskCreated:
+		task = e.Task
+	case domain.TaskUpdated:
+		task = e.Task
+	case domain.TaskCompleted:
+		task = e.Task
+	case domain.TaskReopened:
+		task = e.Task
+	case domain.TaskOverdue:
+		task = e.Task
+	case domain.TaskWoke:
+		task = e.Task
+	default:
+		return msg
+	}
+
+	st := newSnapshotTask(&task)
+	msg.Task = &st
+	msg.Version = task.Version
+	return msg
+}
+
+// keyedEvent - событие со своим ключом идемпотентности
+type keyedEvent interface {
+	IdempotencyKey() string
+}
+
+// recordEvents пишет события в outbox; вызывается внутри WithTx вместе с изменением задачи
+func recordEvents(ctx context.Context, repo domain.TaskRepository, events ...domain.Event) error {
+	for _, event := range events {
+		if err := recordEvent(ctx, repo, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordEvent возвращает domain.ErrDuplicateEvent, если событие с тем же ключом уже есть
+func recordEvent(ctx context.Context, repo domain.TaskRepository, event domain.Event) error {
+	var key string
+	if keyed, ok := event.(keyedEvent); ok {
+		key = keyed.IdempotencyKey()
+	} else {
+		var err error
+		if key, err = newIdempotencyKey(); err != nil {
+			return fmt.Errorf("idempotency key: %w", err)
+		}
+	}
+
+	msg := newEventMessage(key, event)
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	entry := &domain.OutboxEntry{
+		IdempotencyKey: key,
+		EventType:      event.EventName(),
+		TaskID:         event.TaskID(),
+		Payload:        payload,
+		CreatedAt:      event.OccurredAt(),
+	}
+	if err := repo.Outbox().Add(ctx, entry); err != nil {
+		return fmt.Errorf("add outbox entry: %w", err)
+	}
+
+	// История задачи; у task.deleted снапшота нет, а его записи уходят каскадом вместе с задачей
+	if msg.Task != nil {
+		snapshot, err := json.Marshal(msg.Task)
+		if err != nil {
+			return fmt.Errorf("encode snapshot: %w", err)
+		}
+		activity := &domain.ActivityEntry{
+			TaskID:     msg.TaskID,
+			EventType:  msg.Type,
+			Snapshot:   snapshot,
+			OccurredAt: msg.OccurredAt,
+		}
+		if err := repo.Activity().Add(ctx, activity); err != nil {
+			return fmt.Errorf("add activity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "evt_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// SaveWebhook создает подписку или обновляет существующую, если задан ID
+type SaveWebhook struct {
+	repo domain.WebhookRepository
+}
+
+func NewSaveWebhook(repo domain.WebhookRepository) SaveWebhook {
+	return SaveWebhook{repo: repo}
+}
+
+type SaveWebhookInput struct {
+	ID         string   `json:"id,omitempty"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Filter     string   `json:"filter"`
+	Secret     *string  `json:"secret,omitempty"` // nil при обновлении - оставить прежний
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+type WebhookOutput struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Filter     string    `json:"filter"`
+	HasSecret  bool      `json:"has_secret"` // сам секрет наружу не отдаем
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func newWebhookOutput(hook *domain.Webhook) WebhookOutput {
+	eventTypes := hook.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return WebhookOutput{
+		ID:         hook.ID,
+		URL:        hook.URL,
+		EventTypes: eventTypes,
+		Filter:     hook.Filter,
+		HasSecret:  hook.Secret != "",
+		Enabled:    hook.Enabled,
+		CreatedAt:  hook.CreatedAt,
+		UpdatedAt:  hook.UpdatedAt,
+	}
+}
+
+func (uc SaveWebhook) Execute(ctx context.Context, in SaveWebhookInput) (WebhookOutput, error) {
+	var hook *domain.Webhook
+
+	if in.ID == "" {
+		secret := ""
+		if in.Secret != nil {
+			secret = *in.Secret
+		}
+
+		var err error
+		hook, err = domain.NewWebhook(in.URL, in.EventTypes, in.Filter, secret)
+		if err != nil {
+			return WebhookOutput{}, fmt.Errorf("create webhook: %w", err)
+		}
+	} else {
+		var err error
+		hook, err = uc.repo.GetByID(ctx, in.ID)
+		if err != nil {
+			return WebhookOutput{}, fmt.Errorf("get webhook: %w", err)
+		}
+
+		hook.URL = in.URL
+		hook.EventTypes = in.EventTypes
+		hook.Filter = in.Filter
+		if in.Secret != nil {
+			hook.Secret = *in.Secret
+		}
+	}
+
+	if in.Enabled != nil {
+		hook.Enabled = *in.Enabled
+	}
+
+	if err := hook.IsValid(); err != nil {
+		return WebhookOutput{}, fmt.Errorf("validate webhook: %w", err)
+	}
+
+	if err := uc.repo.Save(ctx, hook); err != nil {
+		return WebhookOutput{}, fmt.Errorf("save webhook: %w", err)
+	}
+
+	return newWebhookOutput(hook), nil
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// SendTestWebhook сразу отправляет webhook.test, минуя outbox, и пишет результат в журнал
+type SendTestWebhook struct {
+	repo   domain.WebhookRepository
+	client domain.WebhookClient
+}
+
+func NewSendTestWebhook(repo domain.WebhookRepository, client domain.WebhookClient) SendTestWebhook {
+	return SendTestWebhook{repo: repo, client: client}
+}
+
+func (uc SendTestWebhook) Execute(ctx context.Context, webhookID string) (WebhookDeliveryOutput, error) {
+	hook, err := uc.repo.GetByID(ctx, webhookID)
+	if err != nil {
+		return WebhookDeliveryOutput{}, fmt.Errorf("get webhook: %w", err)
+	}
+
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return WebhookDeliveryOutput{}, fmt.Errorf("idempotency key: %w", err)
+	}
+
+	payload, err := json.Marshal(EventMessage{
+		ID:         key,
+		Type:       domain.EventWebhookTest,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		return WebhookDeliveryOutput{}, fmt.Errorf("encode event: %w", err)
+	}
+
+	delivery := uc.client.Post(ctx, hook, key, domain.EventWebhookTest, payload)
+	if err := uc.repo.AddDelivery(ctx, delivery); err != nil {
+		return WebhookDeliveryOutput{}, fmt.Errorf("log delivery: %w", err)
+	}
+
+	return newWebhookDeliveryOutput(delivery), nil
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// Готовые варианты отсрочки
+const (
+	SnoozeTomorrow = "tomorrow"  // завтра утром
+	SnoozeNextWeek = "next_week" // утро ближайшего понедельника
+)
+
+var ErrInvalidSnooze = errors.New("invalid snooze")
+
+// SnoozeTask откладывает задачу: до StartDate она не видна в списке задач
+type SnoozeTask struct {
+	repo    domain.TaskRepository
+	events  domain.EventPublisher
+	morning time.Duration // "утро" для готовых вариантов, смещение от начала дня
+}
+
+func NewSnoozeTask(repo domain.TaskRepository, events domain.EventPublisher, morning time.Duration) SnoozeTask {
+	return SnoozeTask{repo: repo, events: events, morning: morning}
+}
+
+type SnoozeTaskInput struct {
+	TaskID string     `json:"task_id"`
+	Until  *time.Time `json:"until,omitempty"`  // точное время, важнее Preset
+	Preset string     `json:"preset,omitempty"` // "tomorrow" или "next_week"
+}
+
+type SnoozeTaskOutput struct {
+	StartDate time.Time `json:"start_date"`
+}
+
+func (uc SnoozeTask) Execute(ctx context.Context, in SnoozeTaskInput) (SnoozeTaskOutput, error) {
+	now := time.Now()
+	until, err := uc.until(in, now)
+	if err != nil {
+		return SnoozeTaskOutput{}, err
+	}
+
+	var event domain.Event
+	err = uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		task, err := repo.GetByID(ctx, in.TaskID)
+		if err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+
+		task.StartDate = &until
+		if err := repo.Save(ctx, task); err != nil {
+			return fmt.Errorf("save task: %w", err)
+		}
+
+		event = domain.TaskUpdated{Task: task.Snapshot(), At: task.UpdatedAt}
+		return recordEvents(ctx, repo, event)
+	})
+	if err != nil {
+		return SnoozeTaskOutput{}, err
+	}
+
+	uc.events.Publish(ctx, event)
+	return SnoozeTaskOutput{StartDate: until}, nil
+}
+
+func (uc SnoozeTask) until(in SnoozeTaskInput, now time.Time) (time.Time, error) {
+	if in.Until != nil {
+		if !in.Until.After(now) {
+			return time.Time{}, fmt.Errorf("%w: time is in the past", ErrInvalidSnooze)
+		}
+		return *in.Until, nil
+	}
+
+	today := startOfDay(now)
+	switch in.Preset {
+	case SnoozeTomorrow:
+		return today.AddDate(0, 0, 1).Add(uc.morning), nil
+	case SnoozeNextWeek:
+		// В понедельник - следующий понедельник, а не сегодня
+		days := (int(time.Monday)-int(now.Weekday())+6)%7 + 1
+		return today.AddDate(0, 0, days).Add(uc.morning), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: unknown preset %q", ErrInvalidSnooze, in.Preset)
+}
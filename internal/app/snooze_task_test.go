@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestSnoozeUntilPresets(t *testing.T) {
+	uc := NewSnoozeTask(nil, nil, 9*time.Hour)
+	at := func(day, hour int) time.Time { return time.Date(2026, 3, day, hour, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		preset string
+		want   time.Time
+	}{
+		// 2 марта 2026 - понедельник
+		{"next week on Monday morning", at(2, 8), SnoozeNextWeek, at(9, 9)},
+		{"next week on Monday after morning", at(2, 10), SnoozeNextWeek, at(9, 9)},
+		{"next week at Monday midnight", at(2, 0), SnoozeNextWeek, at(9, 9)},
+		{"next week on Sunday", at(8, 22), SnoozeNextWeek, at(9, 9)},
+		{"next week on Saturday", at(7, 12), SnoozeNextWeek, at(9, 9)},
+		{"next week on Tuesday", at(3, 12), SnoozeNextWeek, at(9, 9)},
+		{"tomorrow", at(2, 23), SnoozeTomorrow, at(3, 9)},
+		{"tomorrow at month end", time.Date(2026, 2, 28, 12, 0, 0, 0, time.Local), SnoozeTomorrow, at(1, 9)},
+	}
+	for _, tt := range tests {
+		got, err := uc.until(SnoozeTaskInput{Preset: tt.preset}, tt.now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("%s: until = %v, %v; want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	now := at(2, 12)
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+	if _, err := uc.until(SnoozeTaskInput{Until: &past, Preset: SnoozeTomorrow}, now); !errors.Is(err, ErrInvalidSnooze) {
+		t.Errorf("past time: err = %v", err)
+	}
+	if got, err := uc.until(SnoozeTaskInput{Until: &future, Preset: SnoozeTomorrow}, now); err != nil || !got.Equal(future) {
+		t.Errorf("exact time over preset = %v, %v", got, err)
+	}
+	if _, err := uc.until(SnoozeTaskInput{Preset: "someday"}, now); !errors.Is(err, ErrInvalidSnooze) {
+		t.Errorf("unknown preset: err = %v", err)
+	}
+}
+
+func TestDetectWakeupsOncePerStartDate(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+
+	repo.Put(
+		&domain.Task{ID: "due", Title: "Due", Status: domain.StatusActive, StartDate: ago(5 * time.Minute)},
+		&domain.Task{ID: "closed", Title: "Closed", Status: domain.StatusCompleted, StartDate: ago(5 * time.Minute)},
+		&domain.Task{ID: "old", Title: "Old", Status: domain.StatusActive, StartDate: ago(2 * time.Hour)}, // за пределами lookback
+		&domain.Task{ID: "later", Title: "Later", Status: domain.StatusActive, StartDate: ago(-time.Hour)},
+	)
+	detect := NewDetectWakeups(repo, testutil.NopPublisher{}, time.Hour)
+
+	if n, err := detect.Execute(ctx); err != nil || n != 1 {
+		t.Fatalf("first run = %d, %v; want 1", n, err)
+	}
+	// Повторный проход по тому же окну ничего не шлет
+	if n, err := detect.Execute(ctx); err != nil || n != 0 {
+		t.Fatalf("second run = %d, %v; want 0", n, err)
+	}
+	if len(repo.OutboxEntries) != 1 || repo.OutboxEntries[0].EventType != domain.EventTaskWoke {
+		t.Fatalf("outbox = %+v", repo.OutboxEntries)
+	}
+
+	// Отложили заново - новая дата начала, новое пробуждение
+	task, _ := repo.GetByID(ctx, "due")
+	task.StartDate = ago(time.Minute)
+	repo.Put(task)
+	if n, err := detect.Execute(ctx); err != nil || n != 1 {
+		t.Fatalf("after re-snooze = %d, %v; want 1", n, err)
+	}
+}
+
+func TestSnoozeTaskSavesStartDate(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	repo.Put(&domain.Task{ID: "t1", Title: "Later", Status: domain.StatusActive})
+
+	until := time.Now().Add(48 * time.Hour)
+	out, err := NewSnoozeTask(repo, testutil.NopPublisher{}, 9*time.Hour).Execute(ctx, SnoozeTaskInput{TaskID: "t1", Until: &until})
+	if err != nil || !out.StartDate.Equal(until) {
+		t.Fatalf("snooze = %+v, %v", out, err)
+	}
+	task, _ := repo.GetByID(ctx, "t1")
+	if task.StartDate == nil || !task.StartDate.Equal(until) || !task.IsDeferred(time.Now()) {
+		t.Fatalf("start date = %v, want deferred until %v", task.StartDate, until)
+	}
+	if len(repo.OutboxEntries) != 1 || repo.OutboxEntries[0].EventType != domain.EventTaskUpdated {
+		t.Fatalf("outbox = %+v", repo.OutboxEntries)
+	}
+}
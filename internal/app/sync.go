@@ -0,0 +1,307 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// Сколько изменений сервер отдает за один обмен
+const syncPageLimit = 500
+
+// SyncTaskRecord - задача в протоколе синхронизации
+type SyncTaskRecord struct {
+	Task   SnapshotTask          `json:"task"`
+	Clocks map[string]domain.HLC `json:"clocks"`
+}
+
+type SyncTombstoneRecord struct {
+	ID        string     `json:"id"`
+	Clock     domain.HLC `json:"clock"`
+	DeletedAt time.Time  `json:"deleted_at"`
+}
+
+// SyncRequest - локальные изменения клиента и курсор последнего полученного изменения сервера
+type SyncRequest struct {
+	Node       string                `json:"node"`
+	Since      int64                 `json:"since"`
+	Records    []SyncTaskRecord      `json:"records,omitempty"`
+	Tombstones []SyncTombstoneRecord `json:"tombstones,omitempty"`
+}
+
+// SyncResponse - изменения сервера после Since; More - есть еще страница
+type SyncResponse struct {
+	Cursor     int64                 `json:"cursor"`
+	Records    []SyncTaskRecord      `json:"records,omitempty"`
+	Tombstones []SyncTombstoneRecord `json:"tombstones,omitempty"`
+	More       bool                  `json:"more"`
+}
+
+// SyncTransport доставляет запрос на сервер синхронизации
+type SyncTransport interface {
+	Exchange(ctx context.Context, req SyncRequest) (SyncResponse, error)
+}
+
+type SyncOutput struct {
+	Pushed int `json:"pushed"`
+	Pulled int `json:"pulled"`
+}
+
+// SyncNow отправляет локальные изменения на сервер и применяет пришедшие
+type SyncNow struct {
+	repo      domain.TaskRepository
+	clock     *domain.Clock
+	transport SyncTransport // nil - синхронизация выключена
+}
+
+func NewSyncNow(repo domain.TaskRepository, clock *domain.Clock, transport SyncTransport) SyncNow {
+	return SyncNow{repo: repo, clock: clock, transport: transport}
+}
+
+func (uc SyncNow) Execute(ctx context.Context) (SyncOutput, error) {
+	var out SyncOutput
+	if uc.transport == nil {
+		return out, domain.ErrSyncNotConfigured
+	}
+
+	sync := uc.repo.Sync()
+	records, tombstones, err := sync.Pending(ctx)
+	if err != nil {
+		return out, fmt.Errorf("get pending changes: %w", err)
+	}
+	cursor, err := sync.Cursor(ctx)
+	if err != nil {
+		return out, fmt.Errorf("get sync cursor: %w", err)
+	}
+
+	req := SyncRequest{
+		Node:       uc.clock.Node(),
+		Since:      cursor,
+		Records:    newSyncTaskRecords(records),
+		Tombstones: newSyncTombstoneRecords(tombstones),
+	}
+
+	for {
+		resp, err := uc.transport.Exchange(ctx, req)
+		if err != nil {
+			return out, fmt.Errorf("exchange: %w", err)
+		}
+
+		err = uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+			// Отметку снимаем до применения: если слияние изменит запись, она уйдет повторно
+			if len(req.Records) > 0 || len(req.Tombstones) > 0 {
+				if err := repo.Sync().MarkSynced(ctx, records, tombstones); err != nil {
+					return fmt.Errorf("mark synced: %w", err)
+				}
+			}
+
+			if err := applyRemote(ctx, repo, uc.clock, resp.Records, resp.Tombstones); err != nil {
+				return err
+			}
+
+			if err := repo.Sync().SetCursor(ctx, resp.Cursor); err != nil {
+				return fmt.Errorf("set sync cursor: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return out, err
+		}
+
+		out.Pushed += len(req.Records) + len(req.Tombstones)
+		out.Pulled += len(resp.Records) + len(resp.Tombstones)
+
+		if !resp.More {
+			return out, nil
+		}
+		req = SyncRequest{Node: uc.clock.Node(), Since: resp.Cursor}
+	}
+}
+
+// ServeSync - сторона сервера: применяет изменения клиента и отдает изменения после курсора.
+// Вызовы должны идти последовательно, иначе курсор может перескочить незакоммиченные номера.
+type ServeSync struct {
+	repo  domain.TaskRepository
+	clock *domain.Clock
+}
+
+func NewServeSync(repo domain.TaskRepository, clock *domain.Clock) ServeSync {
+	return ServeSync{repo: repo, clock: clock}
+}
+
+func (uc ServeSync) Execute(ctx context.Context, req SyncRequest) (SyncResponse, error) {
+	if len(req.Records) > 0 || len(req.Tombstones) > 0 {
+		err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+			return applyRemote(ctx, repo, uc.clock, req.Records, req.Tombstones)
+		})
+		if err != nil {
+			return SyncResponse{}, err
+		}
+	}
+
+	records, tombstones, err := uc.repo.Sync().Changes(ctx, req.Since, syncPageLimit)
+	if err != nil {
+		return SyncResponse{}, fmt.Errorf("get changes: %w", err)
+	}
+
+	return newSyncPage(req.Since, records, tombstones), nil
+}
+
+// newSyncPage склеивает задачи и надгробия в порядке номеров изменений
+// и обрезает по лимиту, чтобы курсор не перескочил непрочитанное
+func newSyncPage(since int64, records []domain.SyncRecord, tombstones []domain.Tombstone) SyncResponse {
+	type change struct {
+		seq       int64
+		record    *domain.SyncRecord
+		tombstone *domain.Tombstone
+	}
+
+	changes := make([]change, 0, len(records)+len(tombstones))
+	for i := range records {
+		changes = append(changes, change{seq: records[i].Seq, record: &records[i]})
+	}
+	for i := range tombstones {
+		changes = append(changes, change{seq: tombstones[i].Seq, tombstone: &tombstones[i]})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].seq < changes[j].seq })
+
+	resp := SyncResponse{Cursor: since}
+	if len(changes) > syncPageLimit {
+		changes = changes[:syncPageLimit]
+		resp.More = true
+	}
+
+	for _, c := range changes {
+		if c.record != nil {
+			resp.Records = append(resp.Records, newSyncTaskRecord(*c.record))
+		} else {
+			resp.Tombstones = append(resp.Tombstones, newSyncTombstoneRecord(*c.tombstone))
+		}
+		resp.Cursor = c.seq
+	}
+	return resp
+}
+
+// applyRemote сливает удаленные изменения с локальными; вызывается внутри WithTx.
+// Удаление побеждает правки, поэтому сначала применяются надгробия.
+func applyRemote(ctx context.Context, repo domain.TaskRepository, clock *domain.Clock, records []SyncTaskRecord, tombstones []SyncTombstoneRecord) error {
+	sync := repo.Sync()
+	w, err := loadWorkflow(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tombstones {
+		clock.Observe(t.Clock)
+		err := sync.PutTombstone(ctx, domain.Tombstone{TaskID: t.ID, Clock: t.Clock, DeletedAt: t.DeletedAt})
+		if err != nil {
+			return fmt.Errorf("apply tombstone %s: %w", t.ID, err)
+		}
+	}
+
+	for _, rec := range records {
+		remote := rec.toDomain()
+		for _, h := range remote.Clocks {
+			clock.Observe(h)
+		}
+
+		deleted, err := sync.IsTombstoned(ctx, remote.Task.ID)
+		if err != nil {
+			return fmt.Errorf("check tombstone %s: %w", remote.Task.ID, err)
+		}
+		if deleted {
+			continue
+		}
+
+		merged := remote
+		local, err := sync.GetRecord(ctx, remote.Task.ID)
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+		case err != nil:
+			return fmt.Errorf("get task %s: %w", remote.Task.ID, err)
+		default:
+			var changed bool
+			merged, changed = domain.MergeRecords(*local, remote)
+			if !changed && !clocksAdvanced(local.Clocks, merged.Clocks) {
+				continue
+			}
+		}
+
+		// Workflow у каждого устройства свой: незнакомый статус заменяем локальным
+		fitWorkflow(w, &merged.Task)
+		if _, known := w.Status(merged.Task.Status); !known {
+			if local != nil {
+				merged.Task.Status = local.Task.Status
+			} else {
+				merged.Task.Status = w.InitialStatus()
+			}
+		}
+		// Старые клиенты не передают время закрытия: берем время смены статуса по HLC
+		merged.Task.MarkCompletion(w, statusChangedAt(merged.Clocks))
+
+		// Запись, которую не принял бы ни один use case, пропускаем, а не блокируем синхронизацию
+		if w.ValidateTask(&merged.Task) != nil {
+			continue
+		}
+
+		if err := sync.PutRecord(ctx, merged); err != nil {
+			return fmt.Errorf("apply task %s: %w", remote.Task.ID, err)
+		}
+	}
+
+	// Подзадачи, чей родитель удален на другом устройстве
+	if _, err := sync.DropOrphans(ctx, clock.Now()); err != nil {
+		return fmt.Errorf("drop orphans: %w", err)
+	}
+	return nil
+}
+
+// statusChangedAt - физическое время последней смены статуса, без метки - текущее
+func statusChangedAt(clocks domain.FieldClocks) time.Time {
+	if h := clocks[domain.SyncFieldStatus]; h.Wall > 0 {
+		return time.UnixMilli(h.Wall)
+	}
+	return time.Now()
+}
+
+// clocksAdvanced - метки обновились без изменения значений (одинаковая правка на двух устройствах)
+func clocksAdvanced(before, after domain.FieldClocks) bool {
+	for field, h := range after {
+		if h.After(before[field]) {
+			return true
+		}
+	}
+	return false
+}
+
+func newSyncTaskRecord(rec domain.SyncRecord) SyncTaskRecord {
+	return SyncTaskRecord{Task: newSnapshotTask(&rec.Task), Clocks: rec.Clocks}
+}
+
+func newSyncTaskRecords(records []domain.SyncRecord) []SyncTaskRecord {
+	out := make([]SyncTaskRecord, 0, len(records))
+	for _, rec := range records {
+		out = append(out, newSyncTaskRecord(rec))
+	}
+	return out
+}
+
+func (r SyncTaskRecord) toDomain() domain.SyncRecord {
+	return domain.SyncRecord{Task: *r.Task.toDomain(), Clocks: r.Clocks}
+}
+
+func newSyncTombstoneRecord(t domain.Tombstone) SyncTombstoneRecord {
+	return SyncTombstoneRecord{ID: t.TaskID, Clock: t.Clock, DeletedAt: t.DeletedAt}
+}
+
+func newSyncTombstoneRecords(tombstones []domain.Tombstone) []SyncTombstoneRecord {
+	out := make([]SyncTombstoneRecord, 0, len(tombstones))
+	for _, t := range tombstones {
+		out = append(out, newSyncTombstoneRecord(t))
+	}
+	return out
+}
@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// syncNode - устройство синхронизации в памяти: задачи с метками полей,
+// номера изменений и отметки неотправленного, как в postgres-репозитории
+type syncNode struct {
+	domain.TaskRepository
+
+	clock      *domain.Clock
+	records    map[string]domain.SyncRecord
+	dirty      map[string]bool
+	tombstones map[string]domain.Tombstone
+	seq        int64
+	cursor     int64
+}
+
+func newSyncNode(node string) *syncNode {
+	return &syncNode{
+		clock:      domain.NewClock(node),
+		records:    map[string]domain.SyncRecord{},
+		dirty:      map[string]bool{},
+		tombstones: map[string]domain.Tombstone{},
+	}
+}
+
+// edit - локальное изменение задачи, как Save: метки на измененные поля и отметка к отправке
+func (n *syncNode) edit(id string, fn func(task *domain.Task)) {
+	rec, exists := n.records[id]
+	var before *domain.Task
+	if exists {
+		snapshot := rec.Task.Snapshot()
+		before = &snapshot
+	} else {
+		rec.Task = domain.Task{ID: id, Status: domain.StatusActive, Priority: domain.PriorityMedium, CreatedAt: time.Now()}
+	}
+
+	task := rec.Task.Snapshot()
+	fn(&task)
+	task.MarkCompletion(domain.DefaultWorkflow(), time.Now())
+
+	n.seq++
+	n.records[id] = domain.SyncRecord{
+		Task:   task,
+		Clocks: domain.StampChanges(before, &task, rec.Clocks, n.clock.Now()),
+		Seq:    n.seq,
+	}
+	n.dirty[id] = true
+}
+
+func (n *syncNode) WithTx(_ context.Context, fn func(repo domain.TaskRepository) error) error {
+	return fn(n)
+}
+
+func (n *syncNode) Sync() domain.SyncRepository         { return n }
+func (n *syncNode) Workflow() domain.WorkflowRepository { return syncWorkflow{} }
+
+func (n *syncNode) Pending(context.Context) ([]domain.SyncRecord, []domain.Tombstone, error) {
+	var records []domain.SyncRecord
+	for id := range n.dirty {
+		records = append(records, n.records[id])
+	}
+	return records, nil, nil
+}
+
+func (n *syncNode) MarkSynced(_ context.Context, records []domain.SyncRecord, _ []domain.Tombstone) error {
+	for _, rec := range records {
+		if n.records[rec.Task.ID].Seq == rec.Seq {
+			delete(n.dirty, rec.Task.ID)
+		}
+	}
+	return nil
+}
+
+func (n *syncNode) Changes(_ context.Context, since int64, _ int) ([]domain.SyncRecord, []domain.Tombstone, error) {
+	var records []domain.SyncRecord
+	for _, rec := range n.records {
+		if rec.Seq > since {
+			records = append(records, rec)
+		}
+	}
+	return records, nil, nil
+}
+
+func (n *syncNode) GetRecord(_ context.Context, id string) (*domain.SyncRecord, error) {
+	rec, ok := n.records[id]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	return &rec, nil
+}
+
+func (n *syncNode) IsTombstoned(_ context.Context, id string) (bool, error) {
+	_, ok := n.tombstones[id]
+	return ok, nil
+}
+
+func (n *syncNode) PutRecord(_ context.Context, rec domain.SyncRecord) error {
+	n.seq++
+	rec.Seq = n.seq
+	n.records[rec.Task.ID] = rec
+	return nil
+}
+
+func (n *syncNode) PutTombstone(_ context.Context, t domain.Tombstone) error {
+	n.tombstones[t.TaskID] = t
+	delete(n.records, t.TaskID)
+	delete(n.dirty, t.TaskID)
+	return nil
+}
+
+func (n *syncNode) DropOrphans(context.Context, domain.HLC) ([]domain.Tombstone, error) {
+	return nil, nil
+}
+
+func (n *syncNode) Cursor(context.Context) (int64, error) { return n.cursor, nil }
+
+func (n *syncNode) SetCursor(_ context.Context, cursor int64) error {
+	n.cursor = cursor
+	return nil
+}
+
+type syncWorkflow struct{ domain.WorkflowRepository }
+
+func (syncWorkflow) Get(context.Context) (domain.Workflow, error) {
+	return domain.DefaultWorkflow(), nil
+}
+
+// wireTransport передает запрос серверу через JSON, как HTTP-транспорт
+type wireTransport struct {
+	t      *testing.T
+	server ServeSync
+}
+
+func (w wireTransport) Exchange(ctx context.Context, req SyncRequest) (SyncResponse, error) {
+	var decoded SyncRequest
+	w.roundTrip(req, &decoded)
+
+	resp, err := w.server.Execute(ctx, decoded)
+	if err != nil {
+		return SyncResponse{}, err
+	}
+
+	var out SyncResponse
+	w.roundTrip(resp, &out)
+	return out, nil
+}
+
+func (w wireTransport) roundTrip(in, out any) {
+	w.t.Helper()
+	data, err := json.Marshal(in)
+	if err != nil {
+		w.t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		w.t.Fatal(err)
+	}
+}
+
+func TestSyncTwoDevicesConverge(t *testing.T) {
+	ctx := context.Background()
+	server := newSyncNode("server")
+	a, b := newSyncNode("a"), newSyncNode("b")
+	transport := wireTransport{t: t, server: NewServeSync(server, server.clock)}
+
+	syncAll := func(nodes ...*syncNode) {
+		t.Helper()
+		for _, n := range nodes {
+			if _, err := NewSyncNow(n, n.clock, transport).Execute(ctx); err != nil {
+				t.Fatalf("sync %s: %v", n.clock.Node(), err)
+			}
+		}
+	}
+
+	a.edit("t", func(task *domain.Task) { task.Title = "Draft" })
+	syncAll(a, b)
+	if _, err := b.GetRecord(ctx, "t"); err != nil {
+		t.Fatalf("task did not reach b: %v", err)
+	}
+
+	// Параллельные правки: разные поля сливаются, одно и то же поле - по последней метке
+	a.edit("t", func(task *domain.Task) {
+		task.Title = "Title from a"
+		task.Tags = []string{"a"}
+	})
+	time.Sleep(2 * time.Millisecond)
+	b.edit("t", func(task *domain.Task) {
+		task.Title = "Title from b"
+		task.Priority = domain.PriorityHigh
+		task.Status = domain.StatusCompleted
+	})
+	syncAll(a, b, a)
+
+	ra, _ := a.GetRecord(ctx, "t")
+	rb, _ := b.GetRecord(ctx, "t")
+	for _, rec := range []*domain.SyncRecord{ra, rb} {
+		task := rec.Task
+		if task.Title != "Title from b" || task.Priority != domain.PriorityHigh || len(task.Tags) != 1 || task.Tags[0] != "a" {
+			t.Fatalf("not merged: title %q, priority %q, tags %v", task.Title, task.Priority, task.Tags)
+		}
+		if task.Status != domain.StatusCompleted || task.CompletedAt == nil || task.CompletedAt.IsZero() {
+			t.Fatalf("status %q, completed_at %v; want completed with time", task.Status, task.CompletedAt)
+		}
+	}
+	if ra.Clocks.Max() != rb.Clocks.Max() || !ra.Task.CompletedAt.Equal(*rb.Task.CompletedAt) {
+		t.Fatalf("devices diverged:\na: %+v %v\nb: %+v %v", ra.Task, ra.Clocks, rb.Task, rb.Clocks)
+	}
+	if len(a.dirty) != 0 || len(b.dirty) != 0 {
+		t.Fatalf("unsent changes left: a %v, b %v", a.dirty, b.dirty)
+	}
+}
+
+func TestApplyRemoteCompletedAtFromStatusClock(t *testing.T) {
+	ctx := context.Background()
+	node := newSyncNode("a")
+	changed := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Старый клиент: задача закрыта, но времени закрытия нет
+	rec := SyncTaskRecord{
+		Task: SnapshotTask{ID: "t", Title: "Old client", Status: "completed", Priority: "medium", CreatedAt: changed.Add(-time.Hour)},
+		Clocks: map[string]domain.HLC{
+			domain.SyncFieldTitle:  {Wall: changed.Add(-time.Hour).UnixMilli(), Node: "old"},
+			domain.SyncFieldStatus: {Wall: changed.UnixMilli(), Node: "old"},
+		},
+	}
+	if err := applyRemote(ctx, node, node.clock, []SyncTaskRecord{rec}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := node.GetRecord(ctx, "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Task.CompletedAt == nil || !got.Task.CompletedAt.Equal(changed) {
+		t.Fatalf("completed_at = %v, want %v", got.Task.CompletedAt, changed)
+	}
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// AddTimeEntry - ручное добавление завершенной записи времени
+type AddTimeEntry struct {
+	repo domain.TaskRepository
+}
+
+func NewAddTimeEntry(repo domain.TaskRepository) AddTimeEntry {
+	return AddTimeEntry{repo: repo}
+}
+
+type AddTimeEntryInput struct {
+	TaskID    string    `json:"task_id"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	Note      string    `json:"note,omitempty"`
+}
+
+func (uc AddTimeEntry) Execute(ctx context.Context, in AddTimeEntryInput) (TimeEntryOutput, error) {
+	entry, err := domain.NewTimeEntry(in.TaskID, in.StartedAt, in.EndedAt, in.Note)
+	if err != nil {
+		return TimeEntryOutput{}, fmt.Errorf("create time entry: %w", err)
+	}
+
+	err = uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if _, err := repo.GetByID(ctx, in.TaskID); err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+		if err := repo.TimeEntries().Save(ctx, entry); err != nil {
+			return fmt.Errorf("save time entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return TimeEntryOutput{}, err
+	}
+
+	return newTimeEntryOutput(entry, time.Now()), nil
+}
+
+// UpdateTimeEntry правит границы и заметку; EndedAt у запущенного таймера останавливает его
+type UpdateTimeEntry struct {
+	repo domain.TaskRepository
+}
+
+func NewUpdateTimeEntry(repo domain.TaskRepository) UpdateTimeEntry {
+	return UpdateTimeEntry{repo: repo}
+}
+
+type UpdateTimeEntryInput struct {
+	ID        string     `json:"id"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+}
+
+func (uc UpdateTimeEntry) Execute(ctx context.Context, in UpdateTimeEntryInput) (TimeEntryOutput, error) {
+	var entry *domain.TimeEntry
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		var err error
+		entry, err = repo.TimeEntries().GetByID(ctx, in.ID)
+		if err != nil {
+			return fmt.Errorf("get time entry: %w", err)
+		}
+
+		if in.StartedAt != nil {
+			entry.StartedAt = *in.StartedAt
+		}
+		if in.EndedAt != nil {
+			endedAt := *in.EndedAt
+			entry.EndedAt = &endedAt
+		}
+		if in.Note != nil {
+			entry.Note = *in.Note
+		}
+
+		if err := entry.IsValid(); err != nil {
+			return err
+		}
+		if err := repo.TimeEntries().Save(ctx, entry); err != nil {
+			return fmt.Errorf("save time entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return TimeEntryOutput{}, err
+	}
+
+	return newTimeEntryOutput(entry, time.Now()), nil
+}
+
+type DeleteTimeEntry struct {
+	repo domain.TaskRepository
+}
+
+func NewDeleteTimeEntry(repo domain.TaskRepository) DeleteTimeEntry {
+	return DeleteTimeEntry{repo: repo}
+}
+
+func (uc DeleteTimeEntry) Execute(ctx context.Context, id string) error {
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		return repo.TimeEntries().Delete(ctx, id)
+	})
+	if err != nil {
+		return fmt.Errorf("delete time entry: %w", err)
+	}
+	return nil
+}
+
+type ListTimeEntries struct {
+	repo domain.TaskRepository
+}
+
+func NewListTimeEntries(repo domain.TaskRepository) ListTimeEntries {
+	return ListTimeEntries{repo: repo}
+}
+
+// Execute возвращает записи задачи, новые первыми
+func (uc ListTimeEntries) Execute(ctx context.Context, taskID string) ([]TimeEntryOutput, error) {
+	entries, err := uc.repo.TimeEntries().ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list time entries: %w", err)
+	}
+
+	now := time.Now()
+	out := make([]TimeEntryOutput, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, newTimeEntryOutput(entry, now))
+	}
+	return out, nil
+}
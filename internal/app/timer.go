@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type TimeEntryOutput struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	Seconds   int64      `json:"seconds"` // у запущенного таймера - на момент запроса
+	Running   bool       `json:"running"`
+}
+
+func newTimeEntryOutput(entry *domain.TimeEntry, now time.Time) TimeEntryOutput {
+	return TimeEntryOutput{
+		ID:        entry.ID,
+		TaskID:    entry.TaskID,
+		StartedAt: entry.StartedAt,
+		EndedAt:   entry.EndedAt,
+		Note:      entry.Note,
+		Seconds:   int64(entry.Duration(now) / time.Second),
+		Running:   entry.Running(),
+	}
+}
+
+// StartTimer запускает таймер задачи. Запущенным может быть только один таймер:
+// таймер другой задачи останавливается, повторный запуск той же задачи ничего не меняет.
+type StartTimer struct {
+	repo domain.TaskRepository
+}
+
+func NewStartTimer(repo domain.TaskRepository) StartTimer {
+	return StartTimer{repo: repo}
+}
+
+type StartTimerInput struct {
+	TaskID string `json:"task_id"`
+	Note   string `json:"note,omitempty"`
+}
+
+func (uc StartTimer) Execute(ctx context.Context, in StartTimerInput) (TimeEntryOutput, error) {
+	now := time.Now()
+
+	var entry *domain.TimeEntry
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if _, err := repo.GetByID(ctx, in.TaskID); err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
+
+		entries := repo.TimeEntries()
+		running, err := entries.GetRunning(ctx)
+		switch {
+		case errors.Is(err, domain.ErrNoRunningTimer):
+		case err != nil:
+			return fmt.Errorf("get running timer: %w", err)
+		case running.TaskID == in.TaskID:
+			entry = running
+			return nil
+		default:
+			running.Stop(now)
+			if err := entries.Save(ctx, running); err != nil {
+				return fmt.Errorf("stop timer: %w", err)
+			}
+		}
+
+		entry = domain.StartTimeEntry(in.TaskID, now, in.Note)
+		if err := entries.Save(ctx, entry); err != nil {
+			return fmt.Errorf("save time entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return TimeEntryOutput{}, err
+	}
+
+	return newTimeEntryOutput(entry, now), nil
+}
+
+type StopTimer struct {
+	repo domain.TaskRepository
+}
+
+func NewStopTimer(repo domain.TaskRepository) StopTimer {
+	return StopTimer{repo: repo}
+}
+
+// Execute возвращает domain.ErrNoRunningTimer, если таймер не запущен
+func (uc StopTimer) Execute(ctx context.Context) (TimeEntryOutput, error) {
+	now := time.Now()
+
+	var entry *domain.TimeEntry
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		var err error
+		entry, err = repo.TimeEntries().GetRunning(ctx)
+		if err != nil {
+			return fmt.Errorf("get running timer: %w", err)
+		}
+
+		entry.Stop(now)
+		if err := repo.TimeEntries().Save(ctx, entry); err != nil {
+			return fmt.Errorf("save time entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return TimeEntryOutput{}, err
+	}
+
+	return newTimeEntryOutput(entry, now), nil
+}
+
+// GetRunningTimer - запущенный таймер хранится в БД, поэтому переживает перезапуск приложения
+type GetRunningTimer struct {
+	repo domain.TaskRepository
+}
+
+func NewGetRunningTimer(repo domain.TaskRepository) GetRunningTimer {
+	return GetRunningTimer{repo: repo}
+}
+
+// Execute возвращает nil, если таймер не запущен
+func (uc GetRunningTimer) Execute(ctx context.Context) (*TimeEntryOutput, error) {
+	entry, err := uc.repo.TimeEntries().GetRunning(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoRunningTimer) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get running timer: %w", err)
+	}
+
+	out := newTimeEntryOutput(entry, time.Now())
+	return &out, nil
+}
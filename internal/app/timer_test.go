@@ -0,0 +1,143 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func timerRepo() *testutil.Repo {
+	repo := testutil.NewRepo()
+	repo.Put(
+		&domain.Task{ID: "a", Title: "A", Status: domain.StatusActive},
+		&domain.Task{ID: "b", Title: "B", Status: domain.StatusActive},
+	)
+	return repo
+}
+
+func TestStartTimerKeepsOneRunning(t *testing.T) {
+	ctx := context.Background()
+	repo := timerRepo()
+	start := NewStartTimer(repo)
+
+	first, err := start.Execute(ctx, StartTimerInput{TaskID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := start.Execute(ctx, StartTimerInput{TaskID: "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Повторный запуск той же задачи возвращает уже идущий таймер
+	again, err := start.Execute(ctx, StartTimerInput{TaskID: "b"})
+	if err != nil || again.ID != second.ID {
+		t.Fatalf("restart = %+v, %v; want running entry %s", again, err, second.ID)
+	}
+
+	if len(repo.Entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(repo.Entries))
+	}
+	stopped, _ := repo.TimeEntries().GetByID(ctx, first.ID)
+	if stopped.Running() {
+		t.Fatal("timer of a must stop when b starts")
+	}
+	running, err := NewGetRunningTimer(repo).Execute(ctx)
+	if err != nil || running == nil || running.TaskID != "b" || !running.Running {
+		t.Fatalf("running = %+v, %v; want b", running, err)
+	}
+
+	if _, err := start.Execute(ctx, StartTimerInput{TaskID: "missing"}); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("unknown task: err = %v", err)
+	}
+	if running, _ := repo.TimeEntries().GetRunning(ctx); running.TaskID != "b" {
+		t.Fatal("failed start must not stop the running timer")
+	}
+}
+
+func TestStopTimer(t *testing.T) {
+	ctx := context.Background()
+	repo := timerRepo()
+
+	if _, err := NewStopTimer(repo).Execute(ctx); !errors.Is(err, domain.ErrNoRunningTimer) {
+		t.Fatalf("stop without timer: err = %v", err)
+	}
+	if _, err := NewStartTimer(repo).Execute(ctx, StartTimerInput{TaskID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := NewStopTimer(repo).Execute(ctx)
+	if err != nil || out.Running || out.EndedAt == nil {
+		t.Fatalf("stop = %+v, %v", out, err)
+	}
+	if running, err := NewGetRunningTimer(repo).Execute(ctx); err != nil || running != nil {
+		t.Fatalf("running after stop = %+v, %v", running, err)
+	}
+}
+
+func TestAddTimeEntryManual(t *testing.T) {
+	ctx := context.Background()
+	repo := timerRepo()
+	add := NewAddTimeEntry(repo)
+	now := time.Now()
+
+	// Ручная запись не трогает запущенный таймер
+	if _, err := NewStartTimer(repo).Execute(ctx, StartTimerInput{TaskID: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := add.Execute(ctx, AddTimeEntryInput{TaskID: "a", StartedAt: now.Add(-90 * time.Minute), EndedAt: now.Add(-time.Hour), Note: "review"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Running || out.Seconds != 30*60 || out.Note != "review" {
+		t.Fatalf("entry = %+v, want 30 minutes, stopped", out)
+	}
+	if running, _ := repo.TimeEntries().GetRunning(ctx); running.TaskID != "b" {
+		t.Fatal("manual entry stopped the running timer")
+	}
+
+	tests := []struct {
+		name    string
+		in      AddTimeEntryInput
+		wantErr error
+	}{
+		{"end before start", AddTimeEntryInput{TaskID: "a", StartedAt: now.Add(-time.Hour), EndedAt: now.Add(-2 * time.Hour)}, domain.ErrInvalidTimeRange},
+		{"end in future", AddTimeEntryInput{TaskID: "a", StartedAt: now.Add(-time.Hour), EndedAt: now.Add(time.Hour)}, domain.ErrInvalidTimeRange},
+		{"no start", AddTimeEntryInput{TaskID: "a", EndedAt: now}, domain.ErrInvalidTimeRange},
+		{"unknown task", AddTimeEntryInput{TaskID: "missing", StartedAt: now.Add(-time.Hour), EndedAt: now}, domain.ErrTaskNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := add.Execute(ctx, tt.in); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if entries, _ := repo.TimeEntries().ListByTask(ctx, "a"); len(entries) != 1 {
+		t.Fatalf("task a entries = %d, want only the valid one", len(entries))
+	}
+}
+
+func TestUpdateTimeEntryStopsRunningTimer(t *testing.T) {
+	ctx := context.Background()
+	repo := timerRepo()
+	now := time.Now()
+
+	started, err := NewStartTimer(repo).Execute(ctx, StartTimerInput{TaskID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	startedAt, endedAt := now.Add(-time.Hour), now.Add(-30*time.Minute)
+	out, err := NewUpdateTimeEntry(repo).Execute(ctx, UpdateTimeEntryInput{ID: started.ID, StartedAt: &startedAt, EndedAt: &endedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Running || out.Seconds != 30*60 {
+		t.Fatalf("updated = %+v, want stopped 30 minute entry", out)
+	}
+
+	badEnd := startedAt.Add(-time.Minute)
+	if _, err := NewUpdateTimeEntry(repo).Execute(ctx, UpdateTimeEntryInput{ID: started.ID, EndedAt: &badEnd}); !errors.Is(err, domain.ErrInvalidTimeRange) {
+		t.Fatalf("end before start: err = %v", err)
+	}
+}
@@ -9,11 +9,12 @@ import (
 )
 
 type UpdateTask struct {
-	repo domain.TaskRepository
+	repo   domain.TaskRepository
+	events domain.EventPublisher
 }
 
-func NewUpdateTask(repo domain.TaskRepository) UpdateTask {
-	return UpdateTask{repo: repo}
+func NewUpdateTask(repo domain.TaskRepository, events domain.EventPublisher) UpdateTask {
+	return UpdateTask{repo: repo, events: events}
 }
 
 type UpdateTaskInput struct {
@@ -22,34 +23,154 @@ type UpdateTaskInput struct {
 	Status   *string    `json:"status,omitempty"`
 	Priority *string    `json:"priority,omitempty"`
 	DueDate  *time.Time `json:"due_date,omitempty"`
+	Project  *string    `json:"project,omitempty"`
+	ParentID *string    `json:"parent_id,omitempty"` // пустая строка отвязывает подзадачу
+	Tags     []string   `json:"tags,omitempty"`      // nil - без изменений, пустой срез очищает теги
+
+	EstimateMinutes *int `json:"estimate_minutes,omitempty"` // 0 снимает оценку
+	EstimatePoints  *int `json:"estimate_points,omitempty"`
+
+	StartDate *time.Time `json:"start_date,omitempty"`
+
+	// CustomFields - изменяемые значения пользовательских полей, nil в значении очищает поле.
+	// Обязательные поля проверяются, если заданы значения или меняется проект.
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+
+	ClearDueDate   bool `json:"clear_due_date,omitempty"`
+	ClearStartDate bool `json:"clear_start_date,omitempty"`
+	// ExpectedVersion - если задан, обновление отклоняется при несовпадении версии задачи
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
 }
 
 func (uc UpdateTask) Execute(ctx context.Context, in UpdateTaskInput) error {
-	task, err := uc.repo.GetByID(ctx, in.ID)
-	if err != nil {
-		return fmt.Errorf("get task: %w", err)
-	}
+	var events []domain.Event
 
-	if in.Title != nil {
-		task.Title = *in.Title
-	}
-	if in.Status != nil {
-		task.Status = domain.TaskStatus(*in.Status)
-	}
-	if in.Priority != nil {
-		task.Priority = domain.Priority(*in.Priority)
-	}
-	if in.DueDate != nil {
-		task.DueDate = in.DueDate
-	}
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		// Строка заблокирована до конца транзакции: версия не изменится между проверкой и Save
+		task, err := repo.GetForUpdate(ctx, in.ID)
+		if err != nil {
+			return fmt.Errorf("get task: %w", err)
+		}
 
-	if err := task.IsValid(); err != nil {
-		return fmt.Errorf("validate task: %w", err)
-	}
+		if in.ExpectedVersion != nil && *in.ExpectedVersion != task.Version {
+			return domain.ErrVersionConflict
+		}
+		statusBefore := task.Status
+		projectBefore := task.Project
 
-	if err := uc.repo.Save(ctx, task); err != nil {
-		return fmt.Errorf("save task: %w", err)
+		w, err := loadWorkflow(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		if in.Title != nil {
+			task.Title = *in.Title
+		}
+		if in.Status != nil {
+			status := domain.TaskStatus(*in.Status)
+			if err := w.CanTransition(task.Status, status); err != nil {
+				return err
+			}
+			// Закрыть задачу можно, только если ее блокеры закрыты, как в CompleteTask
+			if w.IsClosed(status) && !task.IsClosed(w) {
+				blockers, err := openBlockers(ctx, repo, task.ID, w)
+				if err != nil {
+					return err
+				}
+				if len(blockers) > 0 {
+					return blockedError(blockers)
+				}
+			}
+			task.Status = status
+			task.MarkCompletion(w, time.Now())
+		}
+		if in.Priority != nil {
+			task.Priority = domain.Priority(*in.Priority)
+		}
+		if in.DueDate != nil {
+			task.DueDate = in.DueDate
+		} else if in.ClearDueDate {
+			task.DueDate = nil
+		}
+		if in.StartDate != nil {
+			task.StartDate = in.StartDate
+		} else if in.ClearStartDate {
+			task.StartDate = nil
+		}
+		if in.Project != nil {
+			task.Project = *in.Project
+		}
+		if in.Tags != nil {
+			task.Tags = nil
+			for _, tag := range in.Tags {
+				task.AddTag(tag)
+			}
+		}
+		if in.EstimateMinutes != nil {
+			task.EstimateMinutes = *in.EstimateMinutes
+		}
+		if in.EstimatePoints != nil {
+			task.EstimatePoints = *in.EstimatePoints
+		}
+		if in.ParentID != nil {
+			if *in.ParentID == "" {
+				task.ParentID = nil
+			} else {
+				if err := checkParent(ctx, repo, task.ID, *in.ParentID); err != nil {
+					return err
+				}
+				task.ParentID = in.ParentID
+			}
+		}
+
+		if err := w.ValidateTask(task); err != nil {
+			return fmt.Errorf("validate task: %w", err)
+		}
+
+		if in.CustomFields != nil || task.Project != projectBefore {
+			fields, err := repo.CustomFields().List(ctx)
+			if err != nil {
+				return fmt.Errorf("get custom fields: %w", err)
+			}
+			if err := task.SetCustomValues(fields, in.CustomFields); err != nil {
+				return err
+			}
+			if err := task.CheckRequiredFields(fields); err != nil {
+				return err
+			}
+		}
+
+		if err := repo.Save(ctx, task); err != nil {
+			return fmt.Errorf("save task: %w", err)
+		}
+
+		// TaskUpdated приходит всегда, смена статуса дополнительно дает Completed/Reopened
+		events = append([]domain.Event{domain.TaskUpdated{Task: task.Snapshot(), At: task.UpdatedAt}},
+			domain.StatusEvents(w, statusBefore, task, task.UpdatedAt)...)
+		return recordEvents(ctx, repo, events...)
+	})
+	if err != nil {
+		return err
 	}
 
+	uc.events.Publish(ctx, events...)
 	return nil
-}
\ No newline at end of file
+}
+
+// checkParent проверяет, что родитель существует и не является потомком задачи
+func checkParent(ctx context.Context, repo domain.TaskRepository, taskID, parentID string) error {
+	for id := parentID; ; {
+		if id == taskID {
+			return domain.ErrInvalidParent
+		}
+
+		parent, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get parent task: %w", err)
+		}
+		if parent.ParentID == nil {
+			return nil
+		}
+		id = *parent.ParentID
+	}
+}
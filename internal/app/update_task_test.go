@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestUpdateTaskStatusRespectsBlockers(t *testing.T) {
+	ctx := context.Background()
+	w := domain.DefaultWorkflow()
+
+	repo := testutil.NewRepo()
+	repo.Put(
+		&domain.Task{ID: "task", Title: "Release", Status: w.InitialStatus(), Priority: domain.PriorityMedium},
+		&domain.Task{ID: "blocker", Title: "Tests", Status: w.InitialStatus(), Priority: domain.PriorityMedium},
+	)
+	repo.Deps = []domain.Dependency{{TaskID: "task", BlockedByID: "blocker"}}
+
+	uc := NewUpdateTask(repo, testutil.NopPublisher{})
+	done := string(w.DoneStatus())
+
+	err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Status: &done})
+	if !errors.Is(err, domain.ErrTaskBlocked) {
+		t.Fatalf("closing a blocked task: err = %v, want ErrTaskBlocked", err)
+	}
+	// Фронтенд показывает блокеры из ошибки, а не разбирает ее текст
+	var blocked *BlockedError
+	if !errors.As(err, &blocked) || len(blocked.Blockers) != 1 || blocked.Blockers[0].ID != "blocker" {
+		t.Fatalf("blocked error = %#v, want the open blocker", err)
+	}
+	if err.Error() != `task is blocked by "Tests"` {
+		t.Fatalf("message = %q", err)
+	}
+	if task, _ := repo.GetByID(ctx, "task"); task.IsClosed(w) || task.CompletedAt != nil {
+		t.Fatalf("blocked task was closed: status %q", task.Status)
+	}
+
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "blocker", Status: &done}); err != nil {
+		t.Fatalf("close blocker: %v", err)
+	}
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Status: &done}); err != nil {
+		t.Fatalf("close unblocked task: %v", err)
+	}
+	if task, _ := repo.GetByID(ctx, "task"); !task.IsClosed(w) || task.CompletedAt == nil {
+		t.Fatalf("task status = %q, completed_at = %v; want closed", task.Status, task.CompletedAt)
+	}
+}
+
+func TestUpdateTaskVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	w := domain.DefaultWorkflow()
+
+	repo := testutil.NewRepo()
+	repo.Put(&domain.Task{ID: "task", Title: "Draft", Status: w.InitialStatus(), Priority: domain.PriorityMedium, Version: 3})
+
+	uc := NewUpdateTask(repo, testutil.NopPublisher{})
+	title := "Final"
+	stale := int64(2)
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Title: &title, ExpectedVersion: &stale}); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("stale version: err = %v, want ErrVersionConflict", err)
+	}
+
+	current := int64(3)
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Title: &title, ExpectedVersion: &current}); err != nil {
+		t.Fatalf("current version: %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type GetWorkflow struct {
+	repo domain.TaskRepository
+}
+
+func NewGetWorkflow(repo domain.TaskRepository) GetWorkflow {
+	return GetWorkflow{repo: repo}
+}
+
+func (uc GetWorkflow) Execute(ctx context.Context) (domain.Workflow, error) {
+	return loadWorkflow(ctx, uc.repo)
+}
+
+// SaveWorkflow заменяет набор статусов и переходов. Задачи из удаляемых статусов
+// переносятся в Remap[ключ], иначе в начальный или завершающий статус по категории.
+type SaveWorkflow struct {
+	repo   domain.TaskRepository
+	events domain.EventPublisher
+}
+
+func NewSaveWorkflow(repo domain.TaskRepository, events domain.EventPublisher) SaveWorkflow {
+	return SaveWorkflow{repo: repo, events: events}
+}
+
+type SaveWorkflowInput struct {
+	Statuses    []domain.WorkflowStatus     `json:"statuses"`
+	Transitions []domain.WorkflowTransition `json:"transitions"`
+	Remap       map[string]string           `json:"remap,omitempty"` // удаляемый статус -> новый
+}
+
+type SaveWorkflowOutput struct {
+	Workflow domain.Workflow `json:"workflow"`
+	Moved    int             `json:"moved"` // задач перенесено из удаленных статусов
+}
+
+func (uc SaveWorkflow) Execute(ctx context.Context, in SaveWorkflowInput) (SaveWorkflowOutput, error) {
+	next := domain.Workflow{Statuses: in.Statuses, Transitions: in.Transitions}
+	if err := next.IsValid(); err != nil {
+		return SaveWorkflowOutput{}, err
+	}
+
+	var out SaveWorkflowOutput
+	var events []domain.Event
+
+	err := uc.repo.WithTx(ctx, func(repo domain.TaskRepository) error {
+		if err := repo.Workflow().Lock(ctx); err != nil {
+			return fmt.Errorf("lock workflow: %w", err)
+		}
+		current, err := repo.Workflow().Get(ctx)
+		if err != nil {
+			return fmt.Errorf("get workflow: %w", err)
+		}
+
+		// Категории и старых, и новых статусов - для событий о переносе задач
+		both := domain.Workflow{Statuses: append(append([]domain.WorkflowStatus{}, next.Statuses...), current.Statuses...)}
+
+		// Сначала новые статусы, чтобы было куда переносить задачи
+		for _, s := range next.Statuses {
+			if err := repo.Workflow().SaveStatus(ctx, s); err != nil {
+				return fmt.Errorf("save status %s: %w", s.Key, err)
+			}
+		}
+
+		for _, old := range current.Statuses {
+			if _, kept := next.Status(old.Key); kept {
+				continue
+			}
+
+			target, err := remapTarget(next, old, in.Remap[string(old.Key)])
+			if err != nil {
+				return err
+			}
+
+			tasks, err := repo.GetByStatus(ctx, old.Key)
+			if err != nil {
+				return fmt.Errorf("get tasks: %w", err)
+			}
+			// Архивные задачи тоже держат статус; перенос в открытый статус вернет их из архива
+			archived, err := repo.Archive().GetByStatus(ctx, old.Key)
+			if err != nil {
+				return fmt.Errorf("get archived tasks: %w", err)
+			}
+			tasks = append(tasks, archived...)
+			for _, task := range tasks {
+				task.Status = target
+				task.MarkCompletion(next, time.Now())
+				if err := repo.Save(ctx, task); err != nil {
+					return fmt.Errorf("save task %s: %w", task.ID, err)
+				}
+				events = append(events, domain.TaskUpdated{Task: task.Snapshot(), At: task.UpdatedAt})
+				events = append(events, domain.StatusEvents(both, old.Key, task, task.UpdatedAt)...)
+			}
+			out.Moved += len(tasks)
+
+			if err := repo.Workflow().DeleteStatus(ctx, old.Key); err != nil {
+				return fmt.Errorf("delete status %s: %w", old.Key, err)
+			}
+		}
+
+		if err := repo.Workflow().SetTransitions(ctx, next.Transitions); err != nil {
+			return fmt.Errorf("save transitions: %w", err)
+		}
+
+		if out.Workflow, err = repo.Workflow().Get(ctx); err != nil {
+			return fmt.Errorf("get workflow: %w", err)
+		}
+		return recordEvents(ctx, repo, events...)
+	})
+	if err != nil {
+		return SaveWorkflowOutput{}, err
+	}
+
+	uc.events.Publish(ctx, events...)
+	return out, nil
+}
+
+// remapTarget - куда переносить задачи удаляемого статуса
+func remapTarget(w domain.Workflow, old domain.WorkflowStatus, requested string) (domain.TaskStatus, error) {
+	if requested != "" {
+		if _, ok := w.Status(domain.TaskStatus(requested)); !ok {
+			return "", fmt.Errorf("%w: remap %s -> unknown status %s", domain.ErrInvalidWorkflow, old.Key, requested)
+		}
+		return domain.TaskStatus(requested), nil
+	}
+
+	if old.Category == domain.CategoryClosed {
+		return w.DoneStatus(), nil
+	}
+	return w.InitialStatus(), nil
+}
+
+func loadWorkflow(ctx context.Context, repo domain.TaskRepository) (domain.Workflow, error) {
+	w, err := repo.Workflow().Get(ctx)
+	if err != nil {
+		return domain.Workflow{}, fmt.Errorf("get workflow: %w", err)
+	}
+	return w, nil
+}
+
+// fitWorkflow переводит исходные active/completed из импорта и внешних клиентов
+// в начальный и завершающий статусы workflow, если таких ключей в нем нет
+func fitWorkflow(w domain.Workflow, task *domain.Task) {
+	if _, ok := w.Status(task.Status); ok {
+		return
+	}
+	switch task.Status {
+	case domain.StatusActive:
+		task.Status = w.InitialStatus()
+	case domain.StatusCompleted:
+		task.Status = w.DoneStatus()
+	}
+}
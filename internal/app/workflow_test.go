@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestUpdateTaskFollowsWorkflowTransitions(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	repo.Flow = domain.Workflow{
+		Statuses: []domain.WorkflowStatus{
+			{Key: "todo", Name: "To do", Category: domain.CategoryOpen, Initial: true},
+			{Key: "review", Name: "Review", Category: domain.CategoryOpen, Position: 1},
+			{Key: "done", Name: "Done", Category: domain.CategoryClosed, Position: 2},
+		},
+		Transitions: []domain.WorkflowTransition{
+			{From: "todo", To: "review"},
+			{From: "review", To: "done"},
+		},
+	}
+	repo.Put(&domain.Task{ID: "task", Title: "Release", Status: "todo", Priority: domain.PriorityMedium})
+	uc := NewUpdateTask(repo, testutil.NopPublisher{})
+
+	done, review := "done", "review"
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Status: &done}); !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Fatalf("todo -> done: err = %v, want ErrInvalidTransition", err)
+	}
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Status: &review}); err != nil {
+		t.Fatalf("todo -> review: %v", err)
+	}
+	if err := uc.Execute(ctx, UpdateTaskInput{ID: "task", Status: &done}); err != nil {
+		t.Fatalf("review -> done: %v", err)
+	}
+
+	task, _ := repo.GetByID(ctx, "task")
+	if task.Status != "done" || task.CompletedAt == nil {
+		t.Fatalf("status %q, completed_at %v; want done with time", task.Status, task.CompletedAt)
+	}
+}
+
+func TestSaveWorkflowRemapsArchivedTasks(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	w := domain.DefaultWorkflow()
+	repo.Flow.Statuses = append(repo.Flow.Statuses,
+		domain.WorkflowStatus{Key: "shipped", Name: "Shipped", Category: domain.CategoryClosed, Position: 9})
+
+	closedAt := time.Now().AddDate(0, -2, 0)
+	archivedAt := time.Now().AddDate(0, -1, 0)
+	repo.Put(
+		&domain.Task{ID: "live", Title: "Live", Status: "shipped", Priority: domain.PriorityMedium, CompletedAt: &closedAt},
+		&domain.Task{ID: "old", Title: "Old", Status: "shipped", Priority: domain.PriorityMedium, CompletedAt: &closedAt, ArchivedAt: &archivedAt},
+	)
+
+	out, err := NewSaveWorkflow(repo, testutil.NopPublisher{}).Execute(ctx, SaveWorkflowInput{Statuses: w.Statuses})
+	if err != nil {
+		t.Fatalf("remove status with archived task: %v", err)
+	}
+	if out.Moved != 2 {
+		t.Fatalf("moved %d, want 2", out.Moved)
+	}
+
+	old, _ := repo.GetByID(ctx, "old")
+	if old.Status != w.DoneStatus() || old.ArchivedAt == nil || !old.CompletedAt.Equal(closedAt) {
+		t.Fatalf("archived task: status %q, archived_at %v, completed_at %v; want %s and still archived",
+			old.Status, old.ArchivedAt, old.CompletedAt, w.DoneStatus())
+	}
+	if _, ok := out.Workflow.Status("shipped"); ok {
+		t.Fatal("status shipped was not removed")
+	}
+}
+
+func TestSaveWorkflowRemapReopensArchivedTask(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewRepo()
+	w := domain.DefaultWorkflow()
+	repo.Flow.Statuses = append(repo.Flow.Statuses,
+		domain.WorkflowStatus{Key: "shipped", Name: "Shipped", Category: domain.CategoryClosed, Position: 9})
+
+	closedAt := time.Now().AddDate(0, -2, 0)
+	repo.Put(&domain.Task{ID: "old", Title: "Old", Status: "shipped", Priority: domain.PriorityMedium, CompletedAt: &closedAt, ArchivedAt: &closedAt})
+
+	in := SaveWorkflowInput{Statuses: w.Statuses, Remap: map[string]string{"shipped": string(w.InitialStatus())}}
+	if _, err := NewSaveWorkflow(repo, testutil.NopPublisher{}).Execute(ctx, in); err != nil {
+		t.Fatal(err)
+	}
+
+	old, _ := repo.GetByID(ctx, "old")
+	if old.Status != w.InitialStatus() || old.CompletedAt != nil || old.ArchivedAt != nil {
+		t.Fatalf("status %q, completed_at %v, archived_at %v; want reopened outside the archive", old.Status, old.CompletedAt, old.ArchivedAt)
+	}
+}
@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    ADD COLUMN project TEXT NOT NULL DEFAULT '',
+    ADD COLUMN parent_id TEXT NULL REFERENCES tasks(id) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED;
+
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE tasks SET updated_at = created_at;
@@ -0,0 +1,18 @@
+CREATE OR REPLACE FUNCTION notify_task_change() RETURNS trigger AS $$
+DECLARE
+    payload JSON;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        payload := json_build_object('op', 'delete', 'id', OLD.id, 'version', OLD.version);
+    ELSE
+        payload := json_build_object('op', lower(TG_OP), 'id', NEW.id, 'version', NEW.version);
+    END IF;
+
+    PERFORM pg_notify('task_changes', payload::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
@@ -0,0 +1,16 @@
+CREATE TABLE outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    idempotency_key TEXT NOT NULL UNIQUE,
+    event_type      TEXT NOT NULL,
+    task_id         TEXT NOT NULL,
+    payload         JSONB NOT NULL,
+    status          TEXT NOT NULL DEFAULT 'pending',
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at    TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_outbox_task ON outbox(task_id, id) WHERE status = 'pending';
@@ -0,0 +1,24 @@
+CREATE TABLE webhooks (
+    id          TEXT PRIMARY KEY,
+    url         TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    filter      TEXT NOT NULL DEFAULT '',
+    secret      TEXT NOT NULL DEFAULT '',
+    enabled     BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE webhook_deliveries (
+    id          BIGSERIAL PRIMARY KEY,
+    webhook_id  TEXT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id    TEXT NOT NULL,
+    event_type  TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error       TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, id DESC);
+CREATE INDEX idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS sync_state;
+
+DROP TABLE IF EXISTS sync_tombstones;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS sync_dirty,
+    DROP COLUMN IF EXISTS sync_seq,
+    DROP COLUMN IF EXISTS field_clocks;
+
+DROP SEQUENCE IF EXISTS task_sync_seq;
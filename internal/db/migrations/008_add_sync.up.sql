@@ -0,0 +1,26 @@
+CREATE SEQUENCE task_sync_seq;
+
+-- field_clocks: HLC последней записи каждого поля, sync_seq: номер изменения,
+-- sync_dirty: локальное изменение еще не отправлено на сервер синхронизации
+ALTER TABLE tasks
+    ADD COLUMN field_clocks JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN sync_seq BIGINT NOT NULL DEFAULT nextval('task_sync_seq'),
+    ADD COLUMN sync_dirty BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX idx_tasks_sync_seq ON tasks(sync_seq);
+CREATE INDEX idx_tasks_sync_dirty ON tasks(sync_seq) WHERE sync_dirty;
+
+CREATE TABLE sync_tombstones (
+    task_id    TEXT PRIMARY KEY,
+    clock      TEXT NOT NULL,
+    deleted_at TIMESTAMP NOT NULL,
+    seq        BIGINT NOT NULL DEFAULT nextval('task_sync_seq'),
+    dirty      BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE INDEX idx_sync_tombstones_seq ON sync_tombstones(seq);
+
+CREATE TABLE sync_state (
+    key   TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
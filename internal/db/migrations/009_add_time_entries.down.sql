@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS tracked_seconds;
+
+DROP TABLE IF EXISTS time_entries;
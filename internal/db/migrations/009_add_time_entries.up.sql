@@ -0,0 +1,18 @@
+-- ended_at IS NULL - таймер запущен; запущенным может быть только один
+CREATE TABLE time_entries (
+    id         TEXT PRIMARY KEY,
+    task_id    TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at   TIMESTAMP NULL,
+    note       TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX idx_time_entries_task ON time_entries(task_id, started_at);
+CREATE INDEX idx_time_entries_started ON time_entries(started_at);
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries((TRUE)) WHERE ended_at IS NULL;
+
+-- Сумма завершенных записей, пересчитывается при каждом изменении записей задачи
+ALTER TABLE tasks ADD COLUMN tracked_seconds BIGINT NOT NULL DEFAULT 0;
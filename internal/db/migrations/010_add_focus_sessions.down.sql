@@ -0,0 +1 @@
+DROP TABLE IF EXISTS focus_sessions;
@@ -0,0 +1,10 @@
+CREATE TABLE focus_sessions (
+    id              TEXT PRIMARY KEY,
+    task_id         TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    started_at      TIMESTAMP NOT NULL,
+    ended_at        TIMESTAMP NOT NULL,
+    planned_seconds BIGINT NOT NULL,
+    completed       BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_focus_sessions_started ON focus_sessions(started_at);
@@ -0,0 +1,10 @@
+-- task_id нельзя начать, пока не завершена blocked_by_id
+CREATE TABLE task_dependencies (
+    task_id       TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocked_by_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, blocked_by_id),
+    CHECK (task_id <> blocked_by_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocker ON task_dependencies(blocked_by_id);
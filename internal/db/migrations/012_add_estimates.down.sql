@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_points;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_minutes;
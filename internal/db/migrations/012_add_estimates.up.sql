@@ -0,0 +1,3 @@
+-- Оценка задачи: в минутах или в story points, 0 - оценки нет
+ALTER TABLE tasks ADD COLUMN estimate_minutes INT NOT NULL DEFAULT 0 CHECK (estimate_minutes >= 0);
+ALTER TABLE tasks ADD COLUMN estimate_points INT NOT NULL DEFAULT 0 CHECK (estimate_points >= 0);
@@ -0,0 +1,11 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_fkey;
+
+-- Пользовательские статусы сворачиваются обратно в active/completed по категории
+UPDATE tasks t SET status = CASE WHEN s.category = 'closed' THEN 'completed' ELSE 'active' END
+FROM workflow_statuses s
+WHERE s.key = t.status AND t.status NOT IN ('active', 'completed');
+
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check CHECK (status IN ('active', 'completed'));
+
+DROP TABLE IF EXISTS workflow_transitions;
+DROP TABLE IF EXISTS workflow_statuses;
@@ -0,0 +1,31 @@
+-- Настраиваемые статусы: category open/closed заменяет жесткие active/completed
+CREATE TABLE workflow_statuses (
+    key        TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    category   TEXT NOT NULL CHECK (category IN ('open', 'closed')),
+    position   INT NOT NULL DEFAULT 0,
+    is_initial BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Из статуса без переходов можно перейти в любой
+CREATE TABLE workflow_transitions (
+    from_status TEXT NOT NULL REFERENCES workflow_statuses(key) ON DELETE CASCADE,
+    to_status   TEXT NOT NULL REFERENCES workflow_statuses(key) ON DELETE CASCADE,
+    PRIMARY KEY (from_status, to_status),
+    CHECK (from_status <> to_status)
+);
+
+-- active и completed сохраняют ключи, поэтому существующие строки остаются валидными
+INSERT INTO workflow_statuses (key, name, category, position, is_initial) VALUES
+    ('backlog', 'Backlog', 'open', 0, FALSE),
+    ('active', 'To do', 'open', 1, TRUE),
+    ('in_progress', 'In progress', 'open', 2, FALSE),
+    ('waiting', 'Waiting', 'open', 3, FALSE),
+    ('completed', 'Done', 'closed', 4, FALSE);
+
+UPDATE tasks SET status = 'active'
+WHERE status NOT IN (SELECT key FROM workflow_statuses);
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_fkey
+    FOREIGN KEY (status) REFERENCES workflow_statuses(key);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS board_wip_limits;
+DROP TABLE IF EXISTS board_positions;
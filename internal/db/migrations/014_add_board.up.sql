@@ -0,0 +1,14 @@
+-- Порядок карточек на доске; у каждой группировки свой
+CREATE TABLE board_positions (
+    group_by TEXT NOT NULL,
+    task_id  TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (group_by, task_id)
+);
+
+CREATE TABLE board_wip_limits (
+    group_by   TEXT NOT NULL,
+    column_key TEXT NOT NULL,
+    wip_limit  INT NOT NULL CHECK (wip_limit > 0),
+    PRIMARY KEY (group_by, column_key)
+);
@@ -0,0 +1,9 @@
+-- Когда задача перешла в закрытый статус; NULL у открытых задач
+ALTER TABLE tasks ADD COLUMN completed_at TIMESTAMP;
+
+-- У уже закрытых задач время завершения неизвестно, ближе всего последнее изменение
+UPDATE tasks SET completed_at = tasks.updated_at
+FROM workflow_statuses ws
+WHERE ws.key = tasks.status AND ws.category = 'closed';
+
+CREATE INDEX idx_tasks_completed_at ON tasks (completed_at) WHERE completed_at IS NOT NULL;
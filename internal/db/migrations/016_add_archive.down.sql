@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_archived;
+DROP INDEX IF EXISTS idx_tasks_active_due;
+DROP INDEX IF EXISTS idx_tasks_active_status;
+DROP INDEX IF EXISTS idx_tasks_active_created;
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived_at;
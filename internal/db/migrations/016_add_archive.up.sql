@@ -0,0 +1,8 @@
+-- Архив: закрытые давно задачи остаются в tasks ради связей и синхронизации,
+-- но выпадают из активных запросов. Частичные индексы не растут вместе с архивом.
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMP;
+
+CREATE INDEX idx_tasks_active_created ON tasks (created_at DESC) WHERE archived_at IS NULL;
+CREATE INDEX idx_tasks_active_status ON tasks (status) WHERE archived_at IS NULL;
+CREATE INDEX idx_tasks_active_due ON tasks (due_date) WHERE archived_at IS NULL AND due_date IS NOT NULL;
+CREATE INDEX idx_tasks_archived ON tasks (completed_at DESC, id) WHERE archived_at IS NOT NULL;
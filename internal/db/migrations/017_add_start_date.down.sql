@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_active_start;
+ALTER TABLE tasks DROP COLUMN IF EXISTS start_date;
@@ -0,0 +1,4 @@
+-- Дата начала: до нее задача отложена и не показывается в списке
+ALTER TABLE tasks ADD COLUMN start_date TIMESTAMP;
+
+CREATE INDEX idx_tasks_active_start ON tasks (start_date) WHERE archived_at IS NULL AND start_date IS NOT NULL;
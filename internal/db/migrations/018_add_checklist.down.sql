@@ -0,0 +1,4 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS checklist_done;
+ALTER TABLE tasks DROP COLUMN IF EXISTS checklist_total;
+
+DROP TABLE IF EXISTS checklist_items;
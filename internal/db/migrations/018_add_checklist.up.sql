@@ -0,0 +1,16 @@
+-- Чек-лист внутри задачи: короткие пункты без статуса и сроков
+CREATE TABLE checklist_items (
+    id         TEXT PRIMARY KEY,
+    task_id    TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    title      TEXT NOT NULL,
+    checked    BOOLEAN NOT NULL DEFAULT FALSE,
+    checked_at TIMESTAMP NULL,
+    position   INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_checklist_items_task ON checklist_items(task_id, position);
+
+-- Прогресс чек-листа, пересчитывается при каждом изменении пунктов задачи
+ALTER TABLE tasks ADD COLUMN checklist_total INT NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN checklist_done INT NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+-- Вложения задач; содержимое лежит в локальном хранилище по SHA-256,
+-- одинаковые файлы разных задач делят один blob
+CREATE TABLE attachments (
+    id         TEXT PRIMARY KEY,
+    task_id    TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    name       TEXT NOT NULL,
+    mime_type  TEXT NOT NULL,
+    size       BIGINT NOT NULL CHECK (size >= 0),
+    hash       TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_attachments_task ON attachments(task_id, created_at);
+CREATE INDEX idx_attachments_hash ON attachments(hash);
@@ -0,0 +1,23 @@
+-- Комментарии к задаче; body - Markdown, рендерит фронтенд
+CREATE TABLE task_comments (
+    id         TEXT PRIMARY KEY,
+    task_id    TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author     TEXT NOT NULL,
+    body       TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_comments_task ON task_comments(task_id, created_at);
+
+-- История изменений задачи: снапшот после каждого доменного события.
+-- В отличие от outbox не чистится, пока жива задача.
+CREATE TABLE task_activity (
+    id          BIGSERIAL PRIMARY KEY,
+    task_id     TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    event_type  TEXT NOT NULL,
+    snapshot    JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_task_activity_task ON task_activity(task_id, occurred_at, id);
@@ -0,0 +1,20 @@
+-- Пользовательские поля задач. project = '' - поле доступно во всех проектах.
+-- Ключ общий для всех проектов, чтобы фильтр, сортировка и колонки CSV были однозначны.
+CREATE TABLE custom_fields (
+    key        TEXT PRIMARY KEY,
+    project    TEXT NOT NULL DEFAULT '',
+    name       TEXT NOT NULL,
+    type       TEXT NOT NULL,
+    options    TEXT[] NOT NULL DEFAULT '{}',
+    required   BOOLEAN NOT NULL DEFAULT FALSE,
+    min_value  DOUBLE PRECISION,
+    max_value  DOUBLE PRECISION,
+    pattern    TEXT NOT NULL DEFAULT '',
+    position   INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Значения полей задачи: ключ поля -> значение (строка, число или bool)
+ALTER TABLE tasks ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_tasks_custom_fields ON tasks USING GIN (custom_fields);
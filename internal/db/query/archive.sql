@@ -0,0 +1,43 @@
+-- name: ArchiveTasks :execrows
+-- Родитель остается активным, пока у него есть подзадачи, которые в архив не уходят
+UPDATE tasks SET archived_at = @archived_at
+WHERE archived_at IS NULL
+  AND completed_at < @completed_before
+  AND NOT EXISTS (
+      SELECT 1 FROM tasks c
+      WHERE c.parent_id = tasks.id
+        AND c.archived_at IS NULL
+        AND (c.completed_at IS NULL OR c.completed_at >= @completed_before)
+  );
+
+-- name: CountArchivedTasks :one
+SELECT COUNT(*) FROM tasks
+WHERE archived_at IS NOT NULL
+  AND (sqlc.narg(pattern)::text IS NULL
+       OR title ILIKE sqlc.narg(pattern)
+       OR project ILIKE sqlc.narg(pattern)
+       OR EXISTS (SELECT 1 FROM unnest(tags) tag WHERE tag ILIKE sqlc.narg(pattern)))
+  AND (sqlc.narg(project)::text IS NULL OR project = sqlc.narg(project));
+
+-- name: GetArchivedTasksByStatus :many
+-- Перенос задач из удаляемого статуса затрагивает и архив
+SELECT * FROM tasks
+WHERE archived_at IS NOT NULL
+  AND status = $1
+ORDER BY completed_at DESC, id;
+
+-- name: ListArchivedTasks :many
+SELECT * FROM tasks
+WHERE archived_at IS NOT NULL
+  AND (sqlc.narg(pattern)::text IS NULL
+       OR title ILIKE sqlc.narg(pattern)
+       OR project ILIKE sqlc.narg(pattern)
+       OR EXISTS (SELECT 1 FROM unnest(tags) tag WHERE tag ILIKE sqlc.narg(pattern)))
+  AND (sqlc.narg(project)::text IS NULL OR project = sqlc.narg(project))
+ORDER BY completed_at DESC, id
+LIMIT sqlc.narg(page_limit) OFFSET @page_offset;
+
+-- name: RestoreArchivedTask :exec
+-- Восстановление из бэкапа: в архиве бывают только закрытые задачи
+UPDATE tasks SET archived_at = @archived_at
+WHERE id = @id AND completed_at IS NOT NULL;
@@ -0,0 +1,20 @@
+-- name: SaveAttachment :exec
+INSERT INTO attachments (id, task_id, name, mime_type, size, hash, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: GetAttachmentByID :one
+SELECT * FROM attachments WHERE id = $1;
+
+-- name: ListAttachmentsByTask :many
+SELECT * FROM attachments WHERE task_id = $1 ORDER BY created_at, id;
+
+-- name: ListAttachmentHashes :many
+-- Хеши, на которые ссылается хотя бы одно вложение; остальные blob'ы - мусор
+SELECT DISTINCT hash FROM attachments;
+
+-- name: DeleteAttachment :execrows
+DELETE FROM attachments WHERE id = $1;
+
+-- name: ListAllAttachments :many
+SELECT * FROM attachments ORDER BY task_id, created_at, id;
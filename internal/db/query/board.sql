@@ -0,0 +1,22 @@
+-- name: ListBoardPositions :many
+SELECT task_id, position FROM board_positions WHERE group_by = $1;
+
+-- name: SetBoardPosition :exec
+INSERT INTO board_positions (group_by, task_id, position)
+VALUES ($1, $2, $3)
+ON CONFLICT (group_by, task_id) DO UPDATE SET position = EXCLUDED.position;
+
+-- name: ListBoardWIPLimits :many
+SELECT * FROM board_wip_limits WHERE group_by = $1;
+
+-- name: SetBoardWIPLimit :exec
+INSERT INTO board_wip_limits (group_by, column_key, wip_limit)
+VALUES ($1, $2, $3)
+ON CONFLICT (group_by, column_key) DO UPDATE SET wip_limit = EXCLUDED.wip_limit;
+
+-- name: DeleteBoardWIPLimit :exec
+DELETE FROM board_wip_limits WHERE group_by = $1 AND column_key = $2;
+
+-- name: LockBoard :exec
+-- Проверка WIP-лимита и перенос карточки должны видеть одно и то же состояние доски
+LOCK TABLE board_positions IN SHARE ROW EXCLUSIVE MODE;
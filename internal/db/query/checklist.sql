@@ -0,0 +1,33 @@
+-- name: SaveChecklistItem :exec
+INSERT INTO checklist_items (id, task_id, title, checked, checked_at, position, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (id) DO UPDATE
+SET title      = EXCLUDED.title,
+    checked    = EXCLUDED.checked,
+    checked_at = EXCLUDED.checked_at,
+    position   = EXCLUDED.position;
+
+-- name: GetChecklistItemByID :one
+SELECT * FROM checklist_items WHERE id = $1;
+
+-- name: ListChecklistItemsByTask :many
+SELECT * FROM checklist_items WHERE task_id = $1 ORDER BY position, created_at;
+
+-- name: SetChecklistItemPosition :exec
+UPDATE checklist_items SET position = $3 WHERE id = $1 AND task_id = $2;
+
+-- name: CheckAllChecklistItems :execrows
+UPDATE checklist_items SET checked = TRUE, checked_at = $2
+WHERE task_id = $1 AND NOT checked;
+
+-- name: DeleteChecklistItem :execrows
+DELETE FROM checklist_items WHERE id = $1;
+
+-- name: RefreshTaskChecklist :exec
+UPDATE tasks
+SET checklist_total = (SELECT COUNT(*) FROM checklist_items WHERE task_id = $1),
+    checklist_done  = (SELECT COUNT(*) FROM checklist_items WHERE task_id = $1 AND checked)
+WHERE id = $1;
+
+-- name: ListAllChecklistItems :many
+SELECT * FROM checklist_items ORDER BY task_id, position, id;
@@ -0,0 +1,64 @@
+-- name: SaveComment :exec
+INSERT INTO task_comments (id, task_id, author, body, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (id) DO UPDATE
+SET body       = EXCLUDED.body,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetCommentByID :one
+SELECT * FROM task_comments WHERE id = $1;
+
+-- name: DeleteComment :execrows
+DELETE FROM task_comments WHERE id = $1;
+
+-- name: AddTaskActivity :exec
+-- task.updated без видимых изменений (например, только учтенное время) не пишется
+INSERT INTO task_activity (task_id, event_type, snapshot, occurred_at)
+SELECT @task_id::TEXT, @event_type::TEXT, @snapshot::JSONB, @occurred_at::TIMESTAMP
+WHERE @event_type::TEXT <> 'task.updated'
+   OR @snapshot::JSONB IS DISTINCT FROM (
+       SELECT a.snapshot FROM task_activity a
+       WHERE a.task_id = @task_id::TEXT
+       ORDER BY a.occurred_at DESC, a.id DESC
+       LIMIT 1
+   );
+
+-- name: ListTaskTimeline :many
+-- Комментарии и активность одной лентой, новые первыми. previous - снапшот
+-- предыдущей записи активности, чтобы показать изменения на любой странице.
+SELECT kind, id, seq, at, author, body, updated_at, event_type, snapshot, previous FROM (
+    SELECT 'comment'::TEXT AS kind, c.id, 0::BIGINT AS seq, c.created_at AS at,
+           c.author, c.body, c.updated_at,
+           ''::TEXT AS event_type, NULL::JSONB AS snapshot, NULL::JSONB AS previous
+    FROM task_comments c
+    WHERE c.task_id = $1
+    UNION ALL
+    SELECT 'activity'::TEXT, a.id::TEXT, a.id, a.occurred_at,
+           '', '', a.occurred_at,
+           a.event_type, a.snapshot, LAG(a.snapshot) OVER (ORDER BY a.occurred_at, a.id)
+    FROM task_activity a
+    WHERE a.task_id = $1
+) timeline
+ORDER BY at DESC, seq DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountTaskTimeline :one
+SELECT (SELECT COUNT(*) FROM task_comments c WHERE c.task_id = $1)
+     + (SELECT COUNT(*) FROM task_activity a WHERE a.task_id = $1) AS total;
+
+-- name: ListAllComments :many
+SELECT * FROM task_comments ORDER BY task_id, created_at, id;
+
+-- name: ListAllTaskActivity :many
+SELECT * FROM task_activity ORDER BY task_id, occurred_at, id;
+
+-- name: RestoreTaskActivity :exec
+-- Запись из бэкапа; повторный импорт того же бэкапа ее не дублирует
+INSERT INTO task_activity (task_id, event_type, snapshot, occurred_at)
+SELECT @task_id::TEXT, @event_type::TEXT, @snapshot::JSONB, @occurred_at::TIMESTAMP
+WHERE NOT EXISTS (
+    SELECT 1 FROM task_activity
+    WHERE task_id = @task_id::TEXT
+      AND event_type = @event_type::TEXT
+      AND occurred_at = @occurred_at::TIMESTAMP
+);
@@ -0,0 +1,40 @@
+-- name: ListCustomFields :many
+SELECT * FROM custom_fields ORDER BY position, key;
+
+-- name: GetCustomField :one
+SELECT * FROM custom_fields WHERE key = $1;
+
+-- name: SaveCustomField :exec
+INSERT INTO custom_fields (key, project, name, type, options, required, min_value, max_value, pattern, position)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (key) DO UPDATE
+SET project   = EXCLUDED.project,
+    name      = EXCLUDED.name,
+    type      = EXCLUDED.type,
+    options   = EXCLUDED.options,
+    required  = EXCLUDED.required,
+    min_value = EXCLUDED.min_value,
+    max_value = EXCLUDED.max_value,
+    pattern   = EXCLUDED.pattern,
+    position  = EXCLUDED.position;
+
+-- name: DeleteCustomField :execrows
+DELETE FROM custom_fields WHERE key = $1;
+
+-- name: GetTasksWithCustomField :many
+-- Задачи, включая архив, у которых заполнено поле
+SELECT * FROM tasks WHERE custom_fields ? @key::TEXT ORDER BY id;
+
+-- name: FindTasksByCustomFields :many
+-- Задачи вне архива по условиям на значения полей, проверяемым GIN-индексом
+SELECT * FROM tasks
+WHERE archived_at IS NULL
+  AND (cardinality(@statuses::TEXT[]) = 0 OR status = ANY(@statuses::TEXT[]))
+  AND custom_fields @> @contains::JSONB
+  AND custom_fields ?& @present::TEXT[]
+  AND NOT custom_fields ?| @absent::TEXT[]
+  AND NOT EXISTS (
+      SELECT 1 FROM jsonb_array_elements(@excludes::JSONB) AS e(value)
+      WHERE tasks.custom_fields @> e.value
+  )
+ORDER BY created_at DESC;
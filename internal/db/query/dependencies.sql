@@ -0,0 +1,17 @@
+-- name: AddTaskDependency :exec
+INSERT INTO task_dependencies (task_id, blocked_by_id, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteTaskDependency :execrows
+DELETE FROM task_dependencies WHERE task_id = $1 AND blocked_by_id = $2;
+
+-- name: ListTaskDependencies :many
+SELECT * FROM task_dependencies ORDER BY task_id, blocked_by_id;
+
+-- name: ListTaskBlockers :many
+SELECT blocked_by_id FROM task_dependencies WHERE task_id = $1 ORDER BY blocked_by_id;
+
+-- name: LockTaskDependencies :exec
+-- Проверка на цикл читает весь граф, поэтому параллельные вставки ребер сериализуются
+LOCK TABLE task_dependencies IN SHARE ROW EXCLUSIVE MODE;
@@ -0,0 +1,13 @@
+-- name: AddFocusSession :exec
+INSERT INTO focus_sessions (id, task_id, started_at, ended_at, planned_seconds, completed)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: ListFocusSessionsBetween :many
+SELECT * FROM focus_sessions
+WHERE started_at >= $1
+  AND started_at < $2
+ORDER BY started_at;
+
+-- name: ListAllFocusSessions :many
+SELECT * FROM focus_sessions ORDER BY started_at, id;
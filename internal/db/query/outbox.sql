@@ -0,0 +1,50 @@
+-- name: AddOutboxEntry :execrows
+INSERT INTO outbox (idempotency_key, event_type, task_id, payload, created_at, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $5)
+ON CONFLICT (idempotency_key) DO NOTHING;
+
+-- name: ClaimOutboxEntries :many
+-- Берем самые ранние события каждой задачи, чтобы сохранить порядок доставки.
+-- next_attempt_at сдвигается на время аренды: если релей упадет, запись вернется в очередь.
+UPDATE outbox
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id IN (
+    SELECT o.id FROM outbox o
+    WHERE o.status = 'pending'
+      AND o.next_attempt_at <= sqlc.arg(now)
+      AND NOT EXISTS (
+          SELECT 1 FROM outbox prev
+          WHERE prev.task_id = o.task_id
+            AND prev.status = 'pending'
+            AND prev.id < o.id
+      )
+    ORDER BY o.id
+    LIMIT sqlc.arg(max_entries)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxDelivered :exec
+UPDATE outbox
+SET status = 'delivered', attempts = attempts + 1, last_error = '', delivered_at = $2
+WHERE id = $1;
+
+-- name: MarkOutboxFailed :exec
+UPDATE outbox
+SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+WHERE id = $1;
+
+-- name: ListOutboxByStatus :many
+SELECT * FROM outbox WHERE status = $1 ORDER BY id DESC LIMIT $2;
+
+-- name: RequeueOutboxEntry :execrows
+UPDATE outbox
+SET status = 'pending', attempts = 0, last_error = '', next_attempt_at = $2
+WHERE id = $1 AND status = 'dead';
+
+-- name: DeleteDeliveredOutbox :execrows
+DELETE FROM outbox WHERE status = 'delivered' AND delivered_at < $1;
+
+-- name: DeleteStaleOutbox :execrows
+-- Недоставленные события старше срока хранения: релей выключен или получатель давно недоступен
+DELETE FROM outbox WHERE status <> 'delivered' AND created_at < $1;
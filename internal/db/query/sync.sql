@@ -0,0 +1,73 @@
+-- name: ApplySyncTask :exec
+-- Запись результата слияния: метки полей приходят готовыми, sync_dirty не трогаем
+INSERT INTO tasks (id, title, status, created_at, due_date, priority, project, parent_id, tags, updated_at, field_clocks, sync_seq, sync_dirty, estimate_minutes, estimate_points, completed_at, start_date, custom_fields)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, nextval('task_sync_seq'), FALSE, $12, $13, $14, $15, $16)
+ON CONFLICT (id) DO UPDATE
+SET title            = EXCLUDED.title,
+    status           = EXCLUDED.status,
+    created_at       = EXCLUDED.created_at,
+    due_date         = EXCLUDED.due_date,
+    priority         = EXCLUDED.priority,
+    project          = EXCLUDED.project,
+    parent_id        = EXCLUDED.parent_id,
+    tags             = EXCLUDED.tags,
+    updated_at       = EXCLUDED.updated_at,
+    estimate_minutes = EXCLUDED.estimate_minutes,
+    estimate_points  = EXCLUDED.estimate_points,
+    completed_at     = EXCLUDED.completed_at,
+    archived_at      = CASE WHEN EXCLUDED.completed_at IS NULL THEN NULL ELSE tasks.archived_at END,
+    start_date       = EXCLUDED.start_date,
+    custom_fields    = EXCLUDED.custom_fields,
+    field_clocks     = EXCLUDED.field_clocks,
+    sync_seq         = EXCLUDED.sync_seq,
+    version          = tasks.version + 1;
+
+-- name: ListDirtyTasks :many
+SELECT * FROM tasks WHERE sync_dirty ORDER BY sync_seq;
+
+-- name: MarkTaskSynced :exec
+UPDATE tasks SET sync_dirty = FALSE WHERE id = $1 AND sync_seq = $2;
+
+-- name: ListTaskChangesSince :many
+SELECT * FROM tasks WHERE sync_seq > $1 ORDER BY sync_seq LIMIT $2;
+
+-- name: GetTaskDescendantIDs :many
+WITH RECURSIVE descendants AS (
+    SELECT id FROM tasks WHERE parent_id = $1
+    UNION
+    SELECT t.id FROM tasks t JOIN descendants d ON t.parent_id = d.id
+)
+SELECT id FROM descendants;
+
+-- name: ListOrphanTaskIDs :many
+SELECT t.id FROM tasks t
+WHERE t.parent_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM tasks p WHERE p.id = t.parent_id);
+
+-- name: AddTombstone :exec
+INSERT INTO sync_tombstones (task_id, clock, deleted_at, dirty)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (task_id) DO NOTHING;
+
+-- name: TombstoneExists :one
+SELECT EXISTS (SELECT 1 FROM sync_tombstones WHERE task_id = $1);
+
+-- name: DeleteDirtyTombstone :exec
+-- Локальное пересоздание задачи (например, импорт с заменой) отменяет неотправленное удаление
+DELETE FROM sync_tombstones WHERE task_id = $1 AND dirty;
+
+-- name: ListDirtyTombstones :many
+SELECT * FROM sync_tombstones WHERE dirty ORDER BY seq;
+
+-- name: MarkTombstoneSynced :exec
+UPDATE sync_tombstones SET dirty = FALSE WHERE task_id = $1 AND seq = $2;
+
+-- name: ListTombstonesSince :many
+SELECT * FROM sync_tombstones WHERE seq > $1 ORDER BY seq LIMIT $2;
+
+-- name: GetSyncState :one
+SELECT value FROM sync_state WHERE key = $1;
+
+-- name: SetSyncState :exec
+INSERT INTO sync_state (key, value) VALUES ($1, $2)
+ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value;
@@ -1,29 +1,78 @@
 -- name: GetTaskByID :one
 SELECT * FROM tasks WHERE id = $1;
 
+-- name: GetTaskByIDForUpdate :one
+-- Блокирует строку до конца транзакции: проверка версии и запись идут без гонок
+SELECT * FROM tasks WHERE id = $1 FOR UPDATE;
+
 -- name: GetAllTasks :many
-SELECT * FROM tasks ORDER BY created_at DESC;
+SELECT * FROM tasks
+WHERE archived_at IS NULL
+ORDER BY created_at DESC;
 
--- name: SaveTask :exec
-INSERT INTO tasks (id, title, status, created_at, due_date, priority)
-VALUES ($1, $2, $3, $4, $5, $6)
+-- name: SaveTask :one
+INSERT INTO tasks (id, title, status, created_at, due_date, priority, project, parent_id, tags, updated_at, field_clocks, sync_seq, sync_dirty, estimate_minutes, estimate_points, completed_at, start_date, custom_fields)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, nextval('task_sync_seq'), TRUE, $12, $13, $14, $15, $16)
 ON CONFLICT (id) DO UPDATE
-SET title      = EXCLUDED.title,
-    status     = EXCLUDED.status,
-    created_at = EXCLUDED.created_at,
-    due_date   = EXCLUDED.due_date,
-    priority   = EXCLUDED.priority;
+SET title            = EXCLUDED.title,
+    status           = EXCLUDED.status,
+    created_at       = EXCLUDED.created_at,
+    due_date         = EXCLUDED.due_date,
+    priority         = EXCLUDED.priority,
+    project          = EXCLUDED.project,
+    parent_id        = EXCLUDED.parent_id,
+    tags             = EXCLUDED.tags,
+    updated_at       = EXCLUDED.updated_at,
+    estimate_minutes = EXCLUDED.estimate_minutes,
+    estimate_points  = EXCLUDED.estimate_points,
+    completed_at     = EXCLUDED.completed_at,
+    archived_at      = CASE WHEN EXCLUDED.completed_at IS NULL THEN NULL ELSE tasks.archived_at END,
+    start_date       = EXCLUDED.start_date,
+    custom_fields    = EXCLUDED.custom_fields,
+    field_clocks     = EXCLUDED.field_clocks,
+    sync_seq         = EXCLUDED.sync_seq,
+    sync_dirty       = TRUE,
+    version          = tasks.version + 1
+RETURNING version;
 
 -- name: DeleteTask :exec
 DELETE FROM tasks WHERE id = $1;
 
--- name: GetTasksByStatus :many
+-- name: DeleteAllTasks :exec
+DELETE FROM tasks;
+
+-- name: GetAllTaskIDs :many
+-- Вместе с архивом: надгробия нужны для всего, что удаляет DeleteAllTasks
+SELECT id FROM tasks;
+
+-- name: GetTasksByStatuses :many
 SELECT * FROM tasks
-WHERE status = $1
+WHERE status = ANY(@statuses::text[])
+  AND archived_at IS NULL
 ORDER BY created_at DESC;
 
+-- name: CountTasksByStatus :many
+SELECT status, COUNT(*) AS count FROM tasks
+WHERE archived_at IS NULL
+GROUP BY status;
+
+-- name: GetTasksCompletedBetween :many
+SELECT * FROM tasks
+WHERE completed_at >= $1
+  AND completed_at < $2
+ORDER BY completed_at ASC;
+
 -- name: GetTasksDueBetween :many
 SELECT * FROM tasks
 WHERE due_date >= $1
   AND due_date < $2
+  AND archived_at IS NULL
 ORDER BY due_date ASC;
+
+-- name: GetTasksStartingBetween :many
+-- Отложенные задачи, чья дата начала наступила в (start, end]
+SELECT * FROM tasks
+WHERE start_date > $1
+  AND start_date <= $2
+  AND archived_at IS NULL
+ORDER BY start_date ASC;
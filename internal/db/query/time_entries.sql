@@ -0,0 +1,40 @@
+-- name: SaveTimeEntry :exec
+INSERT INTO time_entries (id, task_id, started_at, ended_at, note, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (id) DO UPDATE
+SET task_id    = EXCLUDED.task_id,
+    started_at = EXCLUDED.started_at,
+    ended_at   = EXCLUDED.ended_at,
+    note       = EXCLUDED.note,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetTimeEntryByID :one
+SELECT * FROM time_entries WHERE id = $1;
+
+-- name: GetRunningTimeEntry :one
+SELECT * FROM time_entries WHERE ended_at IS NULL;
+
+-- name: ListTimeEntriesByTask :many
+SELECT * FROM time_entries WHERE task_id = $1 ORDER BY started_at DESC;
+
+-- name: ListTimeEntriesBetween :many
+-- Записи, пересекающиеся с интервалом [$1, $2); запущенная считается открытой до текущего момента
+SELECT * FROM time_entries
+WHERE (ended_at IS NULL OR ended_at > sqlc.arg(start_time))
+  AND started_at < sqlc.arg(end_time)
+ORDER BY started_at;
+
+-- name: DeleteTimeEntry :execrows
+DELETE FROM time_entries WHERE id = $1;
+
+-- name: RefreshTaskTrackedTime :exec
+UPDATE tasks
+SET tracked_seconds = (
+    SELECT COALESCE(SUM(EXTRACT(EPOCH FROM ended_at - started_at)), 0)::BIGINT
+    FROM time_entries
+    WHERE task_id = $1 AND ended_at IS NOT NULL
+)
+WHERE id = $1;
+
+-- name: ListAllTimeEntries :many
+SELECT * FROM time_entries ORDER BY started_at, id;
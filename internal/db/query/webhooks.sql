@@ -0,0 +1,39 @@
+-- name: SaveWebhook :exec
+INSERT INTO webhooks (id, url, event_types, filter, secret, enabled, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (id) DO UPDATE
+SET url         = EXCLUDED.url,
+    event_types = EXCLUDED.event_types,
+    filter      = EXCLUDED.filter,
+    secret      = EXCLUDED.secret,
+    enabled     = EXCLUDED.enabled,
+    updated_at  = EXCLUDED.updated_at;
+
+-- name: GetWebhookByID :one
+SELECT * FROM webhooks WHERE id = $1;
+
+-- name: GetAllWebhooks :many
+SELECT * FROM webhooks ORDER BY created_at;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks WHERE id = $1;
+
+-- name: AddWebhookDelivery :one
+INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, status_code, error, duration_ms, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: WebhookEventDelivered :one
+SELECT EXISTS (
+    SELECT 1 FROM webhook_deliveries
+    WHERE webhook_id = $1
+      AND event_id = $2
+      AND error = ''
+      AND status_code BETWEEN 200 AND 299
+);
@@ -0,0 +1,32 @@
+-- name: ListWorkflowStatuses :many
+SELECT * FROM workflow_statuses ORDER BY position, key;
+
+-- name: SaveWorkflowStatus :exec
+INSERT INTO workflow_statuses (key, name, category, position, is_initial)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (key) DO UPDATE
+SET name       = EXCLUDED.name,
+    category   = EXCLUDED.category,
+    position   = EXCLUDED.position,
+    is_initial = EXCLUDED.is_initial;
+
+-- name: DeleteWorkflowStatus :execrows
+DELETE FROM workflow_statuses WHERE key = $1;
+
+-- name: CountTasksWithStatus :one
+SELECT COUNT(*) FROM tasks WHERE status = $1;
+
+-- name: ListWorkflowTransitions :many
+SELECT * FROM workflow_transitions ORDER BY from_status, to_status;
+
+-- name: DeleteWorkflowTransitions :exec
+DELETE FROM workflow_transitions;
+
+-- name: AddWorkflowTransition :exec
+INSERT INTO workflow_transitions (from_status, to_status)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: LockWorkflow :exec
+-- Правки workflow и перенос задач из удаляемых статусов идут по одной
+LOCK TABLE workflow_statuses IN SHARE ROW EXCLUSIVE MODE;
@@ -8,11 +8,178 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Task struct {
+type Attachment struct {
 	ID        string           `json:"id"`
+	TaskID    string           `json:"task_id"`
+	Name      string           `json:"name"`
+	MimeType  string           `json:"mime_type"`
+	Size      int64            `json:"size"`
+	Hash      string           `json:"hash"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type BoardPosition struct {
+	GroupBy  string `json:"group_by"`
+	TaskID   string `json:"task_id"`
+	Position int32  `json:"position"`
+}
+
+type BoardWipLimit struct {
+	GroupBy   string `json:"group_by"`
+	ColumnKey string `json:"column_key"`
+	WipLimit  int32  `json:"wip_limit"`
+}
+
+type ChecklistItem struct {
+	ID        string           `json:"id"`
+	TaskID    string           `json:"task_id"`
 	Title     string           `json:"title"`
-	Status    string           `json:"status"`
+	Checked   bool             `json:"checked"`
+	CheckedAt pgtype.Timestamp `json:"checked_at"`
+	Position  int32            `json:"position"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type CustomField struct {
+	Key       string           `json:"key"`
+	Project   string           `json:"project"`
+	Name      string           `json:"name"`
+	Type      string           `json:"type"`
+	Options   []string         `json:"options"`
+	Required  bool             `json:"required"`
+	MinValue  pgtype.Float8    `json:"min_value"`
+	MaxValue  pgtype.Float8    `json:"max_value"`
+	Pattern   string           `json:"pattern"`
+	Position  int32            `json:"position"`
 	CreatedAt pgtype.Timestamp `json:"created_at"`
-	DueDate   pgtype.Timestamp `json:"due_date"`
-	Priority  string           `json:"priority"`
+}
+
+type FocusSession struct {
+	ID             string           `json:"id"`
+	TaskID         string           `json:"task_id"`
+	StartedAt      pgtype.Timestamp `json:"started_at"`
+	EndedAt        pgtype.Timestamp `json:"ended_at"`
+	PlannedSeconds int64            `json:"planned_seconds"`
+	Completed      bool             `json:"completed"`
+}
+
+type Outbox struct {
+	ID             int64            `json:"id"`
+	IdempotencyKey string           `json:"idempotency_key"`
+	EventType      string           `json:"event_type"`
+	TaskID         string           `json:"task_id"`
+	Payload        []byte           `json:"payload"`
+	Status         string           `json:"status"`
+	Attempts       int32            `json:"attempts"`
+	LastError      string           `json:"last_error"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	NextAttemptAt  pgtype.Timestamp `json:"next_attempt_at"`
+	DeliveredAt    pgtype.Timestamp `json:"delivered_at"`
+}
+
+type SyncState struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type SyncTombstone struct {
+	TaskID    string           `json:"task_id"`
+	Clock     string           `json:"clock"`
+	DeletedAt pgtype.Timestamp `json:"deleted_at"`
+	Seq       int64            `json:"seq"`
+	Dirty     bool             `json:"dirty"`
+}
+
+type Task struct {
+	ID              string           `json:"id"`
+	Title           string           `json:"title"`
+	Status          string           `json:"status"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	DueDate         pgtype.Timestamp `json:"due_date"`
+	Priority        string           `json:"priority"`
+	Project         string           `json:"project"`
+	ParentID        pgtype.Text      `json:"parent_id"`
+	Tags            []string         `json:"tags"`
+	Version         int64            `json:"version"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+	FieldClocks     []byte           `json:"field_clocks"`
+	SyncSeq         int64            `json:"sync_seq"`
+	SyncDirty       bool             `json:"sync_dirty"`
+	TrackedSeconds  int64            `json:"tracked_seconds"`
+	EstimateMinutes int32            `json:"estimate_minutes"`
+	EstimatePoints  int32            `json:"estimate_points"`
+	CompletedAt     pgtype.Timestamp `json:"completed_at"`
+	ArchivedAt      pgtype.Timestamp `json:"archived_at"`
+	StartDate       pgtype.Timestamp `json:"start_date"`
+	ChecklistTotal  int32            `json:"checklist_total"`
+	ChecklistDone   int32            `json:"checklist_done"`
+	CustomFields    []byte           `json:"custom_fields"`
+}
+
+type TaskActivity struct {
+	ID         int64            `json:"id"`
+	TaskID     string           `json:"task_id"`
+	EventType  string           `json:"event_type"`
+	Snapshot   []byte           `json:"snapshot"`
+	OccurredAt pgtype.Timestamp `json:"occurred_at"`
+}
+
+type TaskComment struct {
+	ID        string           `json:"id"`
+	TaskID    string           `json:"task_id"`
+	Author    string           `json:"author"`
+	Body      string           `json:"body"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+type TaskDependency struct {
+	TaskID      string           `json:"task_id"`
+	BlockedByID string           `json:"blocked_by_id"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+type TimeEntry struct {
+	ID        string           `json:"id"`
+	TaskID    string           `json:"task_id"`
+	StartedAt pgtype.Timestamp `json:"started_at"`
+	EndedAt   pgtype.Timestamp `json:"ended_at"`
+	Note      string           `json:"note"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+type Webhook struct {
+	ID         string           `json:"id"`
+	Url        string           `json:"url"`
+	EventTypes []string         `json:"event_types"`
+	Filter     string           `json:"filter"`
+	Secret     string           `json:"secret"`
+	Enabled    bool             `json:"enabled"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID         int64            `json:"id"`
+	WebhookID  string           `json:"webhook_id"`
+	EventID    string           `json:"event_id"`
+	EventType  string           `json:"event_type"`
+	StatusCode int32            `json:"status_code"`
+	Error      string           `json:"error"`
+	DurationMs int64            `json:"duration_ms"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+type WorkflowStatus struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	Position  int32  `json:"position"`
+	IsInitial bool   `json:"is_initial"`
+}
+
+type WorkflowTransition struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
 }
@@ -6,15 +6,138 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AddFocusSession(ctx context.Context, arg AddFocusSessionParams) error
+	AddOutboxEntry(ctx context.Context, arg AddOutboxEntryParams) (int64, error)
+	// task.updated без видимых изменений (например, только учтенное время) не пишется
+	AddTaskActivity(ctx context.Context, arg AddTaskActivityParams) error
+	AddTaskDependency(ctx context.Context, arg AddTaskDependencyParams) error
+	AddTombstone(ctx context.Context, arg AddTombstoneParams) error
+	AddWebhookDelivery(ctx context.Context, arg AddWebhookDeliveryParams) (int64, error)
+	AddWorkflowTransition(ctx context.Context, arg AddWorkflowTransitionParams) error
+	// Запись результата слияния: метки полей приходят готовыми, sync_dirty не трогаем
+	ApplySyncTask(ctx context.Context, arg ApplySyncTaskParams) error
+	// Родитель остается активным, пока у него есть подзадачи, которые в архив не уходят
+	ArchiveTasks(ctx context.Context, arg ArchiveTasksParams) (int64, error)
+	CheckAllChecklistItems(ctx context.Context, arg CheckAllChecklistItemsParams) (int64, error)
+	// Берем самые ранние события каждой задачи, чтобы сохранить порядок доставки.
+	// next_attempt_at сдвигается на время аренды: если релей упадет, запись вернется в очередь.
+	ClaimOutboxEntries(ctx context.Context, arg ClaimOutboxEntriesParams) ([]Outbox, error)
+	CountArchivedTasks(ctx context.Context, arg CountArchivedTasksParams) (int64, error)
+	CountTaskTimeline(ctx context.Context, taskID string) (int64, error)
+	CountTasksByStatus(ctx context.Context) ([]CountTasksByStatusRow, error)
+	CountTasksWithStatus(ctx context.Context, status string) (int64, error)
+	DeleteAllTasks(ctx context.Context) error
+	DeleteAttachment(ctx context.Context, id string) (int64, error)
+	DeleteBoardWIPLimit(ctx context.Context, arg DeleteBoardWIPLimitParams) error
+	DeleteChecklistItem(ctx context.Context, id string) (int64, error)
+	DeleteComment(ctx context.Context, id string) (int64, error)
+	DeleteCustomField(ctx context.Context, key string) (int64, error)
+	DeleteDeliveredOutbox(ctx context.Context, deliveredAt pgtype.Timestamp) (int64, error)
+	// Локальное пересоздание задачи (например, импорт с заменой) отменяет неотправленное удаление
+	DeleteDirtyTombstone(ctx context.Context, taskID string) error
+	// Недоставленные события старше срока хранения: релей выключен или получатель давно недоступен
+	DeleteStaleOutbox(ctx context.Context, createdAt pgtype.Timestamp) (int64, error)
 	DeleteTask(ctx context.Context, id string) error
+	DeleteTaskDependency(ctx context.Context, arg DeleteTaskDependencyParams) (int64, error)
+	DeleteTimeEntry(ctx context.Context, id string) (int64, error)
+	DeleteWebhook(ctx context.Context, id string) (int64, error)
+	DeleteWorkflowStatus(ctx context.Context, key string) (int64, error)
+	DeleteWorkflowTransitions(ctx context.Context) error
+	// Задачи вне архива по условиям на значения полей, проверяемым GIN-индексом
+	FindTasksByCustomFields(ctx context.Context, arg FindTasksByCustomFieldsParams) ([]Task, error)
+	// Вместе с архивом: надгробия нужны для всего, что удаляет DeleteAllTasks
+	GetAllTaskIDs(ctx context.Context) ([]string, error)
 	GetAllTasks(ctx context.Context) ([]Task, error)
+	GetAllWebhooks(ctx context.Context) ([]Webhook, error)
+	// Перенос задач из удаляемого статуса затрагивает и архив
+	GetArchivedTasksByStatus(ctx context.Context, status string) ([]Task, error)
+	GetAttachmentByID(ctx context.Context, id string) (Attachment, error)
+	GetChecklistItemByID(ctx context.Context, id string) (ChecklistItem, error)
+	GetCommentByID(ctx context.Context, id string) (TaskComment, error)
+	GetCustomField(ctx context.Context, key string) (CustomField, error)
+	GetRunningTimeEntry(ctx context.Context) (TimeEntry, error)
+	GetSyncState(ctx context.Context, key string) (string, error)
 	GetTaskByID(ctx context.Context, id string) (Task, error)
-	GetTasksByStatus(ctx context.Context, status string) ([]Task, error)
+	// Блокирует строку до конца транзакции: проверка версии и запись идут без гонок
+	GetTaskByIDForUpdate(ctx context.Context, id string) (Task, error)
+	GetTaskDescendantIDs(ctx context.Context, parentID pgtype.Text) ([]string, error)
+	GetTasksByStatuses(ctx context.Context, statuses []string) ([]Task, error)
+	GetTasksCompletedBetween(ctx context.Context, arg GetTasksCompletedBetweenParams) ([]Task, error)
 	GetTasksDueBetween(ctx context.Context, arg GetTasksDueBetweenParams) ([]Task, error)
-	SaveTask(ctx context.Context, arg SaveTaskParams) error
+	// Отложенные задачи, чья дата начала наступила в (start, end]
+	GetTasksStartingBetween(ctx context.Context, arg GetTasksStartingBetweenParams) ([]Task, error)
+	// Задачи, включая архив, у которых заполнено поле
+	GetTasksWithCustomField(ctx context.Context, key string) ([]Task, error)
+	GetTimeEntryByID(ctx context.Context, id string) (TimeEntry, error)
+	GetWebhookByID(ctx context.Context, id string) (Webhook, error)
+	ListAllAttachments(ctx context.Context) ([]Attachment, error)
+	ListAllChecklistItems(ctx context.Context) ([]ChecklistItem, error)
+	ListAllComments(ctx context.Context) ([]TaskComment, error)
+	ListAllFocusSessions(ctx context.Context) ([]FocusSession, error)
+	ListAllTaskActivity(ctx context.Context) ([]TaskActivity, error)
+	ListAllTimeEntries(ctx context.Context) ([]TimeEntry, error)
+	ListArchivedTasks(ctx context.Context, arg ListArchivedTasksParams) ([]Task, error)
+	// Хеши, на которые ссылается хотя бы одно вложение; остальные blob'ы - мусор
+	ListAttachmentHashes(ctx context.Context) ([]string, error)
+	ListAttachmentsByTask(ctx context.Context, taskID string) ([]Attachment, error)
+	ListBoardPositions(ctx context.Context, groupBy string) ([]ListBoardPositionsRow, error)
+	ListBoardWIPLimits(ctx context.Context, groupBy string) ([]BoardWipLimit, error)
+	ListChecklistItemsByTask(ctx context.Context, taskID string) ([]ChecklistItem, error)
+	ListCustomFields(ctx context.Context) ([]CustomField, error)
+	ListDirtyTasks(ctx context.Context) ([]Task, error)
+	ListDirtyTombstones(ctx context.Context) ([]SyncTombstone, error)
+	ListFocusSessionsBetween(ctx context.Context, arg ListFocusSessionsBetweenParams) ([]FocusSession, error)
+	ListOrphanTaskIDs(ctx context.Context) ([]string, error)
+	ListOutboxByStatus(ctx context.Context, arg ListOutboxByStatusParams) ([]Outbox, error)
+	ListTaskBlockers(ctx context.Context, taskID string) ([]string, error)
+	ListTaskChangesSince(ctx context.Context, arg ListTaskChangesSinceParams) ([]Task, error)
+	ListTaskDependencies(ctx context.Context) ([]TaskDependency, error)
+	// Комментарии и активность одной лентой, новые первыми. previous - снапшот
+	// предыдущей записи активности, чтобы показать изменения на любой странице.
+	ListTaskTimeline(ctx context.Context, arg ListTaskTimelineParams) ([]ListTaskTimelineRow, error)
+	// Записи, пересекающиеся с интервалом [$1, $2); запущенная считается открытой до текущего момента
+	ListTimeEntriesBetween(ctx context.Context, arg ListTimeEntriesBetweenParams) ([]TimeEntry, error)
+	ListTimeEntriesByTask(ctx context.Context, taskID string) ([]TimeEntry, error)
+	ListTombstonesSince(ctx context.Context, arg ListTombstonesSinceParams) ([]SyncTombstone, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWorkflowStatuses(ctx context.Context) ([]WorkflowStatus, error)
+	ListWorkflowTransitions(ctx context.Context) ([]WorkflowTransition, error)
+	// Проверка WIP-лимита и перенос карточки должны видеть одно и то же состояние доски
+	LockBoard(ctx context.Context) error
+	// Проверка на цикл читает весь граф, поэтому параллельные вставки ребер сериализуются
+	LockTaskDependencies(ctx context.Context) error
+	// Правки workflow и перенос задач из удаляемых статусов идут по одной
+	LockWorkflow(ctx context.Context) error
+	MarkOutboxDelivered(ctx context.Context, arg MarkOutboxDeliveredParams) error
+	MarkOutboxFailed(ctx context.Context, arg MarkOutboxFailedParams) error
+	MarkTaskSynced(ctx context.Context, arg MarkTaskSyncedParams) error
+	MarkTombstoneSynced(ctx context.Context, arg MarkTombstoneSyncedParams) error
+	RefreshTaskChecklist(ctx context.Context, taskID string) error
+	RefreshTaskTrackedTime(ctx context.Context, taskID string) error
+	RequeueOutboxEntry(ctx context.Context, arg RequeueOutboxEntryParams) (int64, error)
+	// Восстановление из бэкапа: в архиве бывают только закрытые задачи
+	RestoreArchivedTask(ctx context.Context, arg RestoreArchivedTaskParams) error
+	// Запись из бэкапа; повторный импорт того же бэкапа ее не дублирует
+	RestoreTaskActivity(ctx context.Context, arg RestoreTaskActivityParams) error
+	SaveAttachment(ctx context.Context, arg SaveAttachmentParams) error
+	SaveChecklistItem(ctx context.Context, arg SaveChecklistItemParams) error
+	SaveComment(ctx context.Context, arg SaveCommentParams) error
+	SaveCustomField(ctx context.Context, arg SaveCustomFieldParams) error
+	SaveTask(ctx context.Context, arg SaveTaskParams) (int64, error)
+	SaveTimeEntry(ctx context.Context, arg SaveTimeEntryParams) error
+	SaveWebhook(ctx context.Context, arg SaveWebhookParams) error
+	SaveWorkflowStatus(ctx context.Context, arg SaveWorkflowStatusParams) error
+	SetBoardPosition(ctx context.Context, arg SetBoardPositionParams) error
+	SetBoardWIPLimit(ctx context.Context, arg SetBoardWIPLimitParams) error
+	SetChecklistItemPosition(ctx context.Context, arg SetChecklistItemPositionParams) error
+	SetSyncState(ctx context.Context, arg SetSyncStateParams) error
+	TombstoneExists(ctx context.Context, taskID string) (bool, error)
+	WebhookEventDelivered(ctx context.Context, arg WebhookEventDeliveredParams) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -11,6 +11,46 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTasksByStatus = `-- name: CountTasksByStatus :many
+SELECT status, COUNT(*) AS count FROM tasks
+WHERE archived_at IS NULL
+GROUP BY status
+`
+
+type CountTasksByStatusRow struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+func (q *Queries) CountTasksByStatus(ctx context.Context) ([]CountTasksByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countTasksByStatus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountTasksByStatusRow{}
+	for rows.Next() {
+		var i CountTasksByStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteAllTasks = `-- name: DeleteAllTasks :exec
+DELETE FROM tasks
+`
+
+func (q *Queries) DeleteAllTasks(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteAllTasks)
+	return err
+}
+
 const deleteTask = `-- name: DeleteTask :exec
 DELETE FROM tasks WHERE id = $1
 `
@@ -20,8 +60,35 @@ func (q *Queries) DeleteTask(ctx context.Context, id string) error {
 	return err
 }
 
+const getAllTaskIDs = `-- name: GetAllTaskIDs :many
+SELECT id FROM tasks
+`
+
+// Вместе с архивом: надгробия нужны для всего, что удаляет DeleteAllTasks
+func (q *Queries) GetAllTaskIDs(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, getAllTaskIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllTasks = `-- name: GetAllTasks :many
-SELECT id, title, status, created_at, due_date, priority FROM tasks ORDER BY created_at DESC
+SELECT id, title, status, created_at, due_date, priority, project, parent_id, tags, version, updated_at, field_clocks, sync_seq, sync_dirty, tracked_seconds, estimate_minutes, estimate_points, completed_at, archived_at, start_date, checklist_total, checklist_done, custom_fields FROM tasks
+WHERE archived_at IS NULL
+ORDER BY created_at DESC
 `
 
 func (q *Queries) GetAllTasks(ctx context.Context) ([]Task, error) {
@@ -40,6 +107,23 @@ func (q *Queries) GetAllTasks(ctx context.Context) ([]Task, error) {
 			&i.CreatedAt,
 			&i.DueDate,
 			&i.Priority,
+			&i.Project,
+			&i.ParentID,
+			&i.Tags,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.FieldClocks,
+			&i.SyncSeq,
+			&i.SyncDirty,
+			&i.TrackedSeconds,
+			&i.EstimateMinutes,
+			&i.EstimatePoints,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StartDate,
+			&i.ChecklistTotal,
+			&i.ChecklistDone,
+			&i.CustomFields,
 		); err != nil {
 			return nil, err
 		}
@@ -52,7 +136,7 @@ func (q *Queries) GetAllTasks(ctx context.Context) ([]Task, error) {
 }
 
 const getTaskByID = `-- name: GetTaskByID :one
-SELECT id, title, status, created_at, due_date, priority FROM tasks WHERE id = $1
+SELECT id, title, status, created_at, due_date, priority, project, parent_id, tags, version, updated_at, field_clocks, sync_seq, sync_dirty, tracked_seconds, estimate_minutes, estimate_points, completed_at, archived_at, start_date, checklist_total, checklist_done, custom_fields FROM tasks WHERE id = $1
 `
 
 func (q *Queries) GetTaskByID(ctx context.Context, id string) (Task, error) {
@@ -65,18 +149,72 @@ func (q *Queries) GetTaskByID(ctx context.Context, id string) (Task, error) {
 		&i.CreatedAt,
 		&i.DueDate,
 		&i.Priority,
+		&i.Project,
+		&i.ParentID,
+		&i.Tags,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.FieldClocks,
+		&i.SyncSeq,
+		&i.SyncDirty,
+		&i.TrackedSeconds,
+		&i.EstimateMinutes,
+		&i.EstimatePoints,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StartDate,
+		&i.ChecklistTotal,
+		&i.ChecklistDone,
+		&i.CustomFields,
 	)
 	return i, err
 }
 
-const getTasksByStatus = `-- name: GetTasksByStatus :many
-SELECT id, title, status, created_at, due_date, priority FROM tasks
-WHERE status = $1
+const getTaskByIDForUpdate = `-- name: GetTaskByIDForUpdate :one
+SELECT id, title, status, created_at, due_date, priority, project, parent_id, tags, version, updated_at, field_clocks, sync_seq, sync_dirty, tracked_seconds, estimate_minutes, estimate_points, completed_at, archived_at, start_date, checklist_total, checklist_done, custom_fields FROM tasks WHERE id = $1 FOR UPDATE
+`
+
+// Блокирует строку до конца транзакции: проверка версии и запись идут без гонок
+func (q *Queries) GetTaskByIDForUpdate(ctx context.Context, id string) (Task, error) {
+	row := q.db.QueryRow(ctx, getTaskByIDForUpdate, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Status,
+		&i.CreatedAt,
+		&i.DueDate,
+		&i.Priority,
+		&i.Project,
+		&i.ParentID,
+		&i.Tags,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.FieldClocks,
+		&i.SyncSeq,
+		&i.SyncDirty,
+		&i.TrackedSeconds,
+		&i.EstimateMinutes,
+		&i.EstimatePoints,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StartDate,
+		&i.ChecklistTotal,
+		&i.ChecklistDone,
+		&i.CustomFields,
+	)
+	return i, err
+}
+
+const getTasksByStatuses = `-- name: GetTasksByStatuses :many
+SELECT id, title, status, created_at, due_date, priority, project, parent_id, tags, version, updated_at, field_clocks, sync_seq, sync_dirty, tracked_seconds, estimate_minutes, estimate_points, completed_at, archived_at, start_date, checklist_total, checklist_done, custom_fields FROM tasks
+WHERE status = ANY($1::text[])
+  AND archived_at IS NULL
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetTasksByStatus(ctx context.Context, status string) ([]Task, error) {
-	rows, err := q.db.Query(ctx, getTasksByStatus, status)
+func (q *Queries) GetTasksByStatuses(ctx context.Context, statuses []string) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksByStatuses, statuses)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +229,79 @@ func (q *Queries) GetTasksByStatus(ctx context.Context, status string) ([]Task,
 			&i.CreatedAt,
 			&i.DueDate,
 			&i.Priority,
+			&i.Project,
+			&i.ParentID,
+			&i.Tags,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.FieldClocks,
+			&i.SyncSeq,
+			&i.SyncDirty,
+			&i.TrackedSeconds,
+			&i.EstimateMinutes,
+			&i.EstimatePoints,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StartDate,
+			&i.ChecklistTotal,
+			&i.ChecklistDone,
+			&i.CustomFields,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksCompletedBetween = `-- name: GetTasksCompletedBetween :many
+SELECT id, title, status, created_at, due_date, priority, project, parent_id, tags, version, updated_at, field_clocks, sync_seq, sync_dirty, tracked_seconds, estimate_minutes, estimate_points, completed_at, archived_at, start_date, checklist_total, checklist_done, custom_fields FROM tasks
+WHERE completed_at >= $1
+  AND completed_at < $2
+ORDER BY completed_at ASC
+`
+
+type GetTasksCompletedBetweenParams struct {
+	CompletedAt   pgtype.Timestamp `json:"completed_at"`
+	CompletedAt_2 pgtype.Timestamp `json:"completed_at_2"`
+}
+
+func (q *Queries) GetTasksCompletedBetween(ctx context.Context, arg GetTasksCompletedBetweenParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksCompletedBetween, arg.CompletedAt, arg.CompletedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Status,
+			&i.CreatedAt,
+			&i.DueDate,
+			&i.Priority,
+			&i.Project,
+			&i.ParentID,
+			&i.Tags,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.FieldClocks,
+			&i.SyncSeq,
+			&i.SyncDirty,
+			&i.TrackedSeconds,
+			&i.EstimateMinutes,
+			&i.EstimatePoints,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StartDate,
+			&i.ChecklistTotal,
+			&i.ChecklistDone,
+			&i.CustomFields,
 		); err != nil {
 			return nil, err
 		}
@@ -103,9 +314,10 @@ func (q *Queries) GetTasksByStatus(ctx context.Context, status string) ([]Task,
 }
 
 const getTasksDueBetween = `-- name: GetTasksDueBetween :many
-SELECT id, title, status, created_at, due_date, priority FROM tasks
+SELECT id, title, status, created_at, due_date, priority, project, parent_id, tags, version, updated_at, field_clocks, sync_seq, sync_dirty, tracked_seconds, estimate_minutes, estimate_points, completed_at, archived_at, start_date, checklist_total, checklist_done, custom_fields FROM tasks
 WHERE due_date >= $1
   AND due_date < $2
+  AND archived_at IS NULL
 ORDER BY due_date ASC
 `
 
@@ -130,6 +342,23 @@ func (q *Queries) GetTasksDueBetween(ctx context.Context, arg GetTasksDueBetween
 			&i.CreatedAt,
 			&i.DueDate,
 			&i.Priority,
+			&i.Project,
+			&i.ParentID,
+			&i.Tags,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.FieldClocks,
+			&i.SyncSeq,
+			&i.SyncDirty,
+			&i.TrackedSeconds,
+			&i.EstimateMinutes,
+			&i.EstimatePoints,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StartDate,
+			&i.ChecklistTotal,
+			&i.ChecklistDone,
+			&i.CustomFields,
 		); err != nil {
 			return nil, err
 		}
@@ -141,34 +370,130 @@ func (q *Queries) GetTasksDueBetween(ctx context.Context, arg GetTasksDueBetween
 	return items, nil
 }
 
-const saveTask = `-- name: SaveTask :exec
-INSERT INTO tasks (id, title, status, created_at, due_date, priority)
-VALUES ($1, $2, $3, $4, $5, $6)
+const getTasksStartingBetween = `-- name: GetTasksStartingBetween :many
+-- Отложенные задачи, чья дата начала наступила в (start, end]
+SELECT id, title, status, created_at, due_date, priority, project, parent_id, tags, version, updated_at, field_clocks, sync_seq, sync_dirty, tracked_seconds, estimate_minutes, estimate_points, completed_at, archived_at, start_date, checklist_total, checklist_done, custom_fields FROM tasks
+WHERE start_date > $1
+  AND start_date <= $2
+  AND archived_at IS NULL
+ORDER BY start_date ASC
+`
+
+type GetTasksStartingBetweenParams struct {
+	StartDate   pgtype.Timestamp `json:"start_date"`
+	StartDate_2 pgtype.Timestamp `json:"start_date_2"`
+}
+
+// Отложенные задачи, чья дата начала наступила в (start, end]
+func (q *Queries) GetTasksStartingBetween(ctx context.Context, arg GetTasksStartingBetweenParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksStartingBetween, arg.StartDate, arg.StartDate_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Status,
+			&i.CreatedAt,
+			&i.DueDate,
+			&i.Priority,
+			&i.Project,
+			&i.ParentID,
+			&i.Tags,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.FieldClocks,
+			&i.SyncSeq,
+			&i.SyncDirty,
+			&i.TrackedSeconds,
+			&i.EstimateMinutes,
+			&i.EstimatePoints,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StartDate,
+			&i.ChecklistTotal,
+			&i.ChecklistDone,
+			&i.CustomFields,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const saveTask = `-- name: SaveTask :one
+INSERT INTO tasks (id, title, status, created_at, due_date, priority, project, parent_id, tags, updated_at, field_clocks, sync_seq, sync_dirty, estimate_minutes, estimate_points, completed_at, start_date, custom_fields)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, nextval('task_sync_seq'), TRUE, $12, $13, $14, $15, $16)
 ON CONFLICT (id) DO UPDATE
-SET title      = EXCLUDED.title,
-    status     = EXCLUDED.status,
-    created_at = EXCLUDED.created_at,
-    due_date   = EXCLUDED.due_date,
-    priority   = EXCLUDED.priority
+SET title            = EXCLUDED.title,
+    status           = EXCLUDED.status,
+    created_at       = EXCLUDED.created_at,
+    due_date         = EXCLUDED.due_date,
+    priority         = EXCLUDED.priority,
+    project          = EXCLUDED.project,
+    parent_id        = EXCLUDED.parent_id,
+    tags             = EXCLUDED.tags,
+    updated_at       = EXCLUDED.updated_at,
+    estimate_minutes = EXCLUDED.estimate_minutes,
+    estimate_points  = EXCLUDED.estimate_points,
+    completed_at     = EXCLUDED.completed_at,
+    archived_at      = CASE WHEN EXCLUDED.completed_at IS NULL THEN NULL ELSE tasks.archived_at END,
+    start_date       = EXCLUDED.start_date,
+    custom_fields    = EXCLUDED.custom_fields,
+    field_clocks     = EXCLUDED.field_clocks,
+    sync_seq         = EXCLUDED.sync_seq,
+    sync_dirty       = TRUE,
+    version          = tasks.version + 1
+RETURNING version
 `
 
 type SaveTaskParams struct {
-	ID        string           `json:"id"`
-	Title     string           `json:"title"`
-	Status    string           `json:"status"`
-	CreatedAt pgtype.Timestamp `json:"created_at"`
-	DueDate   pgtype.Timestamp `json:"due_date"`
-	Priority  string           `json:"priority"`
+	ID              string           `json:"id"`
+	Title           string           `json:"title"`
+	Status          string           `json:"status"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	DueDate         pgtype.Timestamp `json:"due_date"`
+	Priority        string           `json:"priority"`
+	Project         string           `json:"project"`
+	ParentID        pgtype.Text      `json:"parent_id"`
+	Tags            []string         `json:"tags"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+	FieldClocks     []byte           `json:"field_clocks"`
+	EstimateMinutes int32            `json:"estimate_minutes"`
+	EstimatePoints  int32            `json:"estimate_points"`
+	CompletedAt     pgtype.Timestamp `json:"completed_at"`
+	StartDate       pgtype.Timestamp `json:"start_date"`
+	CustomFields    []byte           `json:"custom_fields"`
 }
 
-func (q *Queries) SaveTask(ctx context.Context, arg SaveTaskParams) error {
-	_, err := q.db.Exec(ctx, saveTask,
+func (q *Queries) SaveTask(ctx context.Context, arg SaveTaskParams) (int64, error) {
+	row := q.db.QueryRow(ctx, saveTask,
 		arg.ID,
 		arg.Title,
 		arg.Status,
 		arg.CreatedAt,
 		arg.DueDate,
 		arg.Priority,
+		arg.Project,
+		arg.ParentID,
+		arg.Tags,
+		arg.UpdatedAt,
+		arg.FieldClocks,
+		arg.EstimateMinutes,
+		arg.EstimatePoints,
+		arg.CompletedAt,
+		arg.StartDate,
+		arg.CustomFields,
 	)
-	return err
+	var version int64
+	err := row.Scan(&version)
+	return version, err
 }
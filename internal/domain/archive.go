@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ArchiveFilter - поиск по архиву; Limit 0 - без ограничения
+type ArchiveFilter struct {
+	Query   string  // подстрока названия, проекта или тега
+	Project *string // точное совпадение проекта
+	Limit   int
+	Offset  int
+}
+
+// ArchiveRepository - закрытые давно задачи. Архивные задачи не попадают в GetAll,
+// GetByStatus и GetDueBetween, но доступны по ID; повторное открытие возвращает задачу из архива.
+type ArchiveRepository interface {
+	// Archive отправляет в архив задачи, закрытые раньше before, и возвращает их число
+	Archive(ctx context.Context, before, at time.Time) (int, error)
+	// List - по убыванию времени закрытия
+	List(ctx context.Context, filter ArchiveFilter) ([]*Task, error)
+	Count(ctx context.Context, filter ArchiveFilter) (int, error)
+	// GetByStatus - архивные задачи в статусе (перенос из удаляемого статуса)
+	GetByStatus(ctx context.Context, status TaskStatus) ([]*Task, error)
+	// Restore возвращает закрытую задачу в архив с исходным временем (импорт бэкапа)
+	Restore(ctx context.Context, taskID string, at time.Time) error
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrInvalidAttachment  = errors.New("invalid attachment")
+)
+
+// Attachment - файл задачи; содержимое хранится отдельно и адресуется Hash (SHA-256, hex)
+type Attachment struct {
+	ID        string
+	TaskID    string
+	Name      string // имя исходного файла без пути
+	MimeType  string
+	Size      int64
+	Hash      string
+	CreatedAt time.Time
+}
+
+func NewAttachment(taskID, name, mimeType string, size int64, hash string) (*Attachment, error) {
+	a := &Attachment{
+		ID:        "att_" + time.Now().Format("20060102150405") + "_" + randomHex(4),
+		TaskID:    taskID,
+		Name:      CleanAttachmentName(name),
+		MimeType:  mimeType,
+		Size:      size,
+		Hash:      hash,
+		CreatedAt: time.Now(),
+	}
+
+	if err := a.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *Attachment) IsValid() error {
+	if a.Name == "" || len(a.Name) > 255 || a.MimeType == "" || a.Size < 0 || len(a.Hash) != 64 {
+		return ErrInvalidAttachment
+	}
+	return nil
+}
+
+// CleanAttachmentName оставляет от имени только последний элемент пути
+func CleanAttachmentName(name string) string {
+	name = strings.TrimSpace(strings.ReplaceAll(name, "\\", "/"))
+	name = filepath.Base(filepath.FromSlash(name))
+	if name == "." || name == string(filepath.Separator) {
+		return ""
+	}
+	return name
+}
+
+// AttachmentRepository работает в транзакции репозитория задач;
+// вложения удаляются каскадно вместе с задачей, их blob'ы собирает сборщик мусора
+type AttachmentRepository interface {
+	// Save не трогает уже записанное вложение с тем же ID: вложения не меняются
+	Save(ctx context.Context, a *Attachment) error
+	GetByID(ctx context.Context, id string) (*Attachment, error)
+	ListByTask(ctx context.Context, taskID string) ([]*Attachment, error)
+	// GetAll - вложения всех задач, для бэкапа
+	GetAll(ctx context.Context) ([]*Attachment, error)
+	// ReferencedHashes - хеши всех blob'ов, на которые есть ссылки
+	ReferencedHashes(ctx context.Context) (map[string]bool, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidGrouping  = errors.New("invalid board grouping")
+	ErrInvalidColumn    = errors.New("invalid board column")
+	ErrWIPLimitExceeded = errors.New("wip limit exceeded")
+)
+
+// BoardGrouping - поле задачи, по которому карточки раскладываются в колонки
+type BoardGrouping string
+
+const (
+	BoardByStatus   BoardGrouping = "status"
+	BoardByPriority BoardGrouping = "priority"
+	BoardByDue      BoardGrouping = "due"
+)
+
+// BoardGroupings - все группировки доски
+var BoardGroupings = []BoardGrouping{BoardByStatus, BoardByPriority, BoardByDue}
+
+func (g BoardGrouping) IsValid() error {
+	if g != BoardByStatus && g != BoardByPriority && g != BoardByDue {
+		return ErrInvalidGrouping
+	}
+	return nil
+}
+
+// Колонки группировки по сроку
+const (
+	DueOverdue  = "overdue"
+	DueToday    = "today"
+	DueThisWeek = "week"
+	DueLater    = "later"
+	DueNone     = "none"
+)
+
+var DueBuckets = []string{DueOverdue, DueToday, DueThisWeek, DueLater, DueNone}
+
+// DueBucket - колонка задачи при группировке по сроку; неделя начинается в воскресенье, как в списке задач
+func DueBucket(t *Task, now time.Time) string {
+	if t.DueDate == nil {
+		return DueNone
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekEnd := today.AddDate(0, 0, 7-int(now.Weekday()))
+	switch due := *t.DueDate; {
+	case due.Before(now):
+		return DueOverdue
+	case due.Before(today.AddDate(0, 0, 1)):
+		return DueToday
+	case due.Before(weekEnd):
+		return DueThisWeek
+	}
+	return DueLater
+}
+
+// DueForBucket - срок, который получает задача при переносе в колонку:
+// конец дня сегодня, конец недели или начало следующей. В просроченные переносить нельзя.
+func DueForBucket(bucket string, now time.Time) (due *time.Time, err error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekEnd := today.AddDate(0, 0, 7-int(now.Weekday()))
+
+	var d time.Time
+	switch bucket {
+	case DueToday:
+		d = today.AddDate(0, 0, 1).Add(-time.Minute)
+	case DueThisWeek:
+		d = weekEnd.Add(-time.Minute)
+		if d.Before(today.AddDate(0, 0, 1)) {
+			return nil, ErrInvalidColumn // суббота: неделя уже закончилась
+		}
+	case DueLater:
+		d = weekEnd.Add(9 * time.Hour)
+	case DueNone:
+		return nil, nil
+	default:
+		return nil, ErrInvalidColumn
+	}
+	return &d, nil
+}
+
+// BoardRepository - порядок карточек и WIP-лимиты, отдельно для каждой группировки.
+// Работает в транзакции репозитория задач.
+type BoardRepository interface {
+	// Lock сериализует перемещения карточек, чтобы проверка WIP-лимита была атомарной
+	Lock(ctx context.Context) error
+	// Positions - ID задачи -> позиция в колонке
+	Positions(ctx context.Context, grouping BoardGrouping) (map[string]int, error)
+	// SetPositions нумерует задачи колонки по порядку
+	SetPositions(ctx context.Context, grouping BoardGrouping, taskIDs []string) error
+	// WIPLimits - колонка -> лимит; колонок без лимита нет
+	WIPLimits(ctx context.Context, grouping BoardGrouping) (map[string]int, error)
+	// SetWIPLimit с limit 0 снимает лимит
+	SetWIPLimit(ctx context.Context, grouping BoardGrouping, column string, limit int) error
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrInvalidChecklistItem  = errors.New("invalid checklist item")
+)
+
+// ChecklistItem - пункт чек-листа задачи; легче подзадачи: без статуса, срока и событий
+type ChecklistItem struct {
+	ID        string
+	TaskID    string
+	Title     string
+	Checked   bool
+	CheckedAt *time.Time
+	Position  int
+	CreatedAt time.Time
+}
+
+func NewChecklistItem(taskID, title string, position int) (*ChecklistItem, error) {
+	item := &ChecklistItem{
+		ID:        "cl_" + time.Now().Format("20060102150405") + "_" + randomHex(4),
+		TaskID:    taskID,
+		Title:     strings.TrimSpace(title),
+		Position:  position,
+		CreatedAt: time.Now(),
+	}
+
+	if err := item.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (i *ChecklistItem) IsValid() error {
+	if i.Title == "" || len(i.Title) > 255 {
+		return ErrInvalidChecklistItem
+	}
+	return nil
+}
+
+// SetChecked отмечает пункт или снимает отметку вместе со временем
+func (i *ChecklistItem) SetChecked(checked bool, at time.Time) {
+	i.Checked = checked
+	if checked {
+		i.CheckedAt = &at
+	} else {
+		i.CheckedAt = nil
+	}
+}
+
+// ChecklistComplete - у задачи есть чек-лист и все пункты отмечены
+func (t *Task) ChecklistComplete() bool {
+	return t.ChecklistTotal > 0 && t.ChecklistDone == t.ChecklistTotal
+}
+
+// ChecklistRepository работает в транзакции репозитория задач;
+// после каждого изменения пересчитывает Task.ChecklistTotal и ChecklistDone
+type ChecklistRepository interface {
+	Save(ctx context.Context, item *ChecklistItem) error
+	GetByID(ctx context.Context, id string) (*ChecklistItem, error)
+	// ListByTask - пункты задачи по порядку
+	ListByTask(ctx context.Context, taskID string) ([]*ChecklistItem, error)
+	// GetAll - пункты всех задач, для бэкапа
+	GetAll(ctx context.Context) ([]*ChecklistItem, error)
+	// SetPositions нумерует пункты задачи по порядку itemIDs
+	SetPositions(ctx context.Context, taskID string, itemIDs []string) error
+	// CheckAll отмечает все пункты задачи и возвращает число отмеченных
+	CheckAll(ctx context.Context, taskID string, at time.Time) (int, error)
+	Delete(ctx context.Context, id string) error
+}
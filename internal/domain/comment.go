@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// maxCommentLength - ограничение на тело комментария в символах
+const maxCommentLength = 10000
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrInvalidComment  = errors.New("invalid comment")
+)
+
+// Comment - заметка к задаче; Body хранится как Markdown и рендерится фронтендом
+type Comment struct {
+	ID        string
+	TaskID    string
+	Author    string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewComment(taskID, author, body string) (*Comment, error) {
+	now := time.Now()
+	c := &Comment{
+		ID:        "cm_" + now.Format("20060102150405") + "_" + randomHex(4),
+		TaskID:    taskID,
+		Author:    strings.TrimSpace(author),
+		Body:      strings.TrimSpace(body),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := c.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Comment) IsValid() error {
+	if c.Body == "" || len([]rune(c.Body)) > maxCommentLength || c.Author == "" || len(c.Author) > 255 {
+		return ErrInvalidComment
+	}
+	return nil
+}
+
+// Edit меняет текст; автор и время создания остаются прежними
+func (c *Comment) Edit(body string, at time.Time) error {
+	body = strings.TrimSpace(body)
+	if body == c.Body {
+		return nil
+	}
+
+	prev := c.Body
+	c.Body = body
+	if err := c.IsValid(); err != nil {
+		c.Body = prev
+		return err
+	}
+	c.UpdatedAt = at
+	return nil
+}
+
+// Edited - комментарий меняли после создания
+func (c *Comment) Edited() bool {
+	return c.UpdatedAt.After(c.CreatedAt)
+}
+
+// ActivityEntry - запись истории задачи: доменное событие и снапшот задачи после него (JSON)
+type ActivityEntry struct {
+	ID         int64
+	TaskID     string
+	EventType  string
+	Snapshot   []byte
+	OccurredAt time.Time
+}
+
+// TimelineEntry - элемент общей ленты задачи: либо комментарий, либо активность.
+// Previous - снапшот предыдущей записи активности, nil для первой.
+type TimelineEntry struct {
+	Comment  *Comment
+	Activity *ActivityEntry
+	Previous []byte
+}
+
+// At - время элемента ленты
+func (e TimelineEntry) At() time.Time {
+	if e.Comment != nil {
+		return e.Comment.CreatedAt
+	}
+	return e.Activity.OccurredAt
+}
+
+// CommentRepository работает в транзакции репозитория задач;
+// комментарии удаляются каскадно вместе с задачей
+type CommentRepository interface {
+	Save(ctx context.Context, c *Comment) error
+	GetByID(ctx context.Context, id string) (*Comment, error)
+	// GetAll - комментарии всех задач, для бэкапа
+	GetAll(ctx context.Context) ([]*Comment, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// ActivityRepository - история задачи вместе с комментариями, новые первыми
+type ActivityRepository interface {
+	Add(ctx context.Context, entry *ActivityEntry) error
+	Timeline(ctx context.Context, taskID string, limit, offset int) ([]TimelineEntry, error)
+	CountTimeline(ctx context.Context, taskID string) (int, error)
+	// GetAll - история всех задач по времени, для бэкапа
+	GetAll(ctx context.Context) ([]*ActivityEntry, error)
+	// Restore добавляет запись из бэкапа, если такой же (задача, тип, время) еще нет
+	Restore(ctx context.Context, entry *ActivityEntry) error
+}
@@ -0,0 +1,473 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	ErrInvalidCustomField  = errors.New("invalid custom field")
+	ErrInvalidFieldValue   = errors.New("invalid custom field value")
+)
+
+type CustomFieldType string
+
+const (
+	FieldTypeText     CustomFieldType = "text"
+	FieldTypeNumber   CustomFieldType = "number"
+	FieldTypeDate     CustomFieldType = "date"
+	FieldTypeSelect   CustomFieldType = "select"
+	FieldTypeCheckbox CustomFieldType = "checkbox"
+	FieldTypeURL      CustomFieldType = "url"
+)
+
+// CustomFieldDateLayout - поля-даты хранят день без времени, такие строки сортируются как даты
+const CustomFieldDateLayout = "2006-01-02"
+
+// Операторы фильтра по пользовательскому полю
+const (
+	FieldOpEq       = "=="
+	FieldOpNe       = "!="
+	FieldOpLt       = "<"
+	FieldOpLte      = "<="
+	FieldOpGt       = ">"
+	FieldOpGte      = ">="
+	FieldOpContains = "contains" // подстрока без учета регистра, для text и url
+	FieldOpSet      = "set"      // значение заполнено
+	FieldOpUnset    = "unset"
+)
+
+const maxFieldTextLength = 1000
+
+// CustomField - пользовательское поле задачи и правила проверки его значений.
+// Значения хранятся в Task.CustomFields: string для text, url, select и date,
+// float64 для number, bool для checkbox.
+type CustomField struct {
+	Key      string          `json:"key"`               // неизменяем, хранится в задачах
+	Project  string          `json:"project,omitempty"` // пусто - поле есть во всех проектах
+	Name     string          `json:"name"`
+	Type     CustomFieldType `json:"type"`
+	Options  []string        `json:"options,omitempty"` // варианты select в порядке сортировки
+	Required bool            `json:"required,omitempty"`
+	// Min и Max - границы числа или длины текста
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"` // регулярное выражение для text
+	Position int      `json:"position"`
+}
+
+func (f CustomField) IsValid() error {
+	if !statusKeyPattern.MatchString(f.Key) {
+		return fmt.Errorf("%w: bad key %q", ErrInvalidCustomField, f.Key)
+	}
+	if f.Name == "" || len(f.Name) > 50 {
+		return fmt.Errorf("%w: bad name of field %q", ErrInvalidCustomField, f.Key)
+	}
+
+	switch f.Type {
+	case FieldTypeText, FieldTypeNumber, FieldTypeDate, FieldTypeSelect, FieldTypeCheckbox, FieldTypeURL:
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidCustomField, f.Type)
+	}
+
+	if f.Type == FieldTypeSelect {
+		if len(f.Options) == 0 {
+			return fmt.Errorf("%w: select field %q needs options", ErrInvalidCustomField, f.Key)
+		}
+		seen := make(map[string]bool, len(f.Options))
+		for _, option := range f.Options {
+			if option == "" || len(option) > 100 || seen[strings.ToLower(option)] {
+				return fmt.Errorf("%w: bad or duplicate option %q", ErrInvalidCustomField, option)
+			}
+			seen[strings.ToLower(option)] = true
+		}
+	} else if len(f.Options) > 0 {
+		return fmt.Errorf("%w: options are only for select fields", ErrInvalidCustomField)
+	}
+
+	if f.Min != nil || f.Max != nil {
+		if f.Type != FieldTypeNumber && f.Type != FieldTypeText {
+			return fmt.Errorf("%w: min and max are only for number and text fields", ErrInvalidCustomField)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return fmt.Errorf("%w: min is greater than max", ErrInvalidCustomField)
+		}
+	}
+
+	if f.Pattern != "" {
+		if f.Type != FieldTypeText {
+			return fmt.Errorf("%w: pattern is only for text fields", ErrInvalidCustomField)
+		}
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return fmt.Errorf("%w: bad pattern: %v", ErrInvalidCustomField, err)
+		}
+	}
+
+	return nil
+}
+
+// AppliesTo - поле есть у задач проекта
+func (f CustomField) AppliesTo(project string) bool {
+	return f.Project == "" || f.Project == project
+}
+
+// Normalize проверяет значение и приводит его к хранимому виду.
+// Принимает и строки (CSV, формы), nil и пустая строка означают "не заполнено".
+func (f CustomField) Normalize(value any) (any, error) {
+	if s, ok := value.(string); ok {
+		value = strings.TrimSpace(s)
+		if value == "" {
+			return nil, nil
+		}
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidFieldValue, f.Key, reason)
+	}
+
+	switch f.Type {
+	case FieldTypeText:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid("expected text")
+		}
+		n := float64(utf8.RuneCountInString(s))
+		if n > maxFieldTextLength || (f.Min != nil && n < *f.Min) || (f.Max != nil && n > *f.Max) {
+			return nil, invalid("text length is out of range")
+		}
+		if f.Pattern != "" && !regexp.MustCompile(f.Pattern).MatchString(s) {
+			return nil, invalid("text does not match the pattern")
+		}
+		return s, nil
+
+	case FieldTypeURL:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid("expected url")
+		}
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, invalid("expected http or https url")
+		}
+		return s, nil
+
+	case FieldTypeSelect:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid("expected option")
+		}
+		for _, option := range f.Options {
+			if strings.EqualFold(option, s) {
+				return option, nil
+			}
+		}
+		return nil, invalid(fmt.Sprintf("unknown option %q", s))
+
+	case FieldTypeNumber:
+		n, err := toNumber(value)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, invalid("expected number")
+		}
+		if (f.Min != nil && n < *f.Min) || (f.Max != nil && n > *f.Max) {
+			return nil, invalid("number is out of range")
+		}
+		return n, nil
+
+	case FieldTypeCheckbox:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, invalid("expected true or false")
+			}
+			return b, nil
+		}
+		return nil, invalid("expected true or false")
+
+	case FieldTypeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(CustomFieldDateLayout), nil
+		case string:
+			if t, err := time.Parse(CustomFieldDateLayout, v); err == nil {
+				return t.Format(CustomFieldDateLayout), nil
+			}
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				return t.Format(CustomFieldDateLayout), nil
+			}
+		}
+		return nil, invalid("expected date " + CustomFieldDateLayout)
+	}
+
+	return nil, invalid("unknown type")
+}
+
+func toNumber(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("not a number: %T", value)
+}
+
+// Compare сравнивает два нормализованных значения поля: <0, 0, >0.
+// select сортируется в порядке вариантов, checkbox - false раньше true.
+func (f CustomField) Compare(a, b any) int {
+	switch f.Type {
+	case FieldTypeNumber:
+		x, _ := a.(float64)
+		y, _ := b.(float64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case FieldTypeCheckbox:
+		x, _ := a.(bool)
+		y, _ := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		}
+		return 1
+	case FieldTypeSelect:
+		x, _ := a.(string)
+		y, _ := b.(string)
+		return slices.Index(f.Options, x) - slices.Index(f.Options, y)
+	}
+
+	x, _ := a.(string)
+	y, _ := b.(string)
+	if f.Type == FieldTypeText {
+		x, y = strings.ToLower(x), strings.ToLower(y)
+	}
+	return strings.Compare(x, y)
+}
+
+// Matches проверяет значение задачи (nil - не заполнено) оператором FieldOp*.
+// operand нормализуется по типу поля; для set и unset не нужен.
+func (f CustomField) Matches(value any, op string, operand any) (bool, error) {
+	switch op {
+	case FieldOpSet:
+		return value != nil, nil
+	case FieldOpUnset:
+		return value == nil, nil
+	}
+
+	if op == FieldOpContains {
+		if f.Type != FieldTypeText && f.Type != FieldTypeURL {
+			return false, fmt.Errorf("%w: contains is only for text and url fields", ErrInvalidFilter)
+		}
+		s, _ := value.(string)
+		sub, _ := operand.(string)
+		return strings.Contains(strings.ToLower(s), strings.ToLower(strings.TrimSpace(sub))), nil
+	}
+
+	want, err := f.Normalize(operand)
+	if err != nil {
+		return false, err
+	}
+	if want == nil {
+		return false, fmt.Errorf("%w: empty value for %s", ErrInvalidFilter, op)
+	}
+
+	switch op {
+	case FieldOpEq:
+		return value != nil && f.Compare(value, want) == 0, nil
+	case FieldOpNe:
+		return value == nil || f.Compare(value, want) != 0, nil
+	}
+
+	// Незаполненное значение не больше и не меньше ничего
+	if value == nil {
+		switch op {
+		case FieldOpLt, FieldOpLte, FieldOpGt, FieldOpGte:
+			return false, nil
+		}
+	}
+	c := f.Compare(value, want)
+	switch op {
+	case FieldOpLt:
+		return c < 0, nil
+	case FieldOpLte:
+		return c <= 0, nil
+	case FieldOpGt:
+		return c > 0, nil
+	case FieldOpGte:
+		return c >= 0, nil
+	}
+	return false, fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, op)
+}
+
+// CustomFieldQuery - отбор задач вне архива по значениям полей на стороне БД
+type CustomFieldQuery struct {
+	Statuses []TaskStatus   // пусто - любые статусы
+	Contains map[string]any // поле равно значению
+	Excludes map[string]any // поле не равно значению или не заполнено
+	Present  []string       // поле заполнено
+	Absent   []string       // поле не заполнено
+}
+
+// Narrow добавляет в запрос условие фильтра, которое БД проверит по индексу.
+// Диапазоны, contains и равенство text (без учета регистра) остаются Matches,
+// поэтому выборку все равно нужно проверить им.
+func (q *CustomFieldQuery) Narrow(f CustomField, op string, operand any) error {
+	switch op {
+	case FieldOpSet:
+		q.Present = append(q.Present, f.Key)
+		return nil
+	case FieldOpUnset:
+		q.Absent = append(q.Absent, f.Key)
+		return nil
+	case FieldOpEq, FieldOpNe:
+		if f.Type == FieldTypeText {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	want, err := f.Normalize(operand)
+	if err != nil {
+		return err
+	}
+	if want == nil {
+		return fmt.Errorf("%w: empty value for %s", ErrInvalidFilter, op)
+	}
+	if op == FieldOpEq {
+		if q.Contains == nil {
+			q.Contains = map[string]any{}
+		}
+		q.Contains[f.Key] = want
+	} else {
+		if q.Excludes == nil {
+			q.Excludes = map[string]any{}
+		}
+		q.Excludes[f.Key] = want
+	}
+	return nil
+}
+
+// LookupCustomField ищет поле по ключу
+func LookupCustomField(fields []CustomField, key string) (CustomField, bool) {
+	for _, f := range fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return CustomField{}, false
+}
+
+// SetCustomValues проверяет и записывает значения полей; nil или пустая строка очищает поле.
+// Поле должно быть описано и относиться к проекту задачи.
+func (t *Task) SetCustomValues(fields []CustomField, values map[string]any) error {
+	next := maps.Clone(t.CustomFields)
+	if next == nil {
+		next = make(map[string]any, len(values))
+	}
+
+	for key, value := range values {
+		f, ok := LookupCustomField(fields, key)
+		if !ok {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidFieldValue, key)
+		}
+
+		normalized, err := f.Normalize(value)
+		if err != nil {
+			return err
+		}
+		if normalized == nil {
+			delete(next, key)
+			continue
+		}
+		if !f.AppliesTo(t.Project) {
+			return fmt.Errorf("%w: field %q is not used in project %q", ErrInvalidFieldValue, key, t.Project)
+		}
+		next[key] = normalized
+	}
+
+	t.CustomFields = next
+	return nil
+}
+
+// CheckRequiredFields - заполнены все обязательные поля проекта задачи
+func (t *Task) CheckRequiredFields(fields []CustomField) error {
+	for _, f := range fields {
+		if f.Required && f.AppliesTo(t.Project) && t.CustomFields[f.Key] == nil {
+			return fmt.Errorf("%w: %s is required", ErrInvalidFieldValue, f.Key)
+		}
+	}
+	return nil
+}
+
+// NormalizeCustomValues приводит к хранимому виду значения описанных полей, например
+// после импорта. Значения полей без описания (пришли синхронизацией) не трогаются.
+func (t *Task) NormalizeCustomValues(fields []CustomField) error {
+	for key, value := range t.CustomFields {
+		f, ok := LookupCustomField(fields, key)
+		if !ok {
+			continue
+		}
+		normalized, err := f.Normalize(value)
+		if err != nil {
+			return err
+		}
+		if normalized == nil {
+			delete(t.CustomFields, key)
+		} else {
+			t.CustomFields[key] = normalized
+		}
+	}
+	return nil
+}
+
+func customValuesEqual(a, b map[string]any) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// CustomFieldRepository - описания полей; значения хранятся в самих задачах
+type CustomFieldRepository interface {
+	List(ctx context.Context) ([]CustomField, error)
+	Get(ctx context.Context, key string) (CustomField, error)
+	Save(ctx context.Context, f CustomField) error
+	Delete(ctx context.Context, key string) error
+	// TasksWithValue - задачи, включая архив, у которых заполнено поле
+	TasksWithValue(ctx context.Context, key string) ([]*Task, error)
+	// FindTasks - задачи вне архива, подходящие под запрос, новые первыми
+	FindTasks(ctx context.Context, q CustomFieldQuery) ([]*Task, error)
+}
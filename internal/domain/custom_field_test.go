@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCustomFieldNormalize(t *testing.T) {
+	one, ten := 1.0, 10.0
+	tests := []struct {
+		field CustomField
+		value any
+		want  any
+		ok    bool
+	}{
+		{CustomField{Key: "n", Type: FieldTypeNumber, Min: &one, Max: &ten}, "5", 5.0, true},
+		{CustomField{Key: "n", Type: FieldTypeNumber, Min: &one, Max: &ten}, 11, nil, false},
+		{CustomField{Key: "n", Type: FieldTypeNumber}, "abc", nil, false},
+		{CustomField{Key: "s", Type: FieldTypeSelect, Options: []string{"Low", "High"}}, "high", "High", true},
+		{CustomField{Key: "s", Type: FieldTypeSelect, Options: []string{"Low", "High"}}, "mid", nil, false},
+		{CustomField{Key: "c", Type: FieldTypeCheckbox}, "true", true, true},
+		{CustomField{Key: "d", Type: FieldTypeDate}, "2026-03-01T10:00:00Z", "2026-03-01", true},
+		{CustomField{Key: "u", Type: FieldTypeURL}, "ftp://example.com", nil, false},
+		{CustomField{Key: "t", Type: FieldTypeText, Pattern: `^[A-Z]+-\d+$`}, "ABC-12", "ABC-12", true},
+		{CustomField{Key: "t", Type: FieldTypeText, Pattern: `^[A-Z]+-\d+$`}, "abc", nil, false},
+		{CustomField{Key: "t", Type: FieldTypeText}, "  ", nil, true}, // пусто - не заполнено
+	}
+	for _, tt := range tests {
+		got, err := tt.field.Normalize(tt.value)
+		if tt.ok != (err == nil) {
+			t.Errorf("%s.Normalize(%v): err = %v, want ok = %v", tt.field.Type, tt.value, err, tt.ok)
+			continue
+		}
+		if err != nil && !errors.Is(err, ErrInvalidFieldValue) {
+			t.Errorf("%s.Normalize(%v): err = %v, want ErrInvalidFieldValue", tt.field.Type, tt.value, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s.Normalize(%v) = %v, want %v", tt.field.Type, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTaskCustomValuesAndRequiredFields(t *testing.T) {
+	fields := []CustomField{
+		{Key: "sprint", Project: "work", Type: FieldTypeNumber, Required: true},
+		{Key: "link", Type: FieldTypeURL},
+	}
+
+	task := &Task{Project: "home"}
+	if err := task.CheckRequiredFields(fields); err != nil {
+		t.Fatalf("field of another project is required: %v", err)
+	}
+	if err := task.SetCustomValues(fields, map[string]any{"sprint": 3}); !errors.Is(err, ErrInvalidFieldValue) {
+		t.Fatalf("value of a field from another project: err = %v", err)
+	}
+	if err := task.SetCustomValues(fields, map[string]any{"missing": 1}); !errors.Is(err, ErrInvalidFieldValue) {
+		t.Fatalf("unknown field: err = %v", err)
+	}
+
+	task.Project = "work"
+	if err := task.CheckRequiredFields(fields); !errors.Is(err, ErrInvalidFieldValue) {
+		t.Fatalf("missing required field: err = %v", err)
+	}
+	if err := task.SetCustomValues(fields, map[string]any{"sprint": "3", "link": "https://example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := task.CheckRequiredFields(fields); err != nil {
+		t.Fatalf("required field is set: %v", err)
+	}
+	if task.CustomFields["sprint"] != 3.0 {
+		t.Fatalf("sprint = %#v, want 3.0", task.CustomFields["sprint"])
+	}
+
+	if err := task.SetCustomValues(fields, map[string]any{"link": nil}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := task.CustomFields["link"]; ok {
+		t.Fatal("nil did not clear the field")
+	}
+}
+
+func TestCustomFieldMatches(t *testing.T) {
+	priority := CustomField{Key: "p", Type: FieldTypeSelect, Options: []string{"Low", "Mid", "High"}}
+	tests := []struct {
+		value   any
+		op      string
+		operand any
+		want    bool
+	}{
+		{"Mid", FieldOpGt, "low", true},
+		{"Mid", FieldOpGte, "High", false},
+		{nil, FieldOpLt, "High", false}, // незаполненное не меньше и не больше
+		{nil, FieldOpNe, "High", true},
+		{"High", FieldOpEq, "HIGH", true},
+		{nil, FieldOpUnset, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := priority.Matches(tt.value, tt.op, tt.operand)
+		if err != nil || got != tt.want {
+			t.Errorf("Matches(%v %s %v) = %v, %v; want %v", tt.value, tt.op, tt.operand, got, err, tt.want)
+		}
+	}
+
+	if _, err := priority.Matches("Mid", FieldOpContains, "i"); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("contains on select: err = %v, want ErrInvalidFilter", err)
+	}
+}
+
+func TestCustomFieldQueryNarrow(t *testing.T) {
+	number := CustomField{Key: "n", Type: FieldTypeNumber}
+	text := CustomField{Key: "t", Type: FieldTypeText}
+
+	var q CustomFieldQuery
+	for _, c := range []struct {
+		f       CustomField
+		op      string
+		operand any
+	}{
+		{number, FieldOpEq, "2"},
+		{number, FieldOpNe, 5},
+		{number, FieldOpGt, 1}, // диапазон остается Matches
+		{text, FieldOpEq, "Abc"},
+		{text, FieldOpSet, nil},
+		{number, FieldOpUnset, nil},
+	} {
+		if err := q.Narrow(c.f, c.op, c.operand); err != nil {
+			t.Fatalf("Narrow(%s %s): %v", c.f.Key, c.op, err)
+		}
+	}
+
+	if len(q.Contains) != 1 || q.Contains["n"] != 2.0 {
+		t.Errorf("Contains = %v, want only n = 2", q.Contains)
+	}
+	if len(q.Excludes) != 1 || q.Excludes["n"] != 5.0 {
+		t.Errorf("Excludes = %v, want only n = 5", q.Excludes)
+	}
+	if !slices.Equal(q.Present, []string{"t"}) || !slices.Equal(q.Absent, []string{"n"}) {
+		t.Errorf("Present = %v, Absent = %v", q.Present, q.Absent)
+	}
+
+	if err := q.Narrow(number, FieldOpEq, "abc"); !errors.Is(err, ErrInvalidFieldValue) {
+		t.Errorf("bad operand: err = %v, want ErrInvalidFieldValue", err)
+	}
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+var (
+	ErrInvalidDependency  = errors.New("invalid dependency")
+	ErrDependencyCycle    = errors.New("dependency cycle")
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrTaskBlocked        = errors.New("task is blocked")
+)
+
+// Dependency - TaskID нельзя начать, пока не завершена BlockedByID
+type Dependency struct {
+	TaskID      string
+	BlockedByID string
+}
+
+// DependencyGraph - граф blocked_by; ребра идут от задачи к ее блокерам
+type DependencyGraph struct {
+	blockers map[string][]string
+}
+
+func NewDependencyGraph(deps []Dependency) *DependencyGraph {
+	g := &DependencyGraph{blockers: make(map[string][]string)}
+	for _, d := range deps {
+		g.blockers[d.TaskID] = append(g.blockers[d.TaskID], d.BlockedByID)
+	}
+	return g
+}
+
+func (g *DependencyGraph) Blockers(taskID string) []string {
+	return g.blockers[taskID]
+}
+
+// WouldCycle сообщает, замкнет ли ребро taskID -> blockedByID цикл:
+// это так, если blockedByID уже (транзитивно) ждет taskID
+func (g *DependencyGraph) WouldCycle(taskID, blockedByID string) bool {
+	if taskID == blockedByID {
+		return true
+	}
+
+	seen := map[string]bool{}
+	stack := []string{blockedByID}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == taskID {
+			return true
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		stack = append(stack, g.blockers[id]...)
+	}
+	return false
+}
+
+// TopologicalOrder упорядочивает ids так, что блокеры идут раньше зависящих от них задач.
+// Ребра к задачам вне ids не учитываются; при равенстве порядок - по ID.
+// Если граф все же содержит цикл, задачи цикла не попадут в результат.
+func (g *DependencyGraph) TopologicalOrder(ids []string) []string {
+	in := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		in[id] = true
+	}
+
+	pending := make(map[string]int, len(ids)) // число неупорядоченных блокеров
+	dependents := make(map[string][]string, len(ids))
+	for _, id := range ids {
+		for _, b := range g.blockers[id] {
+			if in[b] {
+				pending[id]++
+				dependents[b] = append(dependents[b], id)
+			}
+		}
+	}
+
+	var ready []string
+	for _, id := range ids {
+		if pending[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+	sort.Strings(ready)
+
+	order := make([]string, 0, len(ids))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		var next []string
+		for _, dep := range dependents[id] {
+			pending[dep]--
+			if pending[dep] == 0 {
+				next = append(next, dep)
+			}
+		}
+		if len(next) > 0 {
+			ready = append(ready, next...)
+			sort.Strings(ready)
+		}
+	}
+	return order
+}
+
+// OpenBlockers - незавершенные блокеры задачи
+func (g *DependencyGraph) OpenBlockers(taskID string, tasks map[string]*Task, w Workflow) []*Task {
+	var open []*Task
+	for _, id := range g.blockers[taskID] {
+		if t, ok := tasks[id]; ok && !t.IsClosed(w) {
+			open = append(open, t)
+		}
+	}
+	return open
+}
+
+// DependencyRepository работает в транзакции репозитория задач
+type DependencyRepository interface {
+	// Lock блокирует граф до конца транзакции, чтобы проверка на цикл и вставка были атомарны
+	Lock(ctx context.Context) error
+	Add(ctx context.Context, dep Dependency) error
+	// Remove возвращает ErrDependencyNotFound, если ребра нет
+	Remove(ctx context.Context, dep Dependency) error
+	GetAll(ctx context.Context) ([]Dependency, error)
+	GetBlockers(ctx context.Context, taskID string) ([]string, error)
+}
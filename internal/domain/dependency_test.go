@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDependencyGraphWouldCycle(t *testing.T) {
+	// c ждет b, b ждет a
+	g := NewDependencyGraph([]Dependency{
+		{TaskID: "b", BlockedByID: "a"},
+		{TaskID: "c", BlockedByID: "b"},
+	})
+
+	tests := []struct {
+		task, blockedBy string
+		want            bool
+	}{
+		{"a", "a", true},  // сама на себя
+		{"a", "b", true},  // прямой цикл
+		{"a", "c", true},  // транзитивный цикл
+		{"c", "a", false}, // лишнее, но допустимое ребро
+		{"d", "c", false},
+		{"a", "d", false},
+	}
+	for _, tt := range tests {
+		if got := g.WouldCycle(tt.task, tt.blockedBy); got != tt.want {
+			t.Errorf("WouldCycle(%s, %s) = %v, want %v", tt.task, tt.blockedBy, got, tt.want)
+		}
+	}
+}
+
+func TestDependencyGraphTopologicalOrder(t *testing.T) {
+	g := NewDependencyGraph([]Dependency{
+		{TaskID: "c", BlockedByID: "a"},
+		{TaskID: "c", BlockedByID: "b"},
+		{TaskID: "b", BlockedByID: "a"},
+		{TaskID: "d", BlockedByID: "x"}, // блокер вне набора не учитывается
+	})
+
+	got := g.TopologicalOrder([]string{"d", "c", "b", "a"})
+	want := []string{"a", "b", "c", "d"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("TopologicalOrder = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyGraphTopologicalOrderDropsCycle(t *testing.T) {
+	g := NewDependencyGraph([]Dependency{
+		{TaskID: "a", BlockedByID: "b"},
+		{TaskID: "b", BlockedByID: "a"},
+	})
+
+	got := g.TopologicalOrder([]string{"a", "b", "c"})
+	if !slices.Equal(got, []string{"c"}) {
+		t.Fatalf("TopologicalOrder = %v, want [c]", got)
+	}
+}
+
+func TestDependencyGraphOpenBlockers(t *testing.T) {
+	w := DefaultWorkflow()
+	g := NewDependencyGraph([]Dependency{
+		{TaskID: "t", BlockedByID: "open"},
+		{TaskID: "t", BlockedByID: "done"},
+		{TaskID: "t", BlockedByID: "missing"},
+	})
+	tasks := map[string]*Task{
+		"open": {ID: "open", Status: w.InitialStatus()},
+		"done": {ID: "done", Status: w.DoneStatus()},
+	}
+
+	open := g.OpenBlockers("t", tasks, w)
+	if len(open) != 1 || open[0].ID != "open" {
+		t.Fatalf("OpenBlockers = %v, want only open", open)
+	}
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+// Имена доменных событий
+const (
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskCompleted = "task.completed"
+	EventTaskReopened  = "task.reopened"
+	EventTaskDeleted   = "task.deleted"
+	EventTaskOverdue   = "task.overdue"
+	EventTaskWoke      = "task.woke"
+)
+
+// Event - доменное событие, публикуется use case'ами после коммита транзакции
+type Event interface {
+	EventName() string
+	TaskID() string
+	OccurredAt() time.Time
+}
+
+// EventPublisher - шина событий со стороны use case'ов
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...Event)
+}
+
+type TaskCreated struct {
+	Task Task
+	At   time.Time
+}
+
+func (e TaskCreated) EventName() string     { return EventTaskCreated }
+func (e TaskCreated) TaskID() string        { return e.Task.ID }
+func (e TaskCreated) OccurredAt() time.Time { return e.At }
+
+type TaskUpdated struct {
+	Task Task
+	At   time.Time
+}
+
+func (e TaskUpdated) EventName() string     { return EventTaskUpdated }
+func (e TaskUpdated) TaskID() string        { return e.Task.ID }
+func (e TaskUpdated) OccurredAt() time.Time { return e.At }
+
+type TaskCompleted struct {
+	Task Task
+	At   time.Time
+}
+
+func (e TaskCompleted) EventName() string     { return EventTaskCompleted }
+func (e TaskCompleted) TaskID() string        { return e.Task.ID }
+func (e TaskCompleted) OccurredAt() time.Time { return e.At }
+
+type TaskReopened struct {
+	Task Task
+	At   time.Time
+}
+
+func (e TaskReopened) EventName() string     { return EventTaskReopened }
+func (e TaskReopened) TaskID() string        { return e.Task.ID }
+func (e TaskReopened) OccurredAt() time.Time { return e.At }
+
+// TaskDeleted публикуется только для удаленной задачи, подзадачи удаляются каскадно вместе с ней
+type TaskDeleted struct {
+	ID string
+	At time.Time
+}
+
+func (e TaskDeleted) EventName() string     { return EventTaskDeleted }
+func (e TaskDeleted) TaskID() string        { return e.ID }
+func (e TaskDeleted) OccurredAt() time.Time { return e.At }
+
+// TaskOverdue публикуется один раз на каждый пропущенный срок задачи
+type TaskOverdue struct {
+	Task Task
+	At   time.Time
+}
+
+func (e TaskOverdue) EventName() string     { return EventTaskOverdue }
+func (e TaskOverdue) TaskID() string        { return e.Task.ID }
+func (e TaskOverdue) OccurredAt() time.Time { return e.At }
+
+// IdempotencyKey детерминирован: повторная проверка того же срока не создаст второе событие
+func (e TaskOverdue) IdempotencyKey() string {
+	return "overdue_" + e.Task.ID + "_" + e.Task.DueDate.UTC().Format("20060102T150405")
+}
+
+// TaskWoke публикуется, когда у отложенной задачи наступила дата начала
+type TaskWoke struct {
+	Task Task
+	At   time.Time
+}
+
+func (e TaskWoke) EventName() string     { return EventTaskWoke }
+func (e TaskWoke) TaskID() string        { return e.Task.ID }
+func (e TaskWoke) OccurredAt() time.Time { return e.At }
+
+// IdempotencyKey - по одному событию на каждую дату начала задачи
+func (e TaskWoke) IdempotencyKey() string {
+	return "woke_" + e.Task.ID + "_" + e.Task.StartDate.UTC().Format("20060102T150405")
+}
+
+// Snapshot - копия задачи для события, чтобы асинхронные обработчики не видели последующих изменений
+func (t *Task) Snapshot() Task {
+	s := *t
+	s.Tags = append([]string(nil), t.Tags...)
+	if t.DueDate != nil {
+		due := *t.DueDate
+		s.DueDate = &due
+	}
+	if t.ParentID != nil {
+		parentID := *t.ParentID
+		s.ParentID = &parentID
+	}
+	if t.CompletedAt != nil {
+		completedAt := *t.CompletedAt
+		s.CompletedAt = &completedAt
+	}
+	if t.ArchivedAt != nil {
+		archivedAt := *t.ArchivedAt
+		s.ArchivedAt = &archivedAt
+	}
+	if t.StartDate != nil {
+		startDate := *t.StartDate
+		s.StartDate = &startDate
+	}
+	s.CustomFields = maps.Clone(t.CustomFields)
+	return s
+}
+
+// StatusEvents возвращает события смены статуса между двумя версиями задачи
+func StatusEvents(w Workflow, before TaskStatus, task *Task, at time.Time) []Event {
+	switch {
+	case !w.IsClosed(before) && task.IsClosed(w):
+		return []Event{TaskCompleted{Task: task.Snapshot(), At: at}}
+	case w.IsClosed(before) && !task.IsClosed(w):
+		return []Event{TaskReopened{Task: task.Snapshot(), At: at}}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidHLC = errors.New("invalid hybrid logical clock")
+
+// HLC - метка гибридных логических часов: физическое время в миллисекундах,
+// логический счетчик для событий в одну миллисекунду и узел для разрешения ничьих
+type HLC struct {
+	Wall    int64
+	Logical uint32
+	Node    string
+}
+
+func (h HLC) IsZero() bool {
+	return h.Wall == 0 && h.Logical == 0 && h.Node == ""
+}
+
+// Compare возвращает -1, 0 или 1; при равенстве времени сравниваются узлы
+func (h HLC) Compare(other HLC) int {
+	switch {
+	case h.Wall != other.Wall:
+		return cmpInt(h.Wall, other.Wall)
+	case h.Logical != other.Logical:
+		return cmpInt(int64(h.Logical), int64(other.Logical))
+	}
+	return strings.Compare(h.Node, other.Node)
+}
+
+func (h HLC) After(other HLC) bool {
+	return h.Compare(other) > 0
+}
+
+// String - "wall.logical.node"
+func (h HLC) String() string {
+	return strconv.FormatInt(h.Wall, 10) + "." + strconv.FormatUint(uint64(h.Logical), 10) + "." + h.Node
+}
+
+func ParseHLC(s string) (HLC, error) {
+	parts := strings.SplitN(s, ".", 3)
+	if len(parts) != 3 {
+		return HLC{}, fmt.Errorf("%w: %q", ErrInvalidHLC, s)
+	}
+
+	wall, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return HLC{}, fmt.Errorf("%w: %q", ErrInvalidHLC, s)
+	}
+	logical, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return HLC{}, fmt.Errorf("%w: %q", ErrInvalidHLC, s)
+	}
+
+	return HLC{Wall: wall, Logical: uint32(logical), Node: parts[2]}, nil
+}
+
+func (h HLC) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *HLC) UnmarshalText(data []byte) error {
+	parsed, err := ParseHLC(string(data))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+	return nil
+}
+
+func cmpInt(a, b int64) int {
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+// Clock выдает монотонные HLC-метки узла
+type Clock struct {
+	mu   sync.Mutex
+	node string
+	last HLC
+	now  func() time.Time
+}
+
+func NewClock(node string) *Clock {
+	return &Clock{node: node, now: time.Now}
+}
+
+func (c *Clock) Node() string {
+	return c.node
+}
+
+// Now - метка для локального изменения
+func (c *Clock) Now() HLC {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wall := c.now().UnixMilli()
+	if wall > c.last.Wall {
+		c.last = HLC{Wall: wall, Node: c.node}
+	} else {
+		c.last = HLC{Wall: c.last.Wall, Logical: c.last.Logical + 1, Node: c.node}
+	}
+	return c.last
+}
+
+// Observe продвигает часы после получения удаленной метки,
+// чтобы следующие локальные изменения были упорядочены после нее
+func (c *Clock) Observe(remote HLC) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if remote.Wall > c.last.Wall || (remote.Wall == c.last.Wall && remote.Logical > c.last.Logical) {
+		c.last = HLC{Wall: remote.Wall, Logical: remote.Logical, Node: c.node}
+	}
+}
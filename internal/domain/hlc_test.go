@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHLCCompare(t *testing.T) {
+	tests := []struct {
+		a, b HLC
+		want int
+	}{
+		{HLC{Wall: 1}, HLC{Wall: 2}, -1},
+		{HLC{Wall: 2, Logical: 0}, HLC{Wall: 1, Logical: 9}, 1},
+		{HLC{Wall: 1, Logical: 2}, HLC{Wall: 1, Logical: 1}, 1},
+		{HLC{Wall: 1, Node: "a"}, HLC{Wall: 1, Node: "b"}, -1}, // ничья решается узлом
+		{HLC{Wall: 1, Node: "a"}, HLC{Wall: 1, Node: "a"}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestHLCParseRoundTrip(t *testing.T) {
+	h := HLC{Wall: 1767225600000, Logical: 3, Node: "node.with.dots"}
+	parsed, err := ParseHLC(h.String())
+	if err != nil || parsed != h {
+		t.Fatalf("ParseHLC(%q) = %v, %v; want %v", h.String(), parsed, err, h)
+	}
+
+	for _, bad := range []string{"", "1.2", "x.1.n", "1.-1.n"} {
+		if _, err := ParseHLC(bad); !errors.Is(err, ErrInvalidHLC) {
+			t.Errorf("ParseHLC(%q): err = %v, want ErrInvalidHLC", bad, err)
+		}
+	}
+}
+
+func TestClockMonotonic(t *testing.T) {
+	now := time.UnixMilli(1000)
+	c := NewClock("a")
+	c.now = func() time.Time { return now }
+
+	first := c.Now()
+	second := c.Now() // та же миллисекунда
+	if !second.After(first) || second.Logical != 1 {
+		t.Fatalf("same millisecond: %s then %s", first, second)
+	}
+
+	now = time.UnixMilli(900) // часы ушли назад
+	if third := c.Now(); !third.After(second) {
+		t.Fatalf("clock went backwards: %s after %s", third, second)
+	}
+
+	// Удаленная метка из будущего продвигает локальные часы
+	c.Observe(HLC{Wall: 5000, Logical: 7, Node: "b"})
+	if next := c.Now(); next.Wall != 5000 || next.Logical != 8 || next.Node != "a" {
+		t.Fatalf("after observe: %s, want 5000.8.a", next)
+	}
+}
+
+func TestMergeRecordsPerField(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(wall int64, node string) HLC { return HLC{Wall: wall, Node: node} }
+
+	local := SyncRecord{
+		Task: Task{ID: "t", Title: "Local title", Status: StatusActive, Priority: PriorityLow, CreatedAt: created},
+		Clocks: FieldClocks{
+			SyncFieldTitle:    at(20, "a"),
+			SyncFieldStatus:   at(10, "a"),
+			SyncFieldPriority: at(10, "a"),
+		},
+	}
+	remote := SyncRecord{
+		Task: Task{ID: "t", Title: "Remote title", Status: StatusCompleted, Priority: PriorityLow, CreatedAt: created.Add(-time.Hour)},
+		Clocks: FieldClocks{
+			SyncFieldTitle:    at(15, "b"), // старее локальной правки
+			SyncFieldStatus:   at(30, "b"),
+			SyncFieldPriority: at(30, "b"), // новее, но значение то же
+		},
+	}
+
+	merged, changed := MergeRecords(local, remote)
+	if !changed {
+		t.Fatal("changed = false")
+	}
+	if merged.Task.Title != "Local title" || merged.Task.Status != StatusCompleted {
+		t.Fatalf("merged title %q, status %q", merged.Task.Title, merged.Task.Status)
+	}
+	if merged.Clocks[SyncFieldTitle] != at(20, "a") || merged.Clocks[SyncFieldPriority] != at(30, "b") {
+		t.Fatalf("merged clocks = %v", merged.Clocks)
+	}
+	if !merged.Task.CreatedAt.Equal(created.Add(-time.Hour)) {
+		t.Fatalf("created_at = %v, want the earlier one", merged.Task.CreatedAt)
+	}
+
+	// Слияние в обратную сторону дает те же значения
+	back, _ := MergeRecords(remote, local)
+	if back.Task.Title != merged.Task.Title || back.Task.Status != merged.Task.Status || back.Clocks.Max() != merged.Clocks.Max() {
+		t.Fatalf("merge is not symmetric: %+v vs %+v", back.Task, merged.Task)
+	}
+}
+
+func TestStampChanges(t *testing.T) {
+	before := &Task{Title: "a", Priority: PriorityLow}
+	after := &Task{Title: "b", Priority: PriorityLow}
+	old := HLC{Wall: 1, Node: "n"}
+	now := HLC{Wall: 2, Node: "n"}
+
+	clocks := StampChanges(before, after, FieldClocks{SyncFieldPriority: old}, now)
+	if clocks[SyncFieldTitle] != now || clocks[SyncFieldPriority] != old {
+		t.Fatalf("clocks = %v", clocks)
+	}
+
+	created := StampChanges(nil, after, nil, now)
+	if len(created) != len(SyncFields) {
+		t.Fatalf("new task stamped %d fields, want %d", len(created), len(SyncFields))
+	}
+}
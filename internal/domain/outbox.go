@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxDelivered OutboxStatus = "delivered"
+	OutboxDead      OutboxStatus = "dead" // попытки исчерпаны, ждет ручного повтора
+)
+
+var (
+	ErrOutboxEntryNotFound = errors.New("outbox entry not found")
+	ErrDuplicateEvent      = errors.New("event already recorded")
+)
+
+// OutboxEntry - событие, записанное в той же транзакции, что и изменение задачи
+type OutboxEntry struct {
+	ID             int64
+	IdempotencyKey string // получатель по нему отбрасывает повторные доставки
+	EventType      string
+	TaskID         string
+	Payload        []byte // JSON сообщения
+	Status         OutboxStatus
+	Attempts       int
+	LastError      string
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+}
+
+type OutboxRepository interface {
+	// Add возвращает ErrDuplicateEvent, если событие с таким ключом уже записано
+	Add(ctx context.Context, entry *OutboxEntry) error
+	// Claim резервирует готовые к отправке записи до leaseUntil
+	Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*OutboxEntry, error)
+	MarkDelivered(ctx context.Context, id int64, at time.Time) error
+	MarkFailed(ctx context.Context, id int64, status OutboxStatus, lastError string, nextAttemptAt time.Time) error
+	ListByStatus(ctx context.Context, status OutboxStatus, limit int) ([]*OutboxEntry, error)
+	Requeue(ctx context.Context, id int64, at time.Time) error
+	DeleteDelivered(ctx context.Context, before time.Time) (int64, error)
+	// DeleteStale удаляет недоставленные (pending и dead) записи, созданные до before
+	DeleteStale(ctx context.Context, before time.Time) (int64, error)
+}
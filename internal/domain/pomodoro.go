@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type PomodoroPhase string
+
+const (
+	PhaseIdle       PomodoroPhase = "idle"
+	PhaseWork       PomodoroPhase = "work"
+	PhaseShortBreak PomodoroPhase = "short_break"
+	PhaseLongBreak  PomodoroPhase = "long_break"
+)
+
+var (
+	ErrPomodoroNotRunning   = errors.New("pomodoro is not running")
+	ErrInvalidPomodoroSetup = errors.New("invalid pomodoro settings")
+)
+
+// PomodoroSettings - длительности фаз; длинный перерыв после каждых LongBreakEvery рабочих сессий
+type PomodoroSettings struct {
+	Work           time.Duration
+	ShortBreak     time.Duration
+	LongBreak      time.Duration
+	LongBreakEvery int
+}
+
+func (s PomodoroSettings) IsValid() error {
+	if s.Work <= 0 || s.ShortBreak <= 0 || s.LongBreak <= 0 || s.LongBreakEvery <= 0 {
+		return ErrInvalidPomodoroSetup
+	}
+	return nil
+}
+
+func (s PomodoroSettings) length(phase PomodoroPhase) time.Duration {
+	switch phase {
+	case PhaseWork:
+		return s.Work
+	case PhaseShortBreak:
+		return s.ShortBreak
+	case PhaseLongBreak:
+		return s.LongBreak
+	}
+	return 0
+}
+
+// Pomodoro - текущий цикл, привязанный к задаче
+type Pomodoro struct {
+	TaskID    string
+	Phase     PomodoroPhase
+	Cycle     int // завершенных рабочих сессий в текущем цикле
+	StartedAt time.Time
+	EndsAt    time.Time
+}
+
+func StartPomodoro(taskID string, settings PomodoroSettings, at time.Time) *Pomodoro {
+	return &Pomodoro{
+		TaskID:    taskID,
+		Phase:     PhaseWork,
+		StartedAt: at,
+		EndsAt:    at.Add(settings.Work),
+	}
+}
+
+// Advance переводит цикл в следующую фазу: работа -> перерыв -> работа.
+// Возвращает рабочую сессию, если закончилась фаза работы.
+func (p *Pomodoro) Advance(settings PomodoroSettings, at time.Time) *FocusSession {
+	var session *FocusSession
+
+	next := PhaseWork
+	if p.Phase == PhaseWork {
+		session = p.focusSession(settings, at)
+		p.Cycle++
+		next = PhaseShortBreak
+		if p.Cycle%settings.LongBreakEvery == 0 {
+			next = PhaseLongBreak
+		}
+	}
+
+	p.Phase = next
+	p.StartedAt = at
+	p.EndsAt = at.Add(settings.length(next))
+	return session
+}
+
+// Stop завершает цикл; прерванная рабочая фаза тоже попадает в журнал
+func (p *Pomodoro) Stop(settings PomodoroSettings, at time.Time) *FocusSession {
+	if p.Phase != PhaseWork {
+		return nil
+	}
+	return p.focusSession(settings, at)
+}
+
+func (p *Pomodoro) focusSession(settings PomodoroSettings, at time.Time) *FocusSession {
+	if at.After(p.EndsAt) {
+		at = p.EndsAt
+	}
+	return &FocusSession{
+		ID:        "focus_" + p.StartedAt.Format("20060102150405") + "_" + randomHex(4),
+		TaskID:    p.TaskID,
+		StartedAt: p.StartedAt,
+		EndedAt:   at,
+		Planned:   settings.Work,
+		Completed: !at.Before(p.EndsAt),
+	}
+}
+
+// FocusSession - рабочая фаза помидора в журнале
+type FocusSession struct {
+	ID        string
+	TaskID    string
+	StartedAt time.Time
+	EndedAt   time.Time
+	Planned   time.Duration
+	Completed bool // false - фаза прервана раньше времени
+}
+
+func (s *FocusSession) Duration() time.Duration {
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// FocusSessionRepository работает в транзакции репозитория задач
+type FocusSessionRepository interface {
+	// Add не трогает уже записанную сессию с тем же ID
+	Add(ctx context.Context, session *FocusSession) error
+	// ListBetween - сессии, начатые в [from, to)
+	ListBetween(ctx context.Context, from, to time.Time) ([]*FocusSession, error)
+	// GetAll - все сессии по времени начала, для бэкапа
+	GetAll(ctx context.Context) ([]*FocusSession, error)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPomodoroPhaseTransitions(t *testing.T) {
+	settings := PomodoroSettings{Work: 25 * time.Minute, ShortBreak: 5 * time.Minute, LongBreak: 15 * time.Minute, LongBreakEvery: 3}
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	p := StartPomodoro("t1", settings, at)
+
+	want := []struct {
+		phase   PomodoroPhase
+		cycle   int
+		session bool
+	}{
+		{PhaseShortBreak, 1, true},
+		{PhaseWork, 1, false},
+		{PhaseShortBreak, 2, true},
+		{PhaseWork, 2, false},
+		{PhaseLongBreak, 3, true}, // третья рабочая сессия - длинный перерыв
+		{PhaseWork, 3, false},
+		{PhaseShortBreak, 4, true},
+	}
+	for i, w := range want {
+		at = p.EndsAt
+		session := p.Advance(settings, at)
+		if p.Phase != w.phase || p.Cycle != w.cycle || (session != nil) != w.session {
+			t.Fatalf("step %d: phase %s, cycle %d, session %v; want %+v", i, p.Phase, p.Cycle, session != nil, w)
+		}
+		if !p.StartedAt.Equal(at) || !p.EndsAt.Equal(at.Add(settings.length(w.phase))) {
+			t.Fatalf("step %d: %s from %v to %v", i, p.Phase, p.StartedAt, p.EndsAt)
+		}
+		if session != nil && (!session.Completed || session.Duration() != settings.Work || session.TaskID != "t1") {
+			t.Fatalf("step %d: session %+v, want completed full work phase", i, session)
+		}
+	}
+}
+
+func TestPomodoroInterruptedWork(t *testing.T) {
+	settings := PomodoroSettings{Work: 25 * time.Minute, ShortBreak: 5 * time.Minute, LongBreak: 15 * time.Minute, LongBreakEvery: 4}
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	// Skip посреди работы: сессия есть, но не засчитана как полный помидор
+	p := StartPomodoro("t1", settings, at)
+	session := p.Advance(settings, at.Add(10*time.Minute))
+	if session == nil || session.Completed || session.Duration() != 10*time.Minute {
+		t.Fatalf("skipped work = %+v", session)
+	}
+	if p.Phase != PhaseShortBreak || p.Cycle != 1 {
+		t.Fatalf("after skip: %s, cycle %d", p.Phase, p.Cycle)
+	}
+
+	// Перерыв в журнал не пишется
+	if session := p.Stop(settings, p.StartedAt.Add(time.Minute)); session != nil {
+		t.Fatalf("stop during break = %+v", session)
+	}
+
+	// Опоздавшая остановка обрезается по концу фазы
+	p = StartPomodoro("t1", settings, at)
+	session = p.Stop(settings, at.Add(time.Hour))
+	if session == nil || !session.Completed || !session.EndedAt.Equal(at.Add(settings.Work)) {
+		t.Fatalf("late stop = %+v", session)
+	}
+}
+
+func TestPomodoroSettingsValidation(t *testing.T) {
+	valid := PomodoroSettings{Work: time.Minute, ShortBreak: time.Minute, LongBreak: time.Minute, LongBreakEvery: 1}
+	if err := valid.IsValid(); err != nil {
+		t.Fatalf("valid settings: %v", err)
+	}
+	broken := valid
+	broken.LongBreakEvery = 0
+	if err := broken.IsValid(); err != ErrInvalidPomodoroSetup {
+		t.Fatalf("long break every 0: err = %v", err)
+	}
+}
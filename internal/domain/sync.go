@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	ErrSyncNotConfigured = errors.New("sync server is not configured")
+	ErrSyncUnauthorized  = errors.New("sync unauthorized")
+)
+
+// Поля задачи, которые сливаются независимо (per-field last-writer-wins)
+const (
+	SyncFieldTitle    = "title"
+	SyncFieldStatus   = "status"
+	SyncFieldPriority = "priority"
+	SyncFieldDueDate  = "due_date"
+	SyncFieldProject  = "project"
+	SyncFieldParent   = "parent_id"
+	SyncFieldTags     = "tags"
+	SyncFieldEstimate = "estimate"
+	SyncFieldStart    = "start_date"
+	SyncFieldCustom   = "custom_fields"
+)
+
+var SyncFields = []string{
+	SyncFieldTitle, SyncFieldStatus, SyncFieldPriority, SyncFieldDueDate,
+	SyncFieldProject, SyncFieldParent, SyncFieldTags, SyncFieldEstimate,
+	SyncFieldStart, SyncFieldCustom,
+}
+
+// FieldClocks - HLC последней записи каждого поля
+type FieldClocks map[string]HLC
+
+// Max - самая поздняя метка записи
+func (c FieldClocks) Max() HLC {
+	var max HLC
+	for _, h := range c {
+		if h.After(max) {
+			max = h
+		}
+	}
+	return max
+}
+
+// SyncRecord - задача с метками полей для обмена между устройствами
+type SyncRecord struct {
+	Task   Task
+	Clocks FieldClocks
+	Seq    int64 // локальный номер изменения, не передается
+}
+
+// Tombstone - удаленная задача; удаление побеждает любые правки той же задачи
+type Tombstone struct {
+	TaskID    string
+	Clock     HLC
+	DeletedAt time.Time
+	Seq       int64
+}
+
+// StampChanges ставит метку now на поля, изменившиеся между before и after.
+// before == nil - новая задача, помечаются все поля.
+func StampChanges(before, after *Task, clocks FieldClocks, now HLC) FieldClocks {
+	stamped := make(FieldClocks, len(SyncFields))
+	for field, h := range clocks {
+		stamped[field] = h
+	}
+
+	for _, field := range SyncFields {
+		if before == nil || !fieldEqual(field, before, after) {
+			stamped[field] = now
+		}
+	}
+	return stamped
+}
+
+// MergeRecords сливает удаленную запись в локальную: каждое поле берется
+// из записи с более поздней меткой. changed сообщает, изменилась ли локальная запись.
+func MergeRecords(local, remote SyncRecord) (merged SyncRecord, changed bool) {
+	merged = SyncRecord{Task: local.Task.Snapshot(), Clocks: make(FieldClocks, len(SyncFields))}
+	for field, h := range local.Clocks {
+		merged.Clocks[field] = h
+	}
+
+	for _, field := range SyncFields {
+		remoteClock, ok := remote.Clocks[field]
+		if !ok || !remoteClock.After(local.Clocks[field]) {
+			continue
+		}
+
+		merged.Clocks[field] = remoteClock
+		if !fieldEqual(field, &merged.Task, &remote.Task) {
+			copyField(field, &merged.Task, &remote.Task)
+			changed = true
+		}
+	}
+
+	// Дата создания неизменна, при расхождении берем более раннюю
+	if remote.Task.CreatedAt.Before(merged.Task.CreatedAt) {
+		merged.Task.CreatedAt = remote.Task.CreatedAt
+		changed = true
+	}
+
+	return merged, changed
+}
+
+func fieldEqual(field string, a, b *Task) bool {
+	switch field {
+	case SyncFieldTitle:
+		return a.Title == b.Title
+	case SyncFieldStatus:
+		return a.Status == b.Status
+	case SyncFieldPriority:
+		return a.Priority == b.Priority
+	case SyncFieldDueDate:
+		if a.DueDate == nil || b.DueDate == nil {
+			return a.DueDate == b.DueDate
+		}
+		return a.DueDate.Equal(*b.DueDate)
+	case SyncFieldProject:
+		return a.Project == b.Project
+	case SyncFieldParent:
+		if a.ParentID == nil || b.ParentID == nil {
+			return a.ParentID == b.ParentID
+		}
+		return *a.ParentID == *b.ParentID
+	case SyncFieldTags:
+		return slices.Equal(a.Tags, b.Tags)
+	case SyncFieldEstimate:
+		return a.EstimateMinutes == b.EstimateMinutes && a.EstimatePoints == b.EstimatePoints
+	case SyncFieldStart:
+		if a.StartDate == nil || b.StartDate == nil {
+			return a.StartDate == b.StartDate
+		}
+		return a.StartDate.Equal(*b.StartDate)
+	case SyncFieldCustom:
+		return customValuesEqual(a.CustomFields, b.CustomFields)
+	}
+	return true
+}
+
+func copyField(field string, dst, src *Task) {
+	s := src.Snapshot()
+	switch field {
+	case SyncFieldTitle:
+		dst.Title = s.Title
+	case SyncFieldStatus:
+		// Время закрытия следует за статусом
+		dst.Status = s.Status
+		dst.CompletedAt = s.CompletedAt
+	case SyncFieldPriority:
+		dst.Priority = s.Priority
+	case SyncFieldDueDate:
+		dst.DueDate = s.DueDate
+	case SyncFieldProject:
+		dst.Project = s.Project
+	case SyncFieldParent:
+		dst.ParentID = s.ParentID
+	case SyncFieldTags:
+		dst.Tags = s.Tags
+	case SyncFieldEstimate:
+		dst.EstimateMinutes = s.EstimateMinutes
+		dst.EstimatePoints = s.EstimatePoints
+	case SyncFieldStart:
+		dst.StartDate = s.StartDate
+	case SyncFieldCustom:
+		dst.CustomFields = s.CustomFields
+	}
+}
+
+// SyncRepository - хранилище состояния синхронизации, работает в транзакции репозитория задач
+type SyncRepository interface {
+	// Pending - локальные изменения, еще не подтвержденные сервером
+	Pending(ctx context.Context) ([]SyncRecord, []Tombstone, error)
+	// MarkSynced снимает отметку, только если запись не менялась после чтения (по Seq)
+	MarkSynced(ctx context.Context, records []SyncRecord, tombstones []Tombstone) error
+	// Changes - изменения с номером больше since, не более limit
+	Changes(ctx context.Context, since int64, limit int) ([]SyncRecord, []Tombstone, error)
+
+	GetRecord(ctx context.Context, id string) (*SyncRecord, error)
+	IsTombstoned(ctx context.Context, id string) (bool, error)
+	// PutRecord записывает результат слияния как есть, без новых меток
+	PutRecord(ctx context.Context, record SyncRecord) error
+	// PutTombstone удаляет задачу (если она есть) и сохраняет надгробие
+	PutTombstone(ctx context.Context, tombstone Tombstone) error
+	// DropOrphans удаляет подзадачи, чей родитель удален, и возвращает их надгробия
+	DropOrphans(ctx context.Context, clock HLC) ([]Tombstone, error)
+
+	Cursor(ctx context.Context) (int64, error)
+	SetCursor(ctx context.Context, cursor int64) error
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"strings"
 	"time"
@@ -8,6 +10,7 @@ import (
 
 type TaskStatus string
 
+// Статусы исходного workflow; набор статусов настраивается, см. Workflow
 const (
 	StatusActive    TaskStatus = "active"
 	StatusCompleted TaskStatus = "completed"
@@ -27,6 +30,13 @@ var (
 	ErrInvalidTitle    = errors.New("invalid title")
 	ErrInvalidStatus   = errors.New("invalid status")
 	ErrInvalidPriority = errors.New("invalid priority")
+	ErrInvalidDate     = errors.New("invalid date")
+	ErrInvalidProject  = errors.New("invalid project")
+	ErrInvalidParent   = errors.New("invalid parent")
+	ErrInvalidTag      = errors.New("invalid tag")
+	ErrVersionConflict = errors.New("task version conflict")
+	ErrTaskExists      = errors.New("task already exists")
+	ErrInvalidEstimate = errors.New("invalid estimate")
 )
 
 type Task struct {
@@ -36,6 +46,27 @@ type Task struct {
 	CreatedAt time.Time
 	DueDate   *time.Time
 	Priority  Priority
+	Project   string  // пустая строка - задача без проекта
+	ParentID  *string // ID родительской задачи для подзадач
+	Tags      []string
+	Version   int64 // увеличивается репозиторием при каждом сохранении
+	UpdatedAt time.Time
+	// TrackedTime - сумма завершенных записей времени, ведется репозиторием
+	TrackedTime time.Duration
+	// Оценка в минутах или в story points; 0 - оценки нет
+	EstimateMinutes int
+	EstimatePoints  int
+	// CompletedAt - когда задача перешла в закрытый статус, nil у открытых
+	CompletedAt *time.Time
+	// ArchivedAt - когда задача ушла в архив, ведется репозиторием
+	ArchivedAt *time.Time
+	// StartDate - до этого момента задача отложена и не показывается в списке
+	StartDate *time.Time
+	// Прогресс чек-листа: всего пунктов и отмеченных, ведется репозиторием
+	ChecklistTotal int
+	ChecklistDone  int
+	// CustomFields - значения пользовательских полей по ключу, см. CustomField
+	CustomFields map[string]any
 }
 
 // Фабрика для создания новой задачи
@@ -65,7 +96,8 @@ func (t *Task) IsValid() error {
 		return ErrInvalidTitle
 	}
 
-	if t.Status != StatusActive && t.Status != StatusCompleted {
+	// Есть ли статус в workflow, проверяет Workflow.ValidateTask
+	if !statusKeyPattern.MatchString(string(t.Status)) {
 		return ErrInvalidStatus
 	}
 
@@ -73,24 +105,106 @@ func (t *Task) IsValid() error {
 		return ErrInvalidPriority
 	}
 
+	if len(t.Project) > 100 {
+		return ErrInvalidProject
+	}
+
+	if t.ParentID != nil && (*t.ParentID == "" || *t.ParentID == t.ID) {
+		return ErrInvalidParent
+	}
+
+	for _, tag := range t.Tags {
+		if strings.TrimSpace(tag) == "" || len(tag) > 50 {
+			return ErrInvalidTag
+		}
+	}
+
+	if t.EstimateMinutes < 0 || t.EstimatePoints < 0 {
+		return ErrInvalidEstimate
+	}
+
 	return nil
 }
 
-func (t *Task) Complete() error {
-	if t.Status == StatusCompleted {
+// Complete переводит задачу в закрытый статус workflow
+func (t *Task) Complete(w Workflow) error {
+	if t.IsClosed(w) {
 		return errors.New("task already completed")
 	}
-	t.Status = StatusCompleted
+	done := w.DoneStatus()
+	if err := w.CanTransition(t.Status, done); err != nil {
+		return err
+	}
+	t.Status = done
+	t.MarkCompletion(w, time.Now())
 	return nil
 }
 
-func (t *Task) IsOverdue() bool {
-	if t.DueDate == nil || t.Status == StatusCompleted {
+// MarkCompletion приводит CompletedAt к статусу: закрытая задача без времени
+// закрытия получает at, открытая теряет его. Вызывается при каждой смене статуса.
+func (t *Task) MarkCompletion(w Workflow, at time.Time) {
+	switch {
+	case !t.IsClosed(w):
+		t.CompletedAt = nil
+	case t.CompletedAt == nil:
+		t.CompletedAt = &at
+	}
+}
+
+func (t *Task) IsClosed(w Workflow) bool {
+	return w.IsClosed(t.Status)
+}
+
+func (t *Task) HasTag(tag string) bool {
+	for _, tt := range t.Tags {
+		if strings.EqualFold(tt, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag добавляет тег, если его еще нет
+func (t *Task) AddTag(tag string) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || t.HasTag(tag) {
+		return
+	}
+	t.Tags = append(t.Tags, tag)
+}
+
+// Effort - оценка трудоемкости: минуты важнее очков, очки переводятся
+// через pointDuration. ok=false, если задача не оценена.
+func (t *Task) Effort(pointDuration time.Duration) (effort time.Duration, ok bool) {
+	switch {
+	case t.EstimateMinutes > 0:
+		return time.Duration(t.EstimateMinutes) * time.Minute, true
+	case t.EstimatePoints > 0:
+		return time.Duration(t.EstimatePoints) * pointDuration, true
+	}
+	return 0, false
+}
+
+// IsDeferred - задача отложена до StartDate
+func (t *Task) IsDeferred(now time.Time) bool {
+	return t.StartDate != nil && t.StartDate.After(now)
+}
+
+func (t *Task) IsOverdue(w Workflow) bool {
+	if t.DueDate == nil || t.IsClosed(w) {
 		return false
 	}
 	return t.DueDate.Before(time.Now())
 }
 
+// Время в ID с точностью до секунды, а импорт создает много задач подряд,
+// поэтому добавляем случайный суффикс
 func generateID() string {
-	return "task_" + time.Now().Format("20060102150405000")
+	return "task_" + time.Now().Format("20060102150405000") + "_" + randomHex(4)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
 }
\ No newline at end of file
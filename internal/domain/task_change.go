@@ -0,0 +1,16 @@
+package domain
+
+type ChangeOp string
+
+const (
+	ChangeInsert ChangeOp = "insert"
+	ChangeUpdate ChangeOp = "update"
+	ChangeDelete ChangeOp = "delete"
+)
+
+// TaskChange - изменение строки задачи, сделанное любым клиентом общей базы
+type TaskChange struct {
+	Op      ChangeOp `json:"op"`
+	TaskID  string   `json:"id"`
+	Version int64    `json:"version"`
+}
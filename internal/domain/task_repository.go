@@ -8,9 +8,47 @@ import (
 type TaskRepository interface {
 	Save(ctx context.Context, task *Task) error
 	GetByID(ctx context.Context, id string) (*Task, error)
+	// GetForUpdate - как GetByID, но блокирует строку до конца транзакции (вызывать внутри WithTx)
+	GetForUpdate(ctx context.Context, id string) (*Task, error)
 	GetAll(ctx context.Context) ([]*Task, error)
-	GetByStatus(ctx context.Context, status TaskStatus) ([]*Task, error)
+	// GetByStatus - задачи в любом из статусов
+	GetByStatus(ctx context.Context, statuses ...TaskStatus) ([]*Task, error)
+	// CountByStatus - число задач вне архива по статусам
+	CountByStatus(ctx context.Context) (map[TaskStatus]int, error)
 	GetDueBetween(ctx context.Context, startDate, endDate time.Time) ([]*Task, error)
+	// GetCompletedBetween - задачи, закрытые в [startDate, endDate), по времени закрытия
+	GetCompletedBetween(ctx context.Context, startDate, endDate time.Time) ([]*Task, error)
+	// GetStartingBetween - отложенные задачи с датой начала в (startDate, endDate]
+	GetStartingBetween(ctx context.Context, startDate, endDate time.Time) ([]*Task, error)
 	Delete(ctx context.Context, id string) error
+	DeleteAll(ctx context.Context) error
 	WithTx(ctx context.Context, fn func(repo TaskRepository) error) error
+	// Outbox работает в той же транзакции, что и репозиторий задач
+	Outbox() OutboxRepository
+	// Sync - состояние синхронизации между устройствами, в той же транзакции
+	Sync() SyncRepository
+	// TimeEntries - учет времени по задачам, в той же транзакции
+	TimeEntries() TimeEntryRepository
+	// FocusSessions - журнал помидоров
+	FocusSessions() FocusSessionRepository
+	// Dependencies - связи blocked_by между задачами
+	Dependencies() DependencyRepository
+	// Workflow - настраиваемые статусы и переходы
+	Workflow() WorkflowRepository
+	// Board - порядок карточек и WIP-лимиты канбан-доски
+	Board() BoardRepository
+	// Archive - давно закрытые задачи вне активных запросов
+	Archive() ArchiveRepository
+	// Checklist - пункты чек-листов задач
+	Checklist() ChecklistRepository
+	// Attachments - метаданные вложений задач
+	Attachments() AttachmentRepository
+	// Comments - комментарии к задачам
+	Comments() CommentRepository
+	// Activity - история изменений задач и общая лента с комментариями
+	Activity() ActivityRepository
+	// CustomFields - описания пользовательских полей
+	CustomFields() CustomFieldRepository
+	// Webhooks - вебхуки в той же транзакции, нужны импорту бэкапа
+	Webhooks() WebhookRepository
 }
\ No newline at end of file
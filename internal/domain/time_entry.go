@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTimeEntryNotFound = errors.New("time entry not found")
+	ErrNoRunningTimer    = errors.New("no running timer")
+	ErrInvalidTimeRange  = errors.New("invalid time range")
+)
+
+// TimeEntry - отрезок работы над задачей; EndedAt == nil - таймер запущен
+type TimeEntry struct {
+	ID        string
+	TaskID    string
+	StartedAt time.Time
+	EndedAt   *time.Time
+	Note      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// StartTimeEntry - запущенный таймер
+func StartTimeEntry(taskID string, at time.Time, note string) *TimeEntry {
+	return &TimeEntry{
+		ID:        newTimeEntryID(),
+		TaskID:    taskID,
+		StartedAt: at,
+		Note:      note,
+		CreatedAt: at,
+	}
+}
+
+// NewTimeEntry - запись, добавленная вручную
+func NewTimeEntry(taskID string, startedAt, endedAt time.Time, note string) (*TimeEntry, error) {
+	entry := &TimeEntry{
+		ID:        newTimeEntryID(),
+		TaskID:    taskID,
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+
+	if err := entry.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func newTimeEntryID() string {
+	return "te_" + time.Now().Format("20060102150405") + "_" + randomHex(4)
+}
+
+func (e *TimeEntry) IsValid() error {
+	if e.StartedAt.IsZero() || e.StartedAt.After(time.Now()) {
+		return ErrInvalidTimeRange
+	}
+	if e.EndedAt != nil && (e.EndedAt.Before(e.StartedAt) || e.EndedAt.After(time.Now())) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+func (e *TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+func (e *TimeEntry) Stop(at time.Time) {
+	if at.Before(e.StartedAt) {
+		at = e.StartedAt
+	}
+	e.EndedAt = &at
+}
+
+// Duration - длительность записи; у запущенной считается до now
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.EndedAt != nil {
+		end = *e.EndedAt
+	}
+	if end.Before(e.StartedAt) {
+		return 0
+	}
+	return end.Sub(e.StartedAt)
+}
+
+// Overlap - часть записи, попадающая в [from, to)
+func (e *TimeEntry) Overlap(from, to, now time.Time) time.Duration {
+	start, end := e.StartedAt, now
+	if e.EndedAt != nil {
+		end = *e.EndedAt
+	}
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// TimeEntryRepository работает в транзакции репозитория задач;
+// после каждого изменения пересчитывает Task.TrackedTime затронутых задач
+type TimeEntryRepository interface {
+	Save(ctx context.Context, entry *TimeEntry) error
+	GetByID(ctx context.Context, id string) (*TimeEntry, error)
+	// GetRunning возвращает ErrNoRunningTimer, если таймер не запущен
+	GetRunning(ctx context.Context) (*TimeEntry, error)
+	ListByTask(ctx context.Context, taskID string) ([]*TimeEntry, error)
+	// ListBetween - записи, пересекающиеся с [from, to)
+	ListBetween(ctx context.Context, from, to time.Time) ([]*TimeEntry, error)
+	// GetAll - все записи по времени начала, для бэкапа
+	GetAll(ctx context.Context) ([]*TimeEntry, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// EventWebhookTest - синтетическое событие для проверки подписки
+const EventWebhookTest = "webhook.test"
+
+var (
+	ErrWebhookNotFound   = errors.New("webhook not found")
+	ErrInvalidWebhookURL = errors.New("invalid webhook url")
+	ErrInvalidEventType  = errors.New("invalid event type")
+)
+
+// WebhookEventTypes - события, на которые можно подписаться
+var WebhookEventTypes = []string{
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskCompleted,
+	EventTaskReopened,
+	EventTaskDeleted,
+	EventTaskOverdue,
+	EventTaskWoke,
+}
+
+type Webhook struct {
+	ID         string
+	URL        string
+	EventTypes []string // пустой список - все события
+	Filter     string   // выражение ParseFilter, пустое - без фильтра
+	Secret     string   // ключ HMAC подписи, пустой - без подписи
+	Enabled    bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewWebhook(rawURL string, eventTypes []string, filter, secret string) (*Webhook, error) {
+	hook := &Webhook{
+		ID:         "wh_" + time.Now().Format("20060102150405") + "_" + randomHex(4),
+		URL:        rawURL,
+		EventTypes: eventTypes,
+		Filter:     filter,
+		Secret:     secret,
+		Enabled:    true,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := hook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return hook, nil
+}
+
+func (w *Webhook) IsValid() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	for _, t := range w.EventTypes {
+		if !isWebhookEventType(t) {
+			return ErrInvalidEventType
+		}
+	}
+
+	if _, err := ParseFilter(w.Filter); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Matches проверяет тип события и фильтр; тестовое событие проходит всегда
+func (w *Webhook) Matches(s FilterSubject) bool {
+	if s.Event == EventWebhookTest {
+		return true
+	}
+	if !w.Enabled {
+		return false
+	}
+
+	if len(w.EventTypes) > 0 {
+		subscribed := false
+		for _, t := range w.EventTypes {
+			if t == s.Event {
+				subscribed = true
+				break
+			}
+		}
+		if !subscribed {
+			return false
+		}
+	}
+
+	filter, err := ParseFilter(w.Filter)
+	if err != nil {
+		return false
+	}
+	return filter.Match(s)
+}
+
+func isWebhookEventType(t string) bool {
+	for _, known := range WebhookEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery - запись журнала доставки
+type WebhookDelivery struct {
+	ID         int64
+	WebhookID  string
+	EventID    string // ключ идемпотентности события
+	EventType  string
+	StatusCode int // 0 - ответа не было
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.Error == "" && d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+type WebhookRepository interface {
+	Save(ctx context.Context, hook *Webhook) error
+	GetByID(ctx context.Context, id string) (*Webhook, error)
+	GetAll(ctx context.Context) ([]*Webhook, error)
+	Delete(ctx context.Context, id string) error
+	AddDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID string, limit int) ([]*WebhookDelivery, error)
+	// Delivered - была ли успешная доставка события этому вебхуку
+	Delivered(ctx context.Context, webhookID, eventID string) (bool, error)
+}
+
+// WebhookClient отправляет подписанный payload
+type WebhookClient interface {
+	Post(ctx context.Context, hook *Webhook, eventID, eventType string, payload []byte) *WebhookDelivery
+}
@@ -0,0 +1,309 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Поля, доступные в выражении фильтра вебхука
+var filterFields = map[string]bool{
+	"event": true, "id": true, "title": true, "status": true,
+	"priority": true, "project": true, "tags": true, "overdue": true,
+	"category": true,
+}
+
+// Filter - разобранное выражение вида
+//
+//	event == task.created && priority == high && (tags contains ci || project == "Work")
+//
+// Операторы: ==, !=, contains; связки: &&, ||, ! и скобки.
+// Сравнение строк без учета регистра, для tags == и contains означают наличие тега.
+type Filter struct {
+	root filterNode
+}
+
+// FilterSubject - значения полей для проверки фильтра; Task nil у task.deleted
+type FilterSubject struct {
+	Event    string
+	Task     *Task
+	Now      time.Time
+	Workflow Workflow // для category и overdue
+}
+
+type filterNode interface {
+	eval(s FilterSubject) bool
+}
+
+func ParseFilter(expr string) (Filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return Filter{}, nil
+	}
+
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return Filter{}, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return Filter{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return Filter{}, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, p.tokens[p.pos].text)
+	}
+
+	return Filter{root: root}, nil
+}
+
+// Match: пустой фильтр пропускает все события
+func (f Filter) Match(s FilterSubject) bool {
+	if f.root == nil {
+		return true
+	}
+	return f.root.eval(s)
+}
+
+// ------ AST -------
+
+type andNode struct{ left, right filterNode }
+type orNode struct{ left, right filterNode }
+type notNode struct{ inner filterNode }
+
+type cmpNode struct {
+	field string
+	op    string
+	value string
+}
+
+func (n andNode) eval(s FilterSubject) bool { return n.left.eval(s) && n.right.eval(s) }
+func (n orNode) eval(s FilterSubject) bool  { return n.left.eval(s) || n.right.eval(s) }
+func (n notNode) eval(s FilterSubject) bool { return !n.inner.eval(s) }
+
+func (n cmpNode) eval(s FilterSubject) bool {
+	if n.field == "tags" {
+		has := s.Task != nil && s.Task.HasTag(n.value)
+		if n.op == "!=" {
+			return !has
+		}
+		return has
+	}
+
+	actual := n.fieldValue(s)
+	switch n.op {
+	case "==":
+		return strings.EqualFold(actual, n.value)
+	case "!=":
+		return !strings.EqualFold(actual, n.value)
+	default: // contains
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(n.value))
+	}
+}
+
+func (n cmpNode) fieldValue(s FilterSubject) string {
+	if n.field == "event" {
+		return s.Event
+	}
+	if s.Task == nil {
+		return ""
+	}
+
+	switch n.field {
+	case "id":
+		return s.Task.ID
+	case "title":
+		return s.Task.Title
+	case "status":
+		return string(s.Task.Status)
+	case "category":
+		if s.Task.IsClosed(s.Workflow) {
+			return string(CategoryClosed)
+		}
+		return string(CategoryOpen)
+	case "priority":
+		return string(s.Task.Priority)
+	case "project":
+		return s.Task.Project
+	case "overdue":
+		if !s.Task.IsClosed(s.Workflow) && s.Task.DueDate != nil && s.Task.DueDate.Before(s.Now) {
+			return "true"
+		}
+		return "false"
+	}
+	return ""
+}
+
+// ------ lexer -------
+
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, filterToken{text: string(r)})
+			i++
+		case r == '!' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, filterToken{text: "!="})
+			i += 2
+		case r == '!':
+			tokens = append(tokens, filterToken{text: "!"})
+			i++
+		case r == '=' || r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, string(r))
+			}
+			tokens = append(tokens, filterToken{text: string(r) + string(r)})
+			i += 2
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+			}
+			tokens = append(tokens, filterToken{text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && isFilterWordRune(runes[i]) {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, string(r))
+			}
+			tokens = append(tokens, filterToken{text: string(runes[start:i])})
+		}
+	}
+
+	return tokens, nil
+}
+
+func isFilterWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' || r == ':'
+}
+
+// ------ parser -------
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek(text string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == text
+}
+
+func (p *filterParser) next() (filterToken, error) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, fmt.Errorf("%w: unexpected end of expression", ErrInvalidFilter)
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("||") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("&&") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.peek("!") {
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+
+	if p.peek("(") {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(")") {
+			return nil, fmt.Errorf("%w: missing )", ErrInvalidFilter)
+		}
+		p.pos++
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	field, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	name := strings.ToLower(field.text)
+	if name == "tag" {
+		name = "tags"
+	}
+	if field.quoted || !filterFields[name] {
+		return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, field.text)
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if op.quoted || (op.text != "==" && op.text != "!=" && op.text != "contains") {
+		return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, op.text)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if !value.quoted && (value.text == "(" || value.text == ")" || value.text == "&&" || value.text == "||" || value.text == "!") {
+		return nil, fmt.Errorf("%w: missing value for %s", ErrInvalidFilter, field.text)
+	}
+
+	return cmpNode{field: name, op: op.text, value: value.text}, nil
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+var (
+	ErrInvalidWorkflow   = errors.New("invalid workflow")
+	ErrInvalidTransition = errors.New("status transition not allowed")
+	ErrStatusInUse       = errors.New("status is in use")
+)
+
+// StatusCategory - к какой группе относится статус: открытые задачи в работе, закрытые - завершены
+type StatusCategory string
+
+const (
+	CategoryOpen   StatusCategory = "open"
+	CategoryClosed StatusCategory = "closed"
+)
+
+var statusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// WorkflowStatus - пользовательский статус задачи
+type WorkflowStatus struct {
+	Key      TaskStatus     `json:"key"` // неизменяем, хранится в задачах
+	Name     string         `json:"name"`
+	Category StatusCategory `json:"category"`
+	Position int            `json:"position"`
+	Initial  bool           `json:"initial"` // статус новых задач, ровно один и открытый
+}
+
+// WorkflowTransition - разрешенный переход From -> To
+type WorkflowTransition struct {
+	From TaskStatus `json:"from"`
+	To   TaskStatus `json:"to"`
+}
+
+// Workflow - набор статусов и переходов между ними.
+// Из статуса без единого перехода можно перейти в любой.
+type Workflow struct {
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// DefaultWorkflow - исходные active/completed без ограничений на переходы
+func DefaultWorkflow() Workflow {
+	return Workflow{Statuses: []WorkflowStatus{
+		{Key: StatusActive, Name: "Active", Category: CategoryOpen, Position: 0, Initial: true},
+		{Key: StatusCompleted, Name: "Completed", Category: CategoryClosed, Position: 1},
+	}}
+}
+
+func (w Workflow) IsValid() error {
+	keys := make(map[TaskStatus]bool, len(w.Statuses))
+	var open, closed, initial int
+
+	for _, s := range w.Statuses {
+		if !statusKeyPattern.MatchString(string(s.Key)) || keys[s.Key] {
+			return fmt.Errorf("%w: bad or duplicate status key %q", ErrInvalidWorkflow, s.Key)
+		}
+		if s.Name == "" || len(s.Name) > 50 {
+			return fmt.Errorf("%w: bad name of status %q", ErrInvalidWorkflow, s.Key)
+		}
+		keys[s.Key] = true
+
+		switch s.Category {
+		case CategoryOpen:
+			open++
+		case CategoryClosed:
+			closed++
+		default:
+			return fmt.Errorf("%w: unknown category %q", ErrInvalidWorkflow, s.Category)
+		}
+		if s.Initial {
+			if s.Category != CategoryOpen {
+				return fmt.Errorf("%w: initial status must be open", ErrInvalidWorkflow)
+			}
+			initial++
+		}
+	}
+
+	if open == 0 || closed == 0 {
+		return fmt.Errorf("%w: need at least one open and one closed status", ErrInvalidWorkflow)
+	}
+	if initial != 1 {
+		return fmt.Errorf("%w: need exactly one initial status", ErrInvalidWorkflow)
+	}
+
+	for _, t := range w.Transitions {
+		if !keys[t.From] || !keys[t.To] || t.From == t.To {
+			return fmt.Errorf("%w: bad transition %s -> %s", ErrInvalidWorkflow, t.From, t.To)
+		}
+	}
+	return nil
+}
+
+func (w Workflow) Status(key TaskStatus) (WorkflowStatus, bool) {
+	for _, s := range w.Statuses {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// Sorted - статусы в порядке Position
+func (w Workflow) Sorted() []WorkflowStatus {
+	sorted := append([]WorkflowStatus(nil), w.Statuses...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Position < sorted[j].Position })
+	return sorted
+}
+
+// IsClosed - неизвестный статус считается открытым
+func (w Workflow) IsClosed(key TaskStatus) bool {
+	s, ok := w.Status(key)
+	return ok && s.Category == CategoryClosed
+}
+
+// InCategory - ключи статусов категории в порядке Position
+func (w Workflow) InCategory(category StatusCategory) []TaskStatus {
+	var keys []TaskStatus
+	for _, s := range w.Sorted() {
+		if s.Category == category {
+			keys = append(keys, s.Key)
+		}
+	}
+	return keys
+}
+
+func (w Workflow) InitialStatus() TaskStatus {
+	for _, s := range w.Statuses {
+		if s.Initial {
+			return s.Key
+		}
+	}
+	return StatusActive
+}
+
+// DoneStatus - первый закрытый статус, в него переводит CompleteTask
+func (w Workflow) DoneStatus() TaskStatus {
+	if keys := w.InCategory(CategoryClosed); len(keys) > 0 {
+		return keys[0]
+	}
+	return StatusCompleted
+}
+
+// CanTransition проверяет переход; смена на тот же статус разрешена всегда
+func (w Workflow) CanTransition(from, to TaskStatus) error {
+	if _, ok := w.Status(to); !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidStatus, to)
+	}
+	if from == to {
+		return nil
+	}
+
+	restricted := false
+	for _, t := range w.Transitions {
+		if t.From != from {
+			continue
+		}
+		if t.To == to {
+			return nil
+		}
+		restricted = true
+	}
+	if restricted {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// ValidateTask - Task.IsValid плюс проверка, что статус есть в workflow
+func (w Workflow) ValidateTask(t *Task) error {
+	if err := t.IsValid(); err != nil {
+		return err
+	}
+	if _, ok := w.Status(t.Status); !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidStatus, t.Status)
+	}
+	return nil
+}
+
+// WorkflowRepository работает в транзакции репозитория задач
+type WorkflowRepository interface {
+	Get(ctx context.Context) (Workflow, error)
+	// SaveStatus добавляет статус или обновляет его имя, категорию и позицию
+	SaveStatus(ctx context.Context, status WorkflowStatus) error
+	// DeleteStatus возвращает ErrStatusInUse, если статус еще есть у задач
+	DeleteStatus(ctx context.Context, key TaskStatus) error
+	SetTransitions(ctx context.Context, transitions []WorkflowTransition) error
+	// Lock сериализует изменения workflow
+	Lock(ctx context.Context) error
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// kanban: todo -> doing -> review -> done, из review можно вернуться в doing
+func kanban() Workflow {
+	return Workflow{
+		Statuses: []WorkflowStatus{
+			{Key: "todo", Name: "To do", Category: CategoryOpen, Position: 0, Initial: true},
+			{Key: "doing", Name: "Doing", Category: CategoryOpen, Position: 1},
+			{Key: "review", Name: "Review", Category: CategoryOpen, Position: 2},
+			{Key: "done", Name: "Done", Category: CategoryClosed, Position: 3},
+			{Key: "wontfix", Name: "Won't fix", Category: CategoryClosed, Position: 4},
+		},
+		Transitions: []WorkflowTransition{
+			{From: "todo", To: "doing"},
+			{From: "doing", To: "review"},
+			{From: "review", To: "doing"},
+			{From: "review", To: "done"},
+		},
+	}
+}
+
+func TestWorkflowCanTransition(t *testing.T) {
+	w := kanban()
+	tests := []struct {
+		from, to TaskStatus
+		want     error
+	}{
+		{"todo", "doing", nil},
+		{"todo", "done", ErrInvalidTransition}, // у todo есть переходы, done среди них нет
+		{"review", "doing", nil},
+		{"review", "done", nil},
+		{"doing", "doing", nil},    // тот же статус
+		{"done", "todo", nil},      // из статуса без переходов - куда угодно
+		{"wontfix", "review", nil}, // тоже без переходов
+		{"todo", "unknown", ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		err := w.CanTransition(tt.from, tt.to)
+		if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, err, tt.want)
+		}
+	}
+
+	// Без переходов разрешено все
+	if err := DefaultWorkflow().CanTransition(StatusCompleted, StatusActive); err != nil {
+		t.Errorf("default workflow: %v", err)
+	}
+}
+
+func TestWorkflowIsValid(t *testing.T) {
+	if err := kanban().IsValid(); err != nil {
+		t.Fatalf("kanban: %v", err)
+	}
+	if err := DefaultWorkflow().IsValid(); err != nil {
+		t.Fatalf("default: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(w *Workflow)
+	}{
+		{"no initial", func(w *Workflow) { w.Statuses[0].Initial = false }},
+		{"two initial", func(w *Workflow) { w.Statuses[1].Initial = true }},
+		{"closed initial", func(w *Workflow) { w.Statuses[0].Initial, w.Statuses[3].Initial = false, true }},
+		{"no closed", func(w *Workflow) { w.Statuses = w.Statuses[:3]; w.Transitions = nil }},
+		{"duplicate key", func(w *Workflow) { w.Statuses[1].Key = "todo" }},
+		{"bad key", func(w *Workflow) { w.Statuses[1].Key = "In Progress" }},
+		{"unknown category", func(w *Workflow) { w.Statuses[1].Category = "blocked" }},
+		{"transition to unknown", func(w *Workflow) { w.Transitions[0].To = "ghost" }},
+		{"self transition", func(w *Workflow) { w.Transitions[0].To = w.Transitions[0].From }},
+	}
+	for _, tt := range tests {
+		w := kanban()
+		tt.change(&w)
+		if err := w.IsValid(); !errors.Is(err, ErrInvalidWorkflow) {
+			t.Errorf("%s: err = %v, want ErrInvalidWorkflow", tt.name, err)
+		}
+	}
+}
+
+func TestWorkflowCategories(t *testing.T) {
+	w := kanban()
+	if got := w.InCategory(CategoryClosed); !slices.Equal(got, []TaskStatus{"done", "wontfix"}) {
+		t.Fatalf("closed = %v", got)
+	}
+	if w.InitialStatus() != "todo" || w.DoneStatus() != "done" {
+		t.Fatalf("initial %s, done %s", w.InitialStatus(), w.DoneStatus())
+	}
+	if !w.IsClosed("wontfix") || w.IsClosed("review") || w.IsClosed("ghost") {
+		t.Fatal("IsClosed mismatch")
+	}
+
+	task := &Task{Title: "x", Priority: PriorityMedium, Status: "ghost"}
+	if err := w.ValidateTask(task); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("unknown status: err = %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+const (
+	filePrefix = "backup-"
+	fileSuffix = ".json"
+	// Наносекунды фиксированной ширины: бэкапы в одну секунду не затирают друг друга,
+	// а лексикографический порядок имен остается хронологическим
+	timeLayout = "20060102-150405.000000000"
+)
+
+type exporter interface {
+	Execute(ctx context.Context) (app.Snapshot, error)
+}
+
+// Scheduler периодически пишет JSON-снапшот в директорию
+// и оставляет только последние Keep файлов
+type Scheduler struct {
+	export   exporter
+	dir      string
+	interval time.Duration
+	keep     int
+
+	mu sync.Mutex // BackupNow из UI и по таймеру
+}
+
+func NewScheduler(export app.ExportBackup, cfg util.BackupConfig) *Scheduler {
+	return &Scheduler{
+		export:   export,
+		dir:      cfg.Dir,
+		interval: cfg.Interval,
+		keep:     cfg.KeepFiles(),
+	}
+}
+
+func (s *Scheduler) Enabled() bool {
+	return s.dir != "" && s.interval > 0
+}
+
+// Run блокируется до отмены ctx
+func (s *Scheduler) Run(ctx context.Context) {
+	if !s.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.BackupNow(ctx); err != nil {
+				log.Printf("backup: %v", err)
+			}
+		}
+	}
+}
+
+// BackupNow пишет снапшот и возвращает путь к созданному файлу
+func (s *Scheduler) BackupNow(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dir == "" {
+		return "", fmt.Errorf("backup dir is not configured")
+	}
+
+	snapshot, err := s.export.Execute(ctx)
+	if err != nil {
+		return "", fmt.Errorf("export: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", fmt.Errorf("create dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal snapshot: %w", err)
+	}
+
+	path, err := s.freePath(snapshot.ExportedAt)
+	if err != nil {
+		return "", err
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы не оставить обрезанный бэкап
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("rename file: %w", err)
+	}
+
+	if err := s.rotate(); err != nil {
+		return path, fmt.Errorf("rotate: %w", err)
+	}
+
+	return path, nil
+}
+
+// freePath - путь нового бэкапа; при совпадении времени берется следующая наносекунда,
+// чтобы не перезаписать существующий файл и не сломать порядок имен
+func (s *Scheduler) freePath(at time.Time) (string, error) {
+	for {
+		path := filepath.Join(s.dir, filePrefix+at.Format(timeLayout)+fileSuffix)
+		_, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return path, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("stat file: %w", err)
+		}
+		at = at.Add(time.Nanosecond)
+	}
+}
+
+func (s *Scheduler) rotate() error {
+	if s.keep <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		files = append(files, name)
+	}
+
+	if len(files) <= s.keep {
+		return nil
+	}
+
+	// Имя содержит время создания, поэтому лексикографический порядок = хронологический
+	sort.Strings(files)
+	for _, name := range files[:len(files)-s.keep] {
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
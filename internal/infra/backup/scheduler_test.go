@@ -0,0 +1,77 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+)
+
+// fixedExport отдает снапшоты с заданным временем экспорта по очереди
+type fixedExport []time.Time
+
+func (e *fixedExport) Execute(context.Context) (app.Snapshot, error) {
+	at := (*e)[0]
+	*e = (*e)[1:]
+	return app.Snapshot{Version: app.SnapshotVersion, ExportedAt: at}, nil
+}
+
+func TestBackupNowRotates(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	second := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	export := fixedExport{
+		second,
+		second.Add(250 * time.Millisecond),
+		second.Add(250 * time.Millisecond), // то же время до наносекунды
+		second.Add(900 * time.Millisecond),
+		second.Add(time.Second),
+	}
+	s := &Scheduler{export: &export, dir: dir, keep: 3}
+
+	// Старый бэкап с секундной точностью и чужой файл
+	legacy := filepath.Join(dir, "backup-20260301-090000.json")
+	other := filepath.Join(dir, "notes.json")
+	for _, path := range []string{legacy, other} {
+		if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var paths []string
+	for range 5 {
+		path, err := s.BackupNow(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slices.Contains(paths, path) {
+			t.Fatalf("backup overwrote %s", path)
+		}
+		paths = append(paths, path)
+	}
+	if want := "backup-20260302-090000.250000001.json"; filepath.Base(paths[2]) != want {
+		t.Fatalf("same time backup = %s, want %s", filepath.Base(paths[2]), want)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	want := []string{
+		"backup-20260302-090000.250000001.json",
+		"backup-20260302-090000.900000000.json",
+		"backup-20260302-090001.000000000.json",
+		"notes.json",
+	}
+	if !slices.Equal(names, want) {
+		t.Fatalf("files = %v, want %v", names, want)
+	}
+}
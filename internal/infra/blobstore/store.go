@@ -0,0 +1,188 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+// sniffLen - сколько байт смотрит http.DetectContentType
+const sniffLen = 512
+
+// Store хранит содержимое в <dir>/blobs/<первые 2 символа хеша>/<хеш>.
+// Копии для открытия лежат в <dir>/open/<хеш>/<имя> и удаляются вместе с blob'ом.
+type Store struct {
+	dir     string
+	maxSize int64
+}
+
+func NewStore(cfg util.AttachmentsConfig) *Store {
+	return &Store{dir: cfg.Dir, maxSize: int64(cfg.MaxSizeMB) << 20}
+}
+
+var _ app.BlobStore = (*Store)(nil)
+
+func (s *Store) Put(ctx context.Context, r io.Reader) (app.Blob, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return app.Blob{}, fmt.Errorf("read content: %w", err)
+	}
+	head = head[:n]
+
+	tmpDir := filepath.Join(s.dir, "tmp")
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return app.Blob{}, fmt.Errorf("create dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(tmpDir, "upload-*")
+	if err != nil {
+		return app.Blob{}, fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // после переименования ничего не удалит
+
+	// Читаем на байт больше лимита, чтобы отличить файл ровно в лимит от большего
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(io.MultiReader(bytes.NewReader(head), r), s.maxSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return app.Blob{}, fmt.Errorf("write content: %w", err)
+	}
+	if size > s.maxSize {
+		return app.Blob{}, fmt.Errorf("%w: limit is %d bytes", domain.ErrAttachmentTooLarge, s.maxSize)
+	}
+
+	blob := app.Blob{
+		Hash:     hex.EncodeToString(h.Sum(nil)),
+		Size:     size,
+		MimeType: http.DetectContentType(head),
+		ModTime:  time.Now(),
+	}
+
+	path := s.blobPath(blob.Hash)
+	if _, err := os.Stat(path); err == nil {
+		// Такое содержимое уже есть; свежее время защищает его от сборщика мусора
+		if err := os.Chtimes(path, blob.ModTime, blob.ModTime); err != nil {
+			return app.Blob{}, fmt.Errorf("touch blob: %w", err)
+		}
+		return blob, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return app.Blob{}, fmt.Errorf("create dir: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return app.Blob{}, fmt.Errorf("rename blob: %w", err)
+	}
+	return blob, nil
+}
+
+func (s *Store) OpenPath(hash, name string) (string, error) {
+	if !validHash(hash) {
+		return "", fmt.Errorf("invalid hash %q", hash)
+	}
+	name = domain.CleanAttachmentName(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: empty name", domain.ErrInvalidAttachment)
+	}
+
+	path := filepath.Join(s.dir, "open", hash, name)
+	if _, err := os.Stat(path); err == nil {
+		return filepath.Abs(path)
+	}
+
+	// Копия, а не ссылка: правка открытого файла не должна портить blob
+	src, err := os.Open(s.blobPath(hash))
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("create dir: %w", err)
+	}
+	tmp := path + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("create file: %w", err)
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("copy blob: %w", err)
+	}
+
+	return filepath.Abs(path)
+}
+
+func (s *Store) List(ctx context.Context) ([]app.Blob, error) {
+	var blobs []app.Blob
+	err := filepath.WalkDir(filepath.Join(s.dir, "blobs"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() || !validHash(d.Name()) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, app.Blob{Hash: d.Name(), Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	return blobs, err
+}
+
+func (s *Store) Delete(hash string) error {
+	if !validHash(hash) {
+		return fmt.Errorf("invalid hash %q", hash)
+	}
+	if err := os.RemoveAll(filepath.Join(s.dir, "open", hash)); err != nil {
+		return err
+	}
+	if err := os.Remove(s.blobPath(hash)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) blobPath(hash string) string {
+	return filepath.Join(s.dir, "blobs", hash[:2], hash)
+}
+
+// validHash защищает пути от подстановки: только 64 hex-символа
+func validHash(hash string) bool {
+	if len(hash) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
@@ -0,0 +1,134 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+)
+
+func TestPutSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	s := &Store{dir: t.TempDir(), maxSize: 1024}
+
+	atLimit := bytes.Repeat([]byte("a"), 1024)
+	blob, err := s.Put(ctx, bytes.NewReader(atLimit))
+	if err != nil {
+		t.Fatalf("file at the limit: %v", err)
+	}
+	if blob.Size != 1024 || blob.MimeType != "text/plain; charset=utf-8" {
+		t.Fatalf("blob = %+v", blob)
+	}
+	if data, err := os.ReadFile(s.blobPath(blob.Hash)); err != nil || !bytes.Equal(data, atLimit) {
+		t.Fatalf("stored content: %d bytes, %v", len(data), err)
+	}
+
+	_, err = s.Put(ctx, bytes.NewReader(bytes.Repeat([]byte("b"), 1025)))
+	if !errors.Is(err, domain.ErrAttachmentTooLarge) {
+		t.Fatalf("file over the limit: err = %v", err)
+	}
+	blobs, err := s.List(ctx)
+	if err != nil || len(blobs) != 1 {
+		t.Fatalf("blobs after refused put = %+v, %v", blobs, err)
+	}
+	// Временный файл отказанной загрузки не остается
+	if tmp, _ := os.ReadDir(filepath.Join(s.dir, "tmp")); len(tmp) != 0 {
+		t.Fatalf("tmp dir has %d files", len(tmp))
+	}
+
+	// Короче окна сигнатуры - тоже целиком
+	if blob, err := s.Put(ctx, strings.NewReader("%PDF-1.7")); err != nil || blob.Size != 8 || blob.MimeType != "application/pdf" {
+		t.Fatalf("short file = %+v, %v", blob, err)
+	}
+}
+
+func TestPutSameContentTouchesBlob(t *testing.T) {
+	ctx := context.Background()
+	s := &Store{dir: t.TempDir(), maxSize: 1024}
+
+	first, err := s.Put(ctx, strings.NewReader("report"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(s.blobPath(first.Hash), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := s.Put(ctx, strings.NewReader("report"))
+	if err != nil || second.Hash != first.Hash {
+		t.Fatalf("second put = %+v, %v", second, err)
+	}
+	info, err := os.Stat(s.blobPath(first.Hash))
+	if err != nil || !info.ModTime().After(old) {
+		t.Fatalf("blob was not touched: %v, %v", info.ModTime(), err)
+	}
+}
+
+func TestCollectBlobs(t *testing.T) {
+	ctx := context.Background()
+	s := &Store{dir: t.TempDir(), maxSize: 1024}
+	repo := testutil.NewRepo()
+	repo.Put(&domain.Task{ID: "t1", Title: "Report", Status: domain.StatusActive})
+
+	put := func(content string, age time.Duration) app.Blob {
+		blob, err := s.Put(ctx, strings.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		at := time.Now().Add(-age)
+		if err := os.Chtimes(s.blobPath(blob.Hash), at, at); err != nil {
+			t.Fatal(err)
+		}
+		return blob
+	}
+	referenced := put("referenced", 2*time.Hour)
+	orphan := put("orphan", 2*time.Hour)
+	fresh := put("fresh", time.Minute) // в пределах grace: транзакция вложения могла еще не закоммититься
+	edge := put("edge", 59*time.Minute)
+
+	attachment, err := domain.NewAttachment("t1", "report.txt", "text/plain", referenced.Size, referenced.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Attachments().Save(ctx, attachment); err != nil {
+		t.Fatal(err)
+	}
+	// Открытая копия уходит вместе с blob'ом
+	openPath, err := s.OpenPath(orphan.Hash, "orphan.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := app.NewCollectBlobs(repo, s).Execute(ctx)
+	if err != nil || removed != 1 {
+		t.Fatalf("removed = %d, %v; want only the old orphan", removed, err)
+	}
+	for _, blob := range []app.Blob{referenced, fresh, edge} {
+		if _, err := os.Stat(s.blobPath(blob.Hash)); err != nil {
+			t.Errorf("blob %s removed: %v", blob.Hash[:8], err)
+		}
+	}
+	if _, err := os.Stat(s.blobPath(orphan.Hash)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("orphan blob still exists: %v", err)
+	}
+	if _, err := os.Stat(openPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("open copy still exists: %v", err)
+	}
+
+	// Вложение удалили - blob уходит при следующей сборке
+	if err := repo.Attachments().Delete(ctx, attachment.ID); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := app.NewCollectBlobs(repo, s).Execute(ctx); err != nil || removed != 1 {
+		t.Fatalf("after delete: removed = %d, %v", removed, err)
+	}
+}
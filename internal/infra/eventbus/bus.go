@@ -0,0 +1,172 @@
+package eventbus
+
+import (
+	"context"
+	"hash/fnv"
+	"log"
+	"runtime/debug"
+	"sync"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const (
+	defaultWorkers   = 4
+	defaultQueueSize = 256
+)
+
+// Handler - подписчик на доменные события; ошибка только логируется
+type Handler func(ctx context.Context, event domain.Event) error
+
+type subscription struct {
+	name   string
+	events map[string]bool // пустая - все события
+	async  bool
+	handle Handler
+}
+
+func (s subscription) matches(event domain.Event) bool {
+	return len(s.events) == 0 || s.events[event.EventName()]
+}
+
+type delivery struct {
+	ctx   context.Context
+	event domain.Event
+	sub   subscription
+}
+
+// Bus - in-process шина событий.
+// Синхронные обработчики выполняются в Publish по порядку подписки.
+// Асинхронные - в воркерах; события одной задачи всегда попадают
+// в один воркер, поэтому обрабатываются в порядке публикации.
+type Bus struct {
+	mu   sync.RWMutex
+	subs []subscription
+
+	queues  []chan delivery
+	closed  bool
+	sending sync.WaitGroup // отправки в очереди, начатые до Close
+	wg      sync.WaitGroup
+}
+
+func NewBus() *Bus {
+	b := &Bus{queues: make([]chan delivery, defaultWorkers)}
+
+	for i := range b.queues {
+		b.queues[i] = make(chan delivery, defaultQueueSize)
+		b.wg.Add(1)
+		go b.worker(b.queues[i])
+	}
+
+	return b
+}
+
+// Subscribe регистрирует синхронный обработчик; без events - на все события
+func (b *Bus) Subscribe(name string, h Handler, events ...string) {
+	b.subscribe(name, h, false, events)
+}
+
+// SubscribeAsync регистрирует обработчик, который не задерживает use case
+func (b *Bus) SubscribeAsync(name string, h Handler, events ...string) {
+	b.subscribe(name, h, true, events)
+}
+
+func (b *Bus) subscribe(name string, h Handler, async bool, events []string) {
+	sub := subscription{name: name, async: async, handle: h}
+	if len(events) > 0 {
+		sub.events = make(map[string]bool, len(events))
+		for _, e := range events {
+			sub.events[e] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.subs = append(b.subs, sub)
+	b.mu.Unlock()
+}
+
+func (b *Bus) Publish(ctx context.Context, events ...domain.Event) {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+
+	// Асинхронные обработчики не должны зависеть от отмены запроса
+	asyncCtx := context.WithoutCancel(ctx)
+
+	for _, event := range events {
+		queue := b.queueFor(event.TaskID())
+
+		for _, sub := range subs {
+			if !sub.matches(event) {
+				continue
+			}
+			if sub.async {
+				b.enqueue(queue, delivery{ctx: asyncCtx, event: event, sub: sub})
+				continue
+			}
+			dispatch(ctx, sub, event)
+		}
+	}
+}
+
+func (b *Bus) enqueue(queue chan delivery, d delivery) {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		log.Printf("event bus: closed, dropping %s for %s", d.event.EventName(), d.sub.name)
+		return
+	}
+	b.sending.Add(1)
+	b.mu.RUnlock()
+	defer b.sending.Done()
+
+	// Места в полной очереди ждем без блокировки, иначе за этой отправкой
+	// встали бы Close и все новые Publish. Очередь закрывается только после sending.Wait.
+	queue <- d
+}
+
+// Close дожидается обработки уже опубликованных событий, новые асинхронные доставки отбрасываются
+func (b *Bus) Close() {
+	b.mu.Lock()
+	closing := !b.closed
+	b.closed = true
+	b.mu.Unlock()
+
+	if closing {
+		// Воркеры еще работают и освобождают место для начатых отправок
+		b.sending.Wait()
+		for _, q := range b.queues {
+			close(q)
+		}
+	}
+
+	b.wg.Wait()
+}
+
+func (b *Bus) queueFor(taskID string) chan delivery {
+	h := fnv.New32a()
+	h.Write([]byte(taskID))
+	return b.queues[h.Sum32()%uint32(len(b.queues))]
+}
+
+func (b *Bus) worker(queue chan delivery) {
+	defer b.wg.Done()
+	for d := range queue {
+		dispatch(d.ctx, d.sub, d.event)
+	}
+}
+
+// dispatch изолирует паники обработчика от use case'а и остальных подписчиков
+func dispatch(ctx context.Context, sub subscription, event domain.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("event bus: %s panicked on %s (task %s): %v\n%s",
+				sub.name, event.EventName(), event.TaskID(), r, debug.Stack())
+		}
+	}()
+
+	if err := sub.handle(ctx, event); err != nil {
+		log.Printf("event bus: %s failed on %s (task %s): %v",
+			sub.name, event.EventName(), event.TaskID(), err)
+	}
+}
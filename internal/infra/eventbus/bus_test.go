@@ -0,0 +1,87 @@
+package eventbus
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+func TestFullQueueDoesNotHoldLock(t *testing.T) {
+	ctx := context.Background()
+	b := NewBus()
+
+	release := make(chan struct{})
+	var delivered atomic.Int32
+	b.SubscribeAsync("slow", func(context.Context, domain.Event) error {
+		<-release
+		delivered.Add(1)
+		return nil
+	})
+
+	// Одна задача - одна очередь: воркер занят, очередь заполнена, последняя отправка ждет
+	total := defaultQueueSize + 2
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 0; i < total; i++ {
+			b.Publish(ctx, domain.TaskDeleted{ID: "t", At: time.Now()})
+		}
+	}()
+
+	queue := b.queueFor("t")
+	deadline := time.Now().Add(5 * time.Second)
+	for len(queue) < defaultQueueSize {
+		if time.Now().After(deadline) {
+			t.Fatalf("queue length %d, want full", len(queue))
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Пока отправка ждет места, шина остается доступной
+	subscribed := make(chan struct{})
+	go func() {
+		b.Subscribe("late", func(context.Context, domain.Event) error { return nil })
+		close(subscribed)
+	}()
+	select {
+	case <-subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscribe blocked behind a full queue")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		b.Close()
+		close(closed)
+	}()
+	close(release)
+
+	for _, done := range []chan struct{}{published, closed} {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Publish or Close did not finish")
+		}
+	}
+	if got := delivered.Load(); got != int32(total) {
+		t.Fatalf("delivered %d events, want %d", got, total)
+	}
+}
+
+func TestPublishAfterCloseDropsAsync(t *testing.T) {
+	b := NewBus()
+	var async, sync atomic.Int32
+	b.SubscribeAsync("async", func(context.Context, domain.Event) error { async.Add(1); return nil })
+	b.Subscribe("sync", func(context.Context, domain.Event) error { sync.Add(1); return nil })
+
+	b.Close()
+	b.Close() // повторный Close не паникует
+	b.Publish(context.Background(), domain.TaskDeleted{ID: "t", At: time.Now()})
+
+	if async.Load() != 0 || sync.Load() != 1 {
+		t.Fatalf("async %d, sync %d; want 0 and 1", async.Load(), sync.Load())
+	}
+}
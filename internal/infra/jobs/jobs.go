@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every запускает fn сразу и затем каждые interval до отмены ctx; ошибки только логируются
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("%s: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const sendTimeout = 10 * time.Second
+
+// HTTPSender отправляет payload записи POST-запросом на один адрес
+type HTTPSender struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewHTTPSender(endpoint string) *HTTPSender {
+	return &HTTPSender{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: sendTimeout},
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, entry *domain.OutboxEntry) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(entry.Payload))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPermanent, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", entry.IdempotencyKey)
+	req.Header.Set("X-Event-Type", entry.EventType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return CheckStatus(resp.StatusCode)
+}
+
+// CheckStatus: 2xx - доставлено, 408/429/5xx - повторить, остальные 4xx - dead-letter
+func CheckStatus(code int) error {
+	switch {
+	case code >= 200 && code < 300:
+		return nil
+	case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests, code >= 500:
+		return fmt.Errorf("unexpected status %d", code)
+	default:
+		return fmt.Errorf("%w: status %d", ErrPermanent, code)
+	}
+}
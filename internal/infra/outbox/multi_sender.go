@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// MultiSender отправляет запись всем получателям.
+// При повторе успешные получатели получат событие снова и отбросят его по ключу идемпотентности.
+type MultiSender []Sender
+
+func (m MultiSender) Send(ctx context.Context, entry *domain.OutboxEntry) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Send(ctx, entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return JoinErrors(errs)
+}
+
+// JoinErrors объединяет ошибки доставки; постоянной считается только ошибка,
+// где все получатели отказали окончательно
+func JoinErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	joined := errors.Join(errs...)
+	for _, err := range errs {
+		if !errors.Is(err, ErrPermanent) {
+			return errors.New(joined.Error())
+		}
+	}
+	return joined
+}
@@ -0,0 +1,170 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+const (
+	// leaseDuration должна быть больше таймаута отправки
+	leaseDuration   = time.Minute
+	cleanupInterval = time.Hour
+)
+
+// ErrPermanent - получатель отверг событие, повторять бессмысленно
+var ErrPermanent = errors.New("permanent delivery error")
+
+type Sender interface {
+	Send(ctx context.Context, entry *domain.OutboxEntry) error
+}
+
+// Relay доставляет записи outbox: at-least-once, с экспоненциальной задержкой
+// между попытками и dead-letter после MaxAttempts.
+// Повторы возможны, получатель отсекает их по ключу идемпотентности.
+type Relay struct {
+	repo   domain.OutboxRepository
+	sender Sender
+	cfg    util.OutboxConfig
+	wake   chan struct{}
+}
+
+func NewRelay(repo domain.OutboxRepository, sender Sender, cfg util.OutboxConfig) *Relay {
+	return &Relay{
+		repo:   repo,
+		sender: sender,
+		cfg:    cfg,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+func (r *Relay) Enabled() bool {
+	return r.sender != nil && r.cfg.Interval > 0
+}
+
+// Wake запускает доставку, не дожидаясь тика; подписывается на шину событий
+func (r *Relay) Wake(ctx context.Context, event domain.Event) error {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run блокируется до отмены ctx. События пишутся в outbox всегда,
+// поэтому выключенный релей все равно чистит старые записи.
+func (r *Relay) Run(ctx context.Context) {
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+	r.cleanup(ctx)
+
+	if !r.Enabled() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-cleanup.C:
+				r.cleanup(ctx)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	r.Flush(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Flush(ctx)
+		case <-r.wake:
+			r.Flush(ctx)
+		case <-cleanup.C:
+			r.cleanup(ctx)
+		}
+	}
+}
+
+// Flush отправляет все готовые записи
+func (r *Relay) Flush(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := time.Now()
+		entries, err := r.repo.Claim(ctx, now, now.Add(leaseDuration), r.cfg.BatchSize)
+		if err != nil {
+			log.Printf("outbox: claim: %v", err)
+			return
+		}
+		if len(entries) == 0 {
+			return
+		}
+
+		for _, entry := range entries {
+			r.deliver(ctx, entry)
+		}
+	}
+}
+
+func (r *Relay) deliver(ctx context.Context, entry *domain.OutboxEntry) {
+	sendErr := r.sender.Send(ctx, entry)
+	if ctx.Err() != nil {
+		// Остановка приложения - запись вернется в очередь после аренды
+		return
+	}
+
+	if sendErr == nil {
+		if err := r.repo.MarkDelivered(ctx, entry.ID, time.Now()); err != nil {
+			log.Printf("outbox: mark %d delivered: %v", entry.ID, err)
+		}
+		return
+	}
+
+	status := domain.OutboxPending
+	attempts := entry.Attempts + 1
+	if errors.Is(sendErr, ErrPermanent) || attempts >= r.cfg.MaxAttempts {
+		status = domain.OutboxDead
+		log.Printf("outbox: %s (%s) moved to dead-letter after %d attempts: %v",
+			entry.IdempotencyKey, entry.EventType, attempts, sendErr)
+	}
+
+	next := time.Now().Add(r.backoff(attempts))
+	if err := r.repo.MarkFailed(ctx, entry.ID, status, sendErr.Error(), next); err != nil {
+		log.Printf("outbox: mark %d failed: %v", entry.ID, err)
+	}
+}
+
+// backoff - BaseDelay * 2^(attempts-1) с jitter до 20%, не больше MaxDelay
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.cfg.BaseDelay
+	for i := 1; i < attempts && delay < r.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if r.cfg.MaxDelay > 0 && delay > r.cfg.MaxDelay {
+		delay = r.cfg.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay + rand.N(delay/5+1)
+}
+
+// cleanup удаляет доставленные записи и недоставленные старше Retention:
+// за это время повторы с MaxDelay уже исчерпаны или релей выключен
+func (r *Relay) cleanup(ctx context.Context) {
+	if r.cfg.Retention <= 0 {
+		return
+	}
+	before := time.Now().Add(-r.cfg.Retention)
+	if _, err := r.repo.DeleteDelivered(ctx, before); err != nil {
+		log.Printf("outbox: cleanup: %v", err)
+	}
+	if _, err := r.repo.DeleteStale(ctx, before); err != nil {
+		log.Printf("outbox: cleanup stale: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package outbox
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+// memOutbox - outbox в памяти с теми же правилами выборки, что и SQL
+type memOutbox struct {
+	mu      sync.Mutex
+	entries []*domain.OutboxEntry
+}
+
+func (m *memOutbox) Add(_ context.Context, entry *domain.OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.entries {
+		if e.IdempotencyKey == entry.IdempotencyKey {
+			return domain.ErrDuplicateEvent
+		}
+	}
+	entry.ID = int64(len(m.entries) + 1)
+	entry.Status = domain.OutboxPending
+	entry.NextAttemptAt = entry.CreatedAt
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *memOutbox) Claim(_ context.Context, now, leaseUntil time.Time, limit int) ([]*domain.OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var claimed []*domain.OutboxEntry
+	for _, e := range m.entries {
+		if len(claimed) == limit {
+			break
+		}
+		if e.Status == domain.OutboxPending && !e.NextAttemptAt.After(now) {
+			e.NextAttemptAt = leaseUntil
+			copied := *e
+			claimed = append(claimed, &copied)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *memOutbox) MarkDelivered(_ context.Context, id int64, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.entries[id-1]
+	e.Status, e.Attempts, e.LastError, e.DeliveredAt = domain.OutboxDelivered, e.Attempts+1, "", &at
+	return nil
+}
+
+func (m *memOutbox) MarkFailed(_ context.Context, id int64, status domain.OutboxStatus, lastError string, next time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.entries[id-1]
+	e.Status, e.Attempts, e.LastError, e.NextAttemptAt = status, e.Attempts+1, lastError, next
+	return nil
+}
+
+func (m *memOutbox) ListByStatus(context.Context, domain.OutboxStatus, int) ([]*domain.OutboxEntry, error) {
+	return nil, nil
+}
+
+func (m *memOutbox) Requeue(context.Context, int64, time.Time) error { return nil }
+
+func (m *memOutbox) DeleteDelivered(_ context.Context, before time.Time) (int64, error) {
+	return m.deleteWhere(func(e *domain.OutboxEntry) bool {
+		return e.Status == domain.OutboxDelivered && e.DeliveredAt.Before(before)
+	}), nil
+}
+
+func (m *memOutbox) DeleteStale(_ context.Context, before time.Time) (int64, error) {
+	return m.deleteWhere(func(e *domain.OutboxEntry) bool {
+		return e.Status != domain.OutboxDelivered && e.CreatedAt.Before(before)
+	}), nil
+}
+
+func (m *memOutbox) deleteWhere(match func(e *domain.OutboxEntry) bool) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int64
+	for _, e := range m.entries {
+		if e.Status != "" && match(e) {
+			e.Status = "" // ID - индекс в срезе, поэтому запись только помечается удаленной
+			n++
+		}
+	}
+	return n
+}
+
+func (m *memOutbox) get(id int64) domain.OutboxEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return *m.entries[id-1]
+}
+
+func testConfig() util.OutboxConfig {
+	return util.OutboxConfig{Interval: time.Second, BatchSize: 10, MaxAttempts: 3, Retention: time.Hour}
+}
+
+func addEntry(t *testing.T, repo *memOutbox, key string, createdAt time.Time) int64 {
+	t.Helper()
+	entry := &domain.OutboxEntry{
+		IdempotencyKey: key,
+		EventType:      domain.EventTaskCreated,
+		TaskID:         "task",
+		Payload:        []byte(`{"id":"` + key + `"}`),
+		CreatedAt:      createdAt,
+	}
+	if err := repo.Add(context.Background(), entry); err != nil {
+		t.Fatal(err)
+	}
+	return entry.ID
+}
+
+func TestRelayDeliversOverHTTP(t *testing.T) {
+	var got *http.Request
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got, body = r, string(b)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	repo := &memOutbox{}
+	id := addEntry(t, repo, "evt_1", time.Now())
+	NewRelay(repo, NewHTTPSender(srv.URL), testConfig()).Flush(context.Background())
+
+	if got == nil {
+		t.Fatal("nothing was sent")
+	}
+	if got.Header.Get("Idempotency-Key") != "evt_1" || got.Header.Get("X-Event-Type") != domain.EventTaskCreated {
+		t.Fatalf("headers = %v", got.Header)
+	}
+	if body != `{"id":"evt_1"}` {
+		t.Fatalf("body = %q", body)
+	}
+	if e := repo.get(id); e.Status != domain.OutboxDelivered || e.Attempts != 1 {
+		t.Fatalf("entry status %q, attempts %d; want delivered after 1", e.Status, e.Attempts)
+	}
+}
+
+func TestRelayRetriesThenDeadLetters(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         int
+		wantRequests int
+	}{
+		{"server error is retried", http.StatusServiceUnavailable, 3},
+		{"client error is permanent", http.StatusBadRequest, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests++
+				mu.Unlock()
+				w.WriteHeader(tt.code)
+			}))
+			defer srv.Close()
+
+			repo := &memOutbox{}
+			id := addEntry(t, repo, "evt_1", time.Now())
+			// Нулевая задержка: повтор готов сразу, и Flush доходит до dead-letter
+			NewRelay(repo, NewHTTPSender(srv.URL), testConfig()).Flush(context.Background())
+
+			if requests != tt.wantRequests {
+				t.Fatalf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if e := repo.get(id); e.Status != domain.OutboxDead || e.Attempts != tt.wantRequests || e.LastError == "" {
+				t.Fatalf("entry status %q, attempts %d, error %q", e.Status, e.Attempts, e.LastError)
+			}
+		})
+	}
+}
+
+func TestRelayBackoffGrowsUpToMax(t *testing.T) {
+	r := NewRelay(&memOutbox{}, nil, util.OutboxConfig{BaseDelay: time.Second, MaxDelay: 5 * time.Second})
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: 5 * time.Second} {
+		if got := r.backoff(attempts); got < want || got > want+want/5 {
+			t.Errorf("backoff(%d) = %v, want %v plus up to 20%%", attempts, got, want)
+		}
+	}
+}
+
+func TestDisabledRelayStillCleansUp(t *testing.T) {
+	repo := &memOutbox{}
+	old := addEntry(t, repo, "evt_old", time.Now().Add(-2*time.Hour))
+	fresh := addEntry(t, repo, "evt_fresh", time.Now())
+
+	cfg := testConfig()
+	cfg.Interval = 0
+	r := NewRelay(repo, nil, cfg)
+	if r.Enabled() {
+		t.Fatal("relay without sender is enabled")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r.Run(ctx)
+
+	if e := repo.get(old); e.Status != "" {
+		t.Fatalf("stale pending entry kept: %q", e.Status)
+	}
+	if e := repo.get(fresh); e.Status != domain.OutboxPending {
+		t.Fatalf("fresh entry status %q, want pending", e.Status)
+	}
+}
@@ -0,0 +1,183 @@
+package pomodoro
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+// Runner ведет один цикл помидоров: переключает фазы по таймеру,
+// пишет рабочие фазы в журнал и сообщает о каждой смене фазы
+type Runner struct {
+	getTask    app.GetTask
+	logSession app.LogFocusSession
+	settings   domain.PomodoroSettings
+
+	mu      sync.Mutex
+	current *domain.Pomodoro
+	timer   *time.Timer
+	notify  func(app.PomodoroState)
+}
+
+func NewRunner(getTask app.GetTask, logSession app.LogFocusSession, cfg util.PomodoroConfig) *Runner {
+	return &Runner{
+		getTask:    getTask,
+		logSession: logSession,
+		settings: domain.PomodoroSettings{
+			Work:           cfg.Work,
+			ShortBreak:     cfg.ShortBreak,
+			LongBreak:      cfg.LongBreak,
+			LongBreakEvery: cfg.LongBreakEvery,
+		},
+	}
+}
+
+// SetNotifier задает получателя смен фазы; fn не должен обращаться к Runner
+func (r *Runner) SetNotifier(fn func(app.PomodoroState)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notify = fn
+}
+
+// Start начинает рабочую фазу задачи; идущий цикл прерывается
+func (r *Runner) Start(ctx context.Context, taskID string) (app.PomodoroState, error) {
+	if err := r.settings.IsValid(); err != nil {
+		return app.PomodoroState{}, err
+	}
+	if _, err := r.getTask.Execute(ctx, app.GetTaskInput{ID: taskID}); err != nil {
+		return app.PomodoroState{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	previous := domain.PhaseIdle
+	if r.current != nil {
+		previous = r.current.Phase
+		r.logLocked(r.current.Stop(r.settings, now))
+	}
+
+	r.current = domain.StartPomodoro(taskID, r.settings, now)
+	r.scheduleLocked()
+	return r.emitLocked(previous, now), nil
+}
+
+// Skip досрочно завершает текущую фазу
+func (r *Runner) Skip(ctx context.Context) (app.PomodoroState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current == nil {
+		return app.PomodoroState{}, domain.ErrPomodoroNotRunning
+	}
+	return r.advanceLocked(time.Now()), nil
+}
+
+func (r *Runner) Stop(ctx context.Context) (app.PomodoroState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current == nil {
+		return app.PomodoroState{}, domain.ErrPomodoroNotRunning
+	}
+
+	now := time.Now()
+	previous := r.current.Phase
+	r.logLocked(r.current.Stop(r.settings, now))
+	r.current = nil
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	return r.emitLocked(previous, now), nil
+}
+
+func (r *Runner) State() app.PomodoroState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stateLocked(time.Now())
+}
+
+// Close останавливает таймер без записи в журнал; незавершенная фаза теряется
+func (r *Runner) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.current = nil
+}
+
+func (r *Runner) advanceLocked(now time.Time) app.PomodoroState {
+	previous := r.current.Phase
+	r.logLocked(r.current.Advance(r.settings, now))
+	r.scheduleLocked()
+	return r.emitLocked(previous, now)
+}
+
+func (r *Runner) scheduleLocked() {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+
+	current := r.current
+	r.timer = time.AfterFunc(time.Until(current.EndsAt), func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		// Фазу уже сменили вручную
+		if r.current != current || time.Now().Before(current.EndsAt) {
+			return
+		}
+		r.advanceLocked(current.EndsAt)
+	})
+}
+
+// logLocked пишет сессию в фоне, чтобы не держать блокировку на время запроса к БД
+func (r *Runner) logLocked(session *domain.FocusSession) {
+	if session == nil {
+		return
+	}
+	go func() {
+		if err := r.logSession.Execute(context.Background(), session); err != nil {
+			log.Printf("pomodoro: log session: %v", err)
+		}
+	}()
+}
+
+func (r *Runner) emitLocked(previous domain.PomodoroPhase, now time.Time) app.PomodoroState {
+	state := r.stateLocked(now)
+	state.Previous = previous
+	// Синхронно, чтобы события приходили в порядке смены фаз
+	if r.notify != nil {
+		r.notify(state)
+	}
+	return state
+}
+
+func (r *Runner) stateLocked(now time.Time) app.PomodoroState {
+	if r.current == nil {
+		return app.PomodoroState{Phase: domain.PhaseIdle}
+	}
+
+	startedAt, endsAt := r.current.StartedAt, r.current.EndsAt
+	remaining := endsAt.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return app.PomodoroState{
+		TaskID:           r.current.TaskID,
+		Phase:            r.current.Phase,
+		Cycle:            r.current.Cycle,
+		StartedAt:        &startedAt,
+		EndsAt:           &endsAt,
+		RemainingSeconds: int64(remaining / time.Second),
+	}
+}
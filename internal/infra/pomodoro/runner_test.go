@@ -0,0 +1,122 @@
+package pomodoro
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/testutil"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+func newTestRunner(repo *testutil.Repo) (*Runner, <-chan app.PomodoroState) {
+	r := NewRunner(app.NewGetTask(repo), app.NewLogFocusSession(repo), util.PomodoroConfig{
+		Work:           40 * time.Millisecond,
+		ShortBreak:     20 * time.Millisecond,
+		LongBreak:      30 * time.Millisecond,
+		LongBreakEvery: 2,
+	})
+	states := make(chan app.PomodoroState, 16)
+	r.SetNotifier(func(state app.PomodoroState) { states <- state })
+	return r, states
+}
+
+func nextState(t *testing.T, states <-chan app.PomodoroState) app.PomodoroState {
+	t.Helper()
+	select {
+	case state := <-states:
+		return state
+	case <-time.After(time.Second):
+		t.Fatal("no phase change")
+		return app.PomodoroState{}
+	}
+}
+
+func TestRunnerAdvancesPhasesOnTimer(t *testing.T) {
+	repo := testutil.NewRepo()
+	repo.Put(&domain.Task{ID: "t1", Title: "Focus", Status: domain.StatusActive})
+	r, states := newTestRunner(repo)
+	defer r.Close()
+
+	if _, err := r.Start(context.Background(), "t1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		previous, phase domain.PomodoroPhase
+		cycle           int
+	}{
+		{domain.PhaseIdle, domain.PhaseWork, 0},
+		{domain.PhaseWork, domain.PhaseShortBreak, 1},
+		{domain.PhaseShortBreak, domain.PhaseWork, 1},
+		{domain.PhaseWork, domain.PhaseLongBreak, 2},
+		{domain.PhaseLongBreak, domain.PhaseWork, 2},
+	}
+	for i, w := range want {
+		state := nextState(t, states)
+		if state.Previous != w.previous || state.Phase != w.phase || state.Cycle != w.cycle || state.TaskID != "t1" {
+			t.Fatalf("change %d = %+v, want %+v", i, state, w)
+		}
+	}
+
+	// Остановка посреди работы пишет прерванную сессию
+	state, err := r.Stop(context.Background())
+	if err != nil || state.Phase != domain.PhaseIdle || state.Previous != domain.PhaseWork {
+		t.Fatalf("stop = %+v, %v", state, err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(repo.LoggedSessions()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	sessions := repo.LoggedSessions()
+	if len(sessions) != 3 {
+		t.Fatalf("logged %d sessions, want 3", len(sessions))
+	}
+	completed := 0
+	for _, session := range sessions {
+		if session.Completed {
+			completed++
+		}
+	}
+	if completed != 2 {
+		t.Fatalf("completed sessions = %d, want two full ones and one interrupted", completed)
+	}
+}
+
+func TestRunnerSkipAndErrors(t *testing.T) {
+	repo := testutil.NewRepo()
+	repo.Put(&domain.Task{ID: "t1", Title: "Focus", Status: domain.StatusActive})
+	r, states := newTestRunner(repo)
+	defer r.Close()
+	ctx := context.Background()
+
+	if _, err := r.Skip(ctx); !errors.Is(err, domain.ErrPomodoroNotRunning) {
+		t.Fatalf("skip when idle: err = %v", err)
+	}
+	if _, err := r.Start(ctx, "missing"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("start unknown task: err = %v", err)
+	}
+
+	if _, err := r.Start(ctx, "t1"); err != nil {
+		t.Fatal(err)
+	}
+	nextState(t, states)
+	state, err := r.Skip(ctx)
+	if err != nil || state.Previous != domain.PhaseWork || state.Phase != domain.PhaseShortBreak || state.Cycle != 1 {
+		t.Fatalf("skip work = %+v, %v", state, err)
+	}
+	if got := nextState(t, states); got.Phase != domain.PhaseShortBreak {
+		t.Fatalf("notified %+v, want the skip", got)
+	}
+	// Старый таймер рабочей фазы не должен сработать поверх перерыва
+	if got := nextState(t, states); got.Previous != domain.PhaseShortBreak || got.Phase != domain.PhaseWork {
+		t.Fatalf("after break = %+v", got)
+	}
+	if r.State().Phase != domain.PhaseWork {
+		t.Fatalf("state = %+v", r.State())
+	}
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type archiveRepository struct {
+	queries  *db.Queries
+	toDomain func(db.Task) *domain.Task
+}
+
+func (r *archiveRepository) Archive(ctx context.Context, before, at time.Time) (int, error) {
+	n, err := r.queries.ArchiveTasks(ctx, db.ArchiveTasksParams{
+		ArchivedAt:      timestamp(at),
+		CompletedBefore: timestamp(before),
+	})
+	return int(n), err
+}
+
+func (r *archiveRepository) List(ctx context.Context, filter domain.ArchiveFilter) ([]*domain.Task, error) {
+	pattern, project := archiveFilterParams(filter)
+	params := db.ListArchivedTasksParams{
+		Pattern:    pattern,
+		Project:    project,
+		PageOffset: int32(filter.Offset),
+	}
+	if filter.Limit > 0 {
+		params.PageLimit = pgtype.Int4{Int32: int32(filter.Limit), Valid: true}
+	}
+
+	rows, err := r.queries.ListArchivedTasks(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(rows))
+	for _, row := range rows {
+		tasks = append(tasks, r.toDomain(row))
+	}
+	return tasks, nil
+}
+
+func (r *archiveRepository) Count(ctx context.Context, filter domain.ArchiveFilter) (int, error) {
+	pattern, project := archiveFilterParams(filter)
+	n, err := r.queries.CountArchivedTasks(ctx, db.CountArchivedTasksParams{Pattern: pattern, Project: project})
+	return int(n), err
+}
+
+func (r *archiveRepository) GetByStatus(ctx context.Context, status domain.TaskStatus) ([]*domain.Task, error) {
+	rows, err := r.queries.GetArchivedTasksByStatus(ctx, string(status))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(rows))
+	for _, row := range rows {
+		tasks = append(tasks, r.toDomain(row))
+	}
+	return tasks, nil
+}
+
+func (r *archiveRepository) Restore(ctx context.Context, taskID string, at time.Time) error {
+	return r.queries.RestoreArchivedTask(ctx, db.RestoreArchivedTaskParams{
+		ArchivedAt: timestamp(at),
+		ID:         taskID,
+	})
+}
+
+// likeEscaper экранирует спецсимволы ILIKE, чтобы запрос искал подстроку как есть
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func archiveFilterParams(filter domain.ArchiveFilter) (pattern, project pgtype.Text) {
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		pattern = pgtype.Text{String: "%" + likeEscaper.Replace(q) + "%", Valid: true}
+	}
+	if filter.Project != nil {
+		project = pgtype.Text{String: *filter.Project, Valid: true}
+	}
+	return pattern, project
+}
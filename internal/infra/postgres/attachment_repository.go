@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type attachmentRepository struct {
+	queries *db.Queries
+}
+
+func (r *attachmentRepository) Save(ctx context.Context, a *domain.Attachment) error {
+	return r.queries.SaveAttachment(ctx, db.SaveAttachmentParams{
+		ID:        a.ID,
+		TaskID:    a.TaskID,
+		Name:      a.Name,
+		MimeType:  a.MimeType,
+		Size:      a.Size,
+		Hash:      a.Hash,
+		CreatedAt: timestamp(a.CreatedAt),
+	})
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	row, err := r.queries.GetAttachmentByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return convertDBAttachment(row), nil
+}
+
+func (r *attachmentRepository) ListByTask(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	rows, err := r.queries.ListAttachmentsByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*domain.Attachment, 0, len(rows))
+	for _, row := range rows {
+		attachments = append(attachments, convertDBAttachment(row))
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) GetAll(ctx context.Context) ([]*domain.Attachment, error) {
+	rows, err := r.queries.ListAllAttachments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*domain.Attachment, 0, len(rows))
+	for _, row := range rows {
+		attachments = append(attachments, convertDBAttachment(row))
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) ReferencedHashes(ctx context.Context) (map[string]bool, error) {
+	hashes, err := r.queries.ListAttachmentHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		referenced[hash] = true
+	}
+	return referenced, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id string) error {
+	n, err := r.queries.DeleteAttachment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func convertDBAttachment(row db.Attachment) *domain.Attachment {
+	return &domain.Attachment{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		Name:      row.Name,
+		MimeType:  row.MimeType,
+		Size:      row.Size,
+		Hash:      row.Hash,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
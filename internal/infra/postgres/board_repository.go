@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type boardRepository struct {
+	queries *db.Queries
+}
+
+func (r *boardRepository) Lock(ctx context.Context) error {
+	return r.queries.LockBoard(ctx)
+}
+
+func (r *boardRepository) Positions(ctx context.Context, grouping domain.BoardGrouping) (map[string]int, error) {
+	rows, err := r.queries.ListBoardPositions(ctx, string(grouping))
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[string]int, len(rows))
+	for _, row := range rows {
+		positions[row.TaskID] = int(row.Position)
+	}
+	return positions, nil
+}
+
+func (r *boardRepository) SetPositions(ctx context.Context, grouping domain.BoardGrouping, taskIDs []string) error {
+	for i, id := range taskIDs {
+		err := r.queries.SetBoardPosition(ctx, db.SetBoardPositionParams{
+			GroupBy:  string(grouping),
+			TaskID:   id,
+			Position: int32(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *boardRepository) WIPLimits(ctx context.Context, grouping domain.BoardGrouping) (map[string]int, error) {
+	rows, err := r.queries.ListBoardWIPLimits(ctx, string(grouping))
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[string]int, len(rows))
+	for _, row := range rows {
+		limits[row.ColumnKey] = int(row.WipLimit)
+	}
+	return limits, nil
+}
+
+func (r *boardRepository) SetWIPLimit(ctx context.Context, grouping domain.BoardGrouping, column string, limit int) error {
+	if limit <= 0 {
+		return r.queries.DeleteBoardWIPLimit(ctx, db.DeleteBoardWIPLimitParams{
+			GroupBy:   string(grouping),
+			ColumnKey: column,
+		})
+	}
+	return r.queries.SetBoardWIPLimit(ctx, db.SetBoardWIPLimitParams{
+		GroupBy:   string(grouping),
+		ColumnKey: column,
+		WipLimit:  int32(limit),
+	})
+}
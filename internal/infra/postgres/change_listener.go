@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// Канал, в который пишет триггер tasks_notify_change
+const taskChangesChannel = "task_changes"
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+	// Окно, за которое уведомления собираются в одну пачку: массовые правки
+	// (импорт, перенос статусов) не должны перерисовывать UI на каждую строку
+	changeDebounce = 200 * time.Millisecond
+)
+
+// notifyConn - соединение в режиме LISTEN, его реализует *pgx.Conn
+type notifyConn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+// ChangeListener слушает NOTIFY от триггера и переводит их в domain.TaskChange
+type ChangeListener struct {
+	connect  func(ctx context.Context) (notifyConn, error)
+	debounce time.Duration
+	minDelay time.Duration
+	maxDelay time.Duration
+}
+
+func NewChangeListener(pool *pgxpool.Pool) *ChangeListener {
+	return &ChangeListener{
+		connect: func(ctx context.Context) (notifyConn, error) {
+			pooled, err := pool.Acquire(ctx)
+			if err != nil {
+				return nil, err
+			}
+			// Соединение в режиме LISTEN не возвращаем в пул. После Hijack пул
+			// от него отказывается, дальше работаем только с самим *pgx.Conn.
+			return pooled.Hijack(), nil
+		},
+		debounce: changeDebounce,
+		minDelay: minReconnectDelay,
+		maxDelay: maxReconnectDelay,
+	}
+}
+
+// Listen блокируется до отмены ctx, при обрыве соединения переподключается.
+// Уведомления, пришедшие в пределах debounce от первого, отдаются одной пачкой,
+// по последнему изменению на задачу.
+func (l *ChangeListener) Listen(ctx context.Context, fn func([]domain.TaskChange)) {
+	delay := l.minDelay
+
+	for {
+		err := l.listen(ctx, fn, func() { delay = l.minDelay })
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("change listener: %v, reconnecting in %s", err, delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > l.maxDelay {
+			delay = l.maxDelay
+		}
+	}
+}
+
+func (l *ChangeListener) listen(ctx context.Context, fn func([]domain.TaskChange), connected func()) error {
+	conn, err := l.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+taskChangesChannel); err != nil {
+		return err
+	}
+	connected()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var batch changeBatch
+		batch.add(n.Payload)
+
+		// Таймаут ожидания соединение не рвет, поэтому окно - просто дедлайн чтения
+		window, cancel := context.WithTimeout(ctx, l.debounce)
+		for err == nil {
+			if n, err = conn.WaitForNotification(window); err == nil {
+				batch.add(n.Payload)
+			}
+		}
+		expired := window.Err() != nil && ctx.Err() == nil
+		cancel()
+
+		if len(batch.changes) > 0 {
+			fn(batch.changes)
+		}
+		if !expired {
+			return err
+		}
+	}
+}
+
+// changeBatch - изменения за окно debounce, по последнему на задачу
+type changeBatch struct {
+	changes []domain.TaskChange
+	index   map[string]int
+}
+
+func (b *changeBatch) add(payload string) {
+	var change domain.TaskChange
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		log.Printf("change listener: invalid payload %q: %v", payload, err)
+		return
+	}
+
+	if i, ok := b.index[change.TaskID]; ok {
+		b.changes[i] = change
+		return
+	}
+	if b.index == nil {
+		b.index = make(map[string]int)
+	}
+	b.index[change.TaskID] = len(b.changes)
+	b.changes = append(b.changes, change)
+}
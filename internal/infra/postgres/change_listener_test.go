@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// fakeConn отдает уведомления и ошибки из канала в порядке поступления
+type fakeConn struct {
+	events chan any // *pgconn.Notification или error
+}
+
+func newFakeConn(events ...any) *fakeConn {
+	c := &fakeConn{events: make(chan any, 16)}
+	for _, ev := range events {
+		c.events <- ev
+	}
+	return c
+}
+
+func (c *fakeConn) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (c *fakeConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case ev := <-c.events:
+		if err, ok := ev.(error); ok {
+			return nil, err
+		}
+		return ev.(*pgconn.Notification), nil
+	}
+}
+
+func (c *fakeConn) Close(context.Context) error { return nil }
+
+func notification(payload string) *pgconn.Notification {
+	return &pgconn.Notification{Channel: taskChangesChannel, Payload: payload}
+}
+
+func testListener(conns ...func() (notifyConn, error)) *ChangeListener {
+	var mu sync.Mutex
+	return &ChangeListener{
+		connect: func(ctx context.Context) (notifyConn, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			next := conns[0]
+			if len(conns) > 1 {
+				conns = conns[1:]
+			}
+			return next()
+		},
+		debounce: 50 * time.Millisecond,
+		minDelay: time.Millisecond,
+		maxDelay: 4 * time.Millisecond,
+	}
+}
+
+func TestChangeListenerCoalescesBurst(t *testing.T) {
+	conn := newFakeConn(
+		notification(`{"op":"insert","id":"t1","version":1}`),
+		notification(`{"op":"update","id":"t2","version":4}`),
+		notification(`not json`),
+		notification(`{"op":"update","id":"t1","version":2}`),
+	)
+	l := testListener(func() (notifyConn, error) { return conn, nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	batches := make(chan []domain.TaskChange, 4)
+	go l.Listen(ctx, func(changes []domain.TaskChange) { batches <- changes })
+
+	want := []domain.TaskChange{
+		{Op: "update", TaskID: "t1", Version: 2},
+		{Op: "update", TaskID: "t2", Version: 4},
+	}
+	if got := <-batches; !slices.Equal(got, want) {
+		t.Fatalf("first batch = %+v, want %+v", got, want)
+	}
+
+	// Уведомление после окна - уже следующая пачка
+	conn.events <- notification(`{"op":"delete","id":"t2","version":5}`)
+	select {
+	case got := <-batches:
+		if !slices.Equal(got, []domain.TaskChange{{Op: "delete", TaskID: "t2", Version: 5}}) {
+			t.Fatalf("second batch = %+v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second batch not delivered")
+	}
+}
+
+func TestChangeListenerReconnectsWithBackoff(t *testing.T) {
+	var logs syncBuffer
+	prev := log.Writer()
+	log.SetOutput(&logs)
+	defer log.SetOutput(prev)
+
+	refused := func() (notifyConn, error) { return nil, errors.New("connection refused") }
+	l := testListener(
+		refused, refused, refused, refused,
+		// Соединилось и оборвалось: задержка снова минимальная
+		func() (notifyConn, error) { return newFakeConn(errors.New("conn closed")), nil },
+		func() (notifyConn, error) {
+			return newFakeConn(notification(`{"op":"insert","id":"t1","version":1}`)), nil
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	batches := make(chan []domain.TaskChange, 1)
+	go func() {
+		l.Listen(ctx, func(changes []domain.TaskChange) { batches <- changes })
+		close(done)
+	}()
+
+	select {
+	case got := <-batches:
+		if len(got) != 1 || got[0].TaskID != "t1" {
+			t.Fatalf("batch = %+v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no changes after reconnect")
+	}
+	cancel()
+	<-done
+
+	var delays []string
+	for _, m := range regexp.MustCompile(`reconnecting in (\S+)`).FindAllStringSubmatch(logs.String(), -1) {
+		delays = append(delays, m[1])
+	}
+	if want := []string{"1ms", "2ms", "4ms", "4ms", "1ms"}; !slices.Equal(delays, want) {
+		t.Fatalf("reconnect delays = %v, want %v", delays, want)
+	}
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
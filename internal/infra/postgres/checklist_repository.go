@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type checklistRepository struct {
+	queries *db.Queries
+}
+
+func (r *checklistRepository) Save(ctx context.Context, item *domain.ChecklistItem) error {
+	params := db.SaveChecklistItemParams{
+		ID:        item.ID,
+		TaskID:    item.TaskID,
+		Title:     item.Title,
+		Checked:   item.Checked,
+		Position:  int32(item.Position),
+		CreatedAt: timestamp(item.CreatedAt),
+	}
+	if item.CheckedAt != nil {
+		params.CheckedAt = timestamp(*item.CheckedAt)
+	}
+
+	if err := r.queries.SaveChecklistItem(ctx, params); err != nil {
+		return err
+	}
+	return r.queries.RefreshTaskChecklist(ctx, item.TaskID)
+}
+
+func (r *checklistRepository) GetByID(ctx context.Context, id string) (*domain.ChecklistItem, error) {
+	row, err := r.queries.GetChecklistItemByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrChecklistItemNotFound
+		}
+		return nil, err
+	}
+	return convertDBChecklistItem(row), nil
+}
+
+func (r *checklistRepository) ListByTask(ctx context.Context, taskID string) ([]*domain.ChecklistItem, error) {
+	rows, err := r.queries.ListChecklistItemsByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.ChecklistItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, convertDBChecklistItem(row))
+	}
+	return items, nil
+}
+
+func (r *checklistRepository) GetAll(ctx context.Context) ([]*domain.ChecklistItem, error) {
+	rows, err := r.queries.ListAllChecklistItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.ChecklistItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, convertDBChecklistItem(row))
+	}
+	return items, nil
+}
+
+func (r *checklistRepository) SetPositions(ctx context.Context, taskID string, itemIDs []string) error {
+	for i, id := range itemIDs {
+		err := r.queries.SetChecklistItemPosition(ctx, db.SetChecklistItemPositionParams{
+			ID:       id,
+			TaskID:   taskID,
+			Position: int32(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *checklistRepository) CheckAll(ctx context.Context, taskID string, at time.Time) (int, error) {
+	n, err := r.queries.CheckAllChecklistItems(ctx, db.CheckAllChecklistItemsParams{
+		TaskID:    taskID,
+		CheckedAt: timestamp(at),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return int(n), r.queries.RefreshTaskChecklist(ctx, taskID)
+}
+
+func (r *checklistRepository) Delete(ctx context.Context, id string) error {
+	item, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.queries.DeleteChecklistItem(ctx, id); err != nil {
+		return err
+	}
+	return r.queries.RefreshTaskChecklist(ctx, item.TaskID)
+}
+
+func convertDBChecklistItem(row db.ChecklistItem) *domain.ChecklistItem {
+	item := &domain.ChecklistItem{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		Title:     row.Title,
+		Checked:   row.Checked,
+		Position:  int(row.Position),
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.CheckedAt.Valid {
+		item.CheckedAt = &row.CheckedAt.Time
+	}
+	return item
+}
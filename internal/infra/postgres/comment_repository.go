@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type commentRepository struct {
+	queries *db.Queries
+}
+
+func (r *commentRepository) Save(ctx context.Context, c *domain.Comment) error {
+	return r.queries.SaveComment(ctx, db.SaveCommentParams{
+		ID:        c.ID,
+		TaskID:    c.TaskID,
+		Author:    c.Author,
+		Body:      c.Body,
+		CreatedAt: timestamp(c.CreatedAt),
+		UpdatedAt: timestamp(c.UpdatedAt),
+	})
+}
+
+func (r *commentRepository) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	row, err := r.queries.GetCommentByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return convertDBComment(row), nil
+}
+
+func (r *commentRepository) GetAll(ctx context.Context) ([]*domain.Comment, error) {
+	rows, err := r.queries.ListAllComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*domain.Comment, 0, len(rows))
+	for _, row := range rows {
+		comments = append(comments, convertDBComment(row))
+	}
+	return comments, nil
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id string) error {
+	n, err := r.queries.DeleteComment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+func convertDBComment(row db.TaskComment) *domain.Comment {
+	return &domain.Comment{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		Author:    row.Author,
+		Body:      row.Body,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+type activityRepository struct {
+	queries *db.Queries
+}
+
+func (r *activityRepository) Add(ctx context.Context, entry *domain.ActivityEntry) error {
+	return r.queries.AddTaskActivity(ctx, db.AddTaskActivityParams{
+		TaskID:     entry.TaskID,
+		EventType:  entry.EventType,
+		Snapshot:   entry.Snapshot,
+		OccurredAt: timestamp(entry.OccurredAt),
+	})
+}
+
+func (r *activityRepository) Timeline(ctx context.Context, taskID string, limit, offset int) ([]domain.TimelineEntry, error) {
+	rows, err := r.queries.ListTaskTimeline(ctx, db.ListTaskTimelineParams{
+		TaskID: taskID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.TimelineEntry, 0, len(rows))
+	for _, row := range rows {
+		if row.Kind == "comment" {
+			entries = append(entries, domain.TimelineEntry{Comment: &domain.Comment{
+				ID:        row.ID,
+				TaskID:    taskID,
+				Author:    row.Author,
+				Body:      row.Body,
+				CreatedAt: row.At.Time,
+				UpdatedAt: row.UpdatedAt.Time,
+			}})
+			continue
+		}
+
+		entries = append(entries, domain.TimelineEntry{
+			Activity: &domain.ActivityEntry{
+				ID:         row.Seq,
+				TaskID:     taskID,
+				EventType:  row.EventType,
+				Snapshot:   row.Snapshot,
+				OccurredAt: row.At.Time,
+			},
+			Previous: row.Previous,
+		})
+	}
+	return entries, nil
+}
+
+func (r *activityRepository) CountTimeline(ctx context.Context, taskID string) (int, error) {
+	total, err := r.queries.CountTaskTimeline(ctx, taskID)
+	if err != nil {
+		return 0, err
+	}
+	return int(total), nil
+}
+
+func (r *activityRepository) GetAll(ctx context.Context) ([]*domain.ActivityEntry, error) {
+	rows, err := r.queries.ListAllTaskActivity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.ActivityEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, &domain.ActivityEntry{
+			ID:         row.ID,
+			TaskID:     row.TaskID,
+			EventType:  row.EventType,
+			Snapshot:   row.Snapshot,
+			OccurredAt: row.OccurredAt.Time,
+		})
+	}
+	return entries, nil
+}
+
+func (r *activityRepository) Restore(ctx context.Context, entry *domain.ActivityEntry) error {
+	return r.queries.RestoreTaskActivity(ctx, db.RestoreTaskActivityParams{
+		TaskID:     entry.TaskID,
+		EventType:  entry.EventType,
+		Snapshot:   entry.Snapshot,
+		OccurredAt: timestamp(entry.OccurredAt),
+	})
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type customFieldRepository struct {
+	queries  *db.Queries
+	toDomain func(db.Task) *domain.Task
+}
+
+func (r *customFieldRepository) List(ctx context.Context) ([]domain.CustomField, error) {
+	rows, err := r.queries.ListCustomFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]domain.CustomField, 0, len(rows))
+	for _, row := range rows {
+		fields = append(fields, convertDBCustomField(row))
+	}
+	return fields, nil
+}
+
+func (r *customFieldRepository) Get(ctx context.Context, key string) (domain.CustomField, error) {
+	row, err := r.queries.GetCustomField(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CustomField{}, domain.ErrCustomFieldNotFound
+		}
+		return domain.CustomField{}, err
+	}
+	return convertDBCustomField(row), nil
+}
+
+func (r *customFieldRepository) Save(ctx context.Context, f domain.CustomField) error {
+	params := db.SaveCustomFieldParams{
+		Key:      f.Key,
+		Project:  f.Project,
+		Name:     f.Name,
+		Type:     string(f.Type),
+		Options:  f.Options,
+		Required: f.Required,
+		Pattern:  f.Pattern,
+		Position: int32(f.Position),
+	}
+	if params.Options == nil {
+		params.Options = []string{}
+	}
+	if f.Min != nil {
+		params.MinValue = pgtype.Float8{Float64: *f.Min, Valid: true}
+	}
+	if f.Max != nil {
+		params.MaxValue = pgtype.Float8{Float64: *f.Max, Valid: true}
+	}
+	return r.queries.SaveCustomField(ctx, params)
+}
+
+func (r *customFieldRepository) Delete(ctx context.Context, key string) error {
+	n, err := r.queries.DeleteCustomField(ctx, key)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrCustomFieldNotFound
+	}
+	return nil
+}
+
+func (r *customFieldRepository) TasksWithValue(ctx context.Context, key string) ([]*domain.Task, error) {
+	rows, err := r.queries.GetTasksWithCustomField(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(rows))
+	for _, row := range rows {
+		tasks = append(tasks, r.toDomain(row))
+	}
+	return tasks, nil
+}
+
+func (r *customFieldRepository) FindTasks(ctx context.Context, q domain.CustomFieldQuery) ([]*domain.Task, error) {
+	contains, err := encodeCustomValues(q.Contains)
+	if err != nil {
+		return nil, err
+	}
+	// Каждое исключение - отдельный объект {key: value}, задача не должна содержать ни один
+	excludes := make([]map[string]any, 0, len(q.Excludes))
+	for key, value := range q.Excludes {
+		excludes = append(excludes, map[string]any{key: value})
+	}
+	excludesJSON, err := json.Marshal(excludes)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]string, 0, len(q.Statuses))
+	for _, status := range q.Statuses {
+		statuses = append(statuses, string(status))
+	}
+
+	rows, err := r.queries.FindTasksByCustomFields(ctx, db.FindTasksByCustomFieldsParams{
+		Statuses: statuses,
+		Contains: contains,
+		Present:  nonNilStrings(q.Present),
+		Absent:   nonNilStrings(q.Absent),
+		Excludes: excludesJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(rows))
+	for _, row := range rows {
+		tasks = append(tasks, r.toDomain(row))
+	}
+	return tasks, nil
+}
+
+// nonNilStrings - пустой массив вместо NULL, иначе ?& и ?| вернут NULL
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func convertDBCustomField(row db.CustomField) domain.CustomField {
+	f := domain.CustomField{
+		Key:      row.Key,
+		Project:  row.Project,
+		Name:     row.Name,
+		Type:     domain.CustomFieldType(row.Type),
+		Options:  row.Options,
+		Required: row.Required,
+		Pattern:  row.Pattern,
+		Position: int(row.Position),
+	}
+	if len(f.Options) == 0 {
+		f.Options = nil
+	}
+	if row.MinValue.Valid {
+		f.Min = &row.MinValue.Float64
+	}
+	if row.MaxValue.Valid {
+		f.Max = &row.MaxValue.Float64
+	}
+	return f
+}
+
+func encodeCustomValues(values map[string]any) ([]byte, error) {
+	if len(values) == 0 {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(values)
+}
+
+// decodeCustomValues - JSON сам дает хранимые типы: string, float64, bool
+func decodeCustomValues(raw []byte) map[string]any {
+	var values map[string]any
+	if len(raw) > 0 {
+		json.Unmarshal(raw, &values)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type dependencyRepository struct {
+	queries *db.Queries
+}
+
+func (r *dependencyRepository) Lock(ctx context.Context) error {
+	return r.queries.LockTaskDependencies(ctx)
+}
+
+func (r *dependencyRepository) Add(ctx context.Context, dep domain.Dependency) error {
+	return r.queries.AddTaskDependency(ctx, db.AddTaskDependencyParams{
+		TaskID:      dep.TaskID,
+		BlockedByID: dep.BlockedByID,
+		CreatedAt:   timestamp(time.Now()),
+	})
+}
+
+func (r *dependencyRepository) Remove(ctx context.Context, dep domain.Dependency) error {
+	n, err := r.queries.DeleteTaskDependency(ctx, db.DeleteTaskDependencyParams{
+		TaskID:      dep.TaskID,
+		BlockedByID: dep.BlockedByID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrDependencyNotFound
+	}
+	return nil
+}
+
+func (r *dependencyRepository) GetAll(ctx context.Context) ([]domain.Dependency, error) {
+	rows, err := r.queries.ListTaskDependencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]domain.Dependency, 0, len(rows))
+	for _, row := range rows {
+		deps = append(deps, domain.Dependency{TaskID: row.TaskID, BlockedByID: row.BlockedByID})
+	}
+	return deps, nil
+}
+
+func (r *dependencyRepository) GetBlockers(ctx context.Context, taskID string) ([]string, error) {
+	return r.queries.ListTaskBlockers(ctx, taskID)
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type focusSessionRepository struct {
+	queries *db.Queries
+}
+
+func (r *focusSessionRepository) Add(ctx context.Context, session *domain.FocusSession) error {
+	return r.queries.AddFocusSession(ctx, db.AddFocusSessionParams{
+		ID:             session.ID,
+		TaskID:         session.TaskID,
+		StartedAt:      timestamp(session.StartedAt),
+		EndedAt:        timestamp(session.EndedAt),
+		PlannedSeconds: int64(session.Planned / time.Second),
+		Completed:      session.Completed,
+	})
+}
+
+func (r *focusSessionRepository) ListBetween(ctx context.Context, from, to time.Time) ([]*domain.FocusSession, error) {
+	rows, err := r.queries.ListFocusSessionsBetween(ctx, db.ListFocusSessionsBetweenParams{
+		StartedAt:   timestamp(from),
+		StartedAt_2: timestamp(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertDBFocusSessions(rows), nil
+}
+
+func (r *focusSessionRepository) GetAll(ctx context.Context) ([]*domain.FocusSession, error) {
+	rows, err := r.queries.ListAllFocusSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertDBFocusSessions(rows), nil
+}
+
+func convertDBFocusSessions(rows []db.FocusSession) []*domain.FocusSession {
+	sessions := make([]*domain.FocusSession, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, &domain.FocusSession{
+			ID:        row.ID,
+			TaskID:    row.TaskID,
+			StartedAt: row.StartedAt.Time,
+			EndedAt:   row.EndedAt.Time,
+			Planned:   time.Duration(row.PlannedSeconds) * time.Second,
+			Completed: row.Completed,
+		})
+	}
+	return sessions
+}
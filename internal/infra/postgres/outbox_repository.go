@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type outboxRepository struct {
+	queries *db.Queries
+}
+
+func (r *outboxRepository) Add(ctx context.Context, entry *domain.OutboxEntry) error {
+	n, err := r.queries.AddOutboxEntry(ctx, db.AddOutboxEntryParams{
+		IdempotencyKey: entry.IdempotencyKey,
+		EventType:      entry.EventType,
+		TaskID:         entry.TaskID,
+		Payload:        entry.Payload,
+		CreatedAt:      timestamp(entry.CreatedAt),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrDuplicateEvent
+	}
+	return nil
+}
+
+func (r *outboxRepository) Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.OutboxEntry, error) {
+	rows, err := r.queries.ClaimOutboxEntries(ctx, db.ClaimOutboxEntriesParams{
+		LeaseUntil: timestamp(leaseUntil),
+		Now:        timestamp(now),
+		MaxEntries: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := convertDBOutbox(rows)
+	// RETURNING не гарантирует порядок
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, nil
+}
+
+func (r *outboxRepository) MarkDelivered(ctx context.Context, id int64, at time.Time) error {
+	return r.queries.MarkOutboxDelivered(ctx, db.MarkOutboxDeliveredParams{
+		ID:          id,
+		DeliveredAt: timestamp(at),
+	})
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id int64, status domain.OutboxStatus, lastError string, nextAttemptAt time.Time) error {
+	return r.queries.MarkOutboxFailed(ctx, db.MarkOutboxFailedParams{
+		ID:            id,
+		Status:        string(status),
+		LastError:     lastError,
+		NextAttemptAt: timestamp(nextAttemptAt),
+	})
+}
+
+func (r *outboxRepository) ListByStatus(ctx context.Context, status domain.OutboxStatus, limit int) ([]*domain.OutboxEntry, error) {
+	rows, err := r.queries.ListOutboxByStatus(ctx, db.ListOutboxByStatusParams{
+		Status: string(status),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertDBOutbox(rows), nil
+}
+
+func (r *outboxRepository) Requeue(ctx context.Context, id int64, at time.Time) error {
+	n, err := r.queries.RequeueOutboxEntry(ctx, db.RequeueOutboxEntryParams{
+		ID:            id,
+		NextAttemptAt: timestamp(at),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrOutboxEntryNotFound
+	}
+	return nil
+}
+
+func (r *outboxRepository) DeleteDelivered(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.DeleteDeliveredOutbox(ctx, timestamp(before))
+}
+
+func (r *outboxRepository) DeleteStale(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.DeleteStaleOutbox(ctx, timestamp(before))
+}
+
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+func convertDBOutbox(rows []db.Outbox) []*domain.OutboxEntry {
+	entries := make([]*domain.OutboxEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := &domain.OutboxEntry{
+			ID:             row.ID,
+			IdempotencyKey: row.IdempotencyKey,
+			EventType:      row.EventType,
+			TaskID:         row.TaskID,
+			Payload:        row.Payload,
+			Status:         domain.OutboxStatus(row.Status),
+			Attempts:       int(row.Attempts),
+			LastError:      row.LastError,
+			CreatedAt:      row.CreatedAt.Time,
+			NextAttemptAt:  row.NextAttemptAt.Time,
+		}
+		if row.DeliveredAt.Valid {
+			entry.DeliveredAt = &row.DeliveredAt.Time
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+const (
+	syncStateNodeID = "node_id"
+	syncStateCursor = "cursor"
+)
+
+type syncRepository struct {
+	queries *db.Queries
+	tasks   *taskRepository
+}
+
+func (r *syncRepository) Pending(ctx context.Context) ([]domain.SyncRecord, []domain.Tombstone, error) {
+	tasks, err := r.queries.ListDirtyTasks(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	tombstones, err := r.queries.ListDirtyTombstones(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.convertRecords(tasks), convertTombstones(tombstones), nil
+}
+
+func (r *syncRepository) MarkSynced(ctx context.Context, records []domain.SyncRecord, tombstones []domain.Tombstone) error {
+	for _, rec := range records {
+		err := r.queries.MarkTaskSynced(ctx, db.MarkTaskSyncedParams{ID: rec.Task.ID, SyncSeq: rec.Seq})
+		if err != nil {
+			return err
+		}
+	}
+	for _, t := range tombstones {
+		err := r.queries.MarkTombstoneSynced(ctx, db.MarkTombstoneSyncedParams{TaskID: t.TaskID, Seq: t.Seq})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *syncRepository) Changes(ctx context.Context, since int64, limit int) ([]domain.SyncRecord, []domain.Tombstone, error) {
+	tasks, err := r.queries.ListTaskChangesSince(ctx, db.ListTaskChangesSinceParams{
+		SyncSeq: since,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	tombstones, err := r.queries.ListTombstonesSince(ctx, db.ListTombstonesSinceParams{
+		Seq:   since,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.convertRecords(tasks), convertTombstones(tombstones), nil
+}
+
+func (r *syncRepository) GetRecord(ctx context.Context, id string) (*domain.SyncRecord, error) {
+	row, err := r.queries.GetTaskByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	rec := r.convertRecord(row)
+	return &rec, nil
+}
+
+func (r *syncRepository) IsTombstoned(ctx context.Context, id string) (bool, error) {
+	return r.queries.TombstoneExists(ctx, id)
+}
+
+func (r *syncRepository) PutRecord(ctx context.Context, rec domain.SyncRecord) error {
+	clocks, err := encodeFieldClocks(rec.Clocks)
+	if err != nil {
+		return err
+	}
+
+	task := rec.Task
+	params := db.ApplySyncTaskParams{
+		ID:          task.ID,
+		Title:       task.Title,
+		Status:      string(task.Status),
+		CreatedAt:   timestamp(task.CreatedAt),
+		Priority:    string(task.Priority),
+		Project:     task.Project,
+		Tags:        task.Tags,
+		UpdatedAt:   timestamp(time.Now()),
+		FieldClocks: clocks,
+
+		EstimateMinutes: int32(task.EstimateMinutes),
+		EstimatePoints:  int32(task.EstimatePoints),
+	}
+	if params.Tags == nil {
+		params.Tags = []string{}
+	}
+	if task.DueDate != nil {
+		params.DueDate = timestamp(*task.DueDate)
+	}
+	if task.CompletedAt != nil {
+		params.CompletedAt = timestamp(*task.CompletedAt)
+	}
+	if task.StartDate != nil {
+		params.StartDate = timestamp(*task.StartDate)
+	}
+	if task.ParentID != nil {
+		params.ParentID = pgtype.Text{String: *task.ParentID, Valid: true}
+	}
+	if params.CustomFields, err = encodeCustomValues(task.CustomFields); err != nil {
+		return err
+	}
+
+	return r.queries.ApplySyncTask(ctx, params)
+}
+
+func (r *syncRepository) PutTombstone(ctx context.Context, t domain.Tombstone) error {
+	err := r.queries.AddTombstone(ctx, db.AddTombstoneParams{
+		TaskID:    t.TaskID,
+		Clock:     t.Clock.String(),
+		DeletedAt: timestamp(t.DeletedAt),
+		Dirty:     false, // пришло с сервера, отправлять обратно не нужно
+	})
+	if err != nil {
+		return err
+	}
+
+	// Подзадачи удалятся каскадно; их надгробия уходят дальше как локальные
+	ids, err := r.queries.GetTaskDescendantIDs(ctx, pgtype.Text{String: t.TaskID, Valid: true})
+	if err != nil {
+		return err
+	}
+	if err := r.tasks.addTombstones(ctx, ids); err != nil {
+		return err
+	}
+
+	return r.queries.DeleteTask(ctx, t.TaskID)
+}
+
+func (r *syncRepository) DropOrphans(ctx context.Context, clock domain.HLC) ([]domain.Tombstone, error) {
+	var dropped []domain.Tombstone
+	now := time.Now()
+
+	for {
+		ids, err := r.queries.ListOrphanTaskIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return dropped, nil
+		}
+
+		for _, id := range ids {
+			err := r.queries.AddTombstone(ctx, db.AddTombstoneParams{
+				TaskID:    id,
+				Clock:     clock.String(),
+				DeletedAt: timestamp(now),
+				Dirty:     true,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := r.queries.DeleteTask(ctx, id); err != nil {
+				return nil, err
+			}
+			dropped = append(dropped, domain.Tombstone{TaskID: id, Clock: clock, DeletedAt: now})
+		}
+	}
+}
+
+func (r *syncRepository) Cursor(ctx context.Context) (int64, error) {
+	value, err := r.queries.GetSyncState(ctx, syncStateCursor)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func (r *syncRepository) SetCursor(ctx context.Context, cursor int64) error {
+	return r.queries.SetSyncState(ctx, db.SetSyncStateParams{
+		Key:   syncStateCursor,
+		Value: strconv.FormatInt(cursor, 10),
+	})
+}
+
+// LoadNodeID возвращает ID узла синхронизации, при первом запуске создает и сохраняет его
+func LoadNodeID(ctx context.Context, queries *db.Queries) (string, error) {
+	id, err := queries.GetSyncState(ctx, syncStateNodeID)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	id = "node_" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	if err := queries.SetSyncState(ctx, db.SetSyncStateParams{Key: syncStateNodeID, Value: id}); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *syncRepository) convertRecords(rows []db.Task) []domain.SyncRecord {
+	records := make([]domain.SyncRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, r.convertRecord(row))
+	}
+	return records
+}
+
+func (r *syncRepository) convertRecord(row db.Task) domain.SyncRecord {
+	return domain.SyncRecord{
+		Task:   *r.tasks.convertDBTaskToDomain(row),
+		Clocks: decodeFieldClocks(row),
+		Seq:    row.SyncSeq,
+	}
+}
+
+func convertTombstones(rows []db.SyncTombstone) []domain.Tombstone {
+	tombstones := make([]domain.Tombstone, 0, len(rows))
+	for _, row := range rows {
+		clock, _ := domain.ParseHLC(row.Clock)
+		tombstones = append(tombstones, domain.Tombstone{
+			TaskID:    row.TaskID,
+			Clock:     clock,
+			DeletedAt: row.DeletedAt.Time,
+			Seq:       row.Seq,
+		})
+	}
+	return tombstones
+}
+
+// decodeFieldClocks: полям без метки (задачи до миграции) ставим время последнего изменения
+func decodeFieldClocks(row db.Task) domain.FieldClocks {
+	clocks := domain.FieldClocks{}
+	if len(row.FieldClocks) > 0 {
+		json.Unmarshal(row.FieldClocks, &clocks)
+	}
+
+	legacy := domain.HLC{Wall: row.UpdatedAt.Time.UnixMilli()}
+	for _, field := range domain.SyncFields {
+		if _, ok := clocks[field]; !ok {
+			clocks[field] = legacy
+		}
+	}
+	return clocks
+}
+
+func encodeFieldClocks(clocks domain.FieldClocks) ([]byte, error) {
+	if clocks == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(clocks)
+}
@@ -15,13 +15,15 @@ import (
 type taskRepository struct {
 	queries *db.Queries
 	pool      *pgxpool.Pool
+	clock   *domain.Clock // метки полей для синхронизации между устройствами
 }
 
-func NewTaskRepository(queries *db.Queries, pool *pgxpool.Pool) domain.TaskRepository {
-	return &taskRepository{queries: queries, pool: pool}
+func NewTaskRepository(queries *db.Queries, pool *pgxpool.Pool, clock *domain.Clock) domain.TaskRepository {
+	return &taskRepository{queries: queries, pool: pool, clock: clock}
 }
 
 func (r *taskRepository) Save(ctx context.Context, task *domain.Task) error {
+	now := time.Now()
 	params := db.SaveTaskParams{
 		ID:       task.ID,
 		Title:    task.Title,
@@ -31,6 +33,19 @@ func (r *taskRepository) Save(ctx context.Context, task *domain.Task) error {
 			Time:  task.CreatedAt,
 			Valid: true,
 		},
+		Project: task.Project,
+		Tags:    task.Tags,
+		UpdatedAt: pgtype.Timestamp{
+			Time:  now,
+			Valid: true,
+		},
+		EstimateMinutes: int32(task.EstimateMinutes),
+		EstimatePoints:  int32(task.EstimatePoints),
+	}
+
+	// NULL в tags запрещен
+	if params.Tags == nil {
+		params.Tags = []string{}
 	}
 
 	if task.DueDate != nil {
@@ -40,7 +55,64 @@ func (r *taskRepository) Save(ctx context.Context, task *domain.Task) error {
 		}
 	}
 
-	return r.queries.SaveTask(ctx, params)
+	if task.ParentID != nil {
+		params.ParentID = pgtype.Text{
+			String: *task.ParentID,
+			Valid:  true,
+		}
+	}
+
+	if task.CompletedAt != nil {
+		params.CompletedAt = pgtype.Timestamp{
+			Time:  *task.CompletedAt,
+			Valid: true,
+		}
+	}
+
+	if task.StartDate != nil {
+		params.StartDate = pgtype.Timestamp{
+			Time:  *task.StartDate,
+			Valid: true,
+		}
+	}
+
+	customFields, err := encodeCustomValues(task.CustomFields)
+	if err != nil {
+		return err
+	}
+	params.CustomFields = customFields
+
+	// Метки получают только изменившиеся поля
+	var (
+		before *domain.Task
+		clocks domain.FieldClocks
+	)
+	existing, err := r.queries.GetTaskByID(ctx, task.ID)
+	switch {
+	case err == nil:
+		before = r.convertDBTaskToDomain(existing)
+		clocks = decodeFieldClocks(existing)
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	clocks = domain.StampChanges(before, task, clocks, r.clock.Now())
+	if params.FieldClocks, err = encodeFieldClocks(clocks); err != nil {
+		return err
+	}
+
+	if err := r.queries.DeleteDirtyTombstone(ctx, task.ID); err != nil {
+		return err
+	}
+
+	version, err := r.queries.SaveTask(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	task.Version = version
+	task.UpdatedAt = now
+	return nil
 }
 
 func (r *taskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
@@ -55,6 +127,18 @@ func (r *taskRepository) GetByID(ctx context.Context, id string) (*domain.Task,
 	return r.convertDBTaskToDomain(dbTask), nil
 }
 
+func (r *taskRepository) GetForUpdate(ctx context.Context, id string) (*domain.Task, error) {
+	dbTask, err := r.queries.GetTaskByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return r.convertDBTaskToDomain(dbTask), nil
+}
+
 func (r *taskRepository) GetAll(ctx context.Context) ([]*domain.Task, error) {
 	dbTasks, err := r.queries.GetAllTasks(ctx)
 	if err != nil {
@@ -69,8 +153,13 @@ func (r *taskRepository) GetAll(ctx context.Context) ([]*domain.Task, error) {
 	return tasks, nil
 }
 
-func (r *taskRepository) GetByStatus(ctx context.Context, status domain.TaskStatus) ([]*domain.Task, error) {
-	dbTasks, err := r.queries.GetTasksByStatus(ctx, string(status))
+func (r *taskRepository) GetByStatus(ctx context.Context, statuses ...domain.TaskStatus) ([]*domain.Task, error) {
+	keys := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		keys = append(keys, string(status))
+	}
+
+	dbTasks, err := r.queries.GetTasksByStatuses(ctx, keys)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +172,20 @@ func (r *taskRepository) GetByStatus(ctx context.Context, status domain.TaskStat
 	return tasks, nil
 }
 
+func (r *taskRepository) CountByStatus(ctx context.Context) (map[domain.TaskStatus]int, error) {
+	rows, err := r.queries.CountTasksByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.TaskStatus]int, len(rows))
+	for _, row := range rows {
+		counts[domain.TaskStatus(row.Status)] = int(row.Count)
+	}
+
+	return counts, nil
+}
+
 func (r *taskRepository) GetDueBetween(ctx context.Context, startDate, endDate time.Time) ([]*domain.Task, error) {
 	params := db.GetTasksDueBetweenParams{
 		DueDate: pgtype.Timestamp{
@@ -108,10 +211,98 @@ func (r *taskRepository) GetDueBetween(ctx context.Context, startDate, endDate t
 	return tasks, nil
 }
 
+func (r *taskRepository) GetCompletedBetween(ctx context.Context, startDate, endDate time.Time) ([]*domain.Task, error) {
+	params := db.GetTasksCompletedBetweenParams{
+		CompletedAt: pgtype.Timestamp{
+			Time:  startDate,
+			Valid: true,
+		},
+		CompletedAt_2: pgtype.Timestamp{
+			Time:  endDate,
+			Valid: true,
+		},
+	}
+
+	dbTasks, err := r.queries.GetTasksCompletedBetween(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(dbTasks))
+	for _, dbTask := range dbTasks {
+		tasks = append(tasks, r.convertDBTaskToDomain(dbTask))
+	}
+
+	return tasks, nil
+}
+
+func (r *taskRepository) GetStartingBetween(ctx context.Context, startDate, endDate time.Time) ([]*domain.Task, error) {
+	params := db.GetTasksStartingBetweenParams{
+		StartDate: pgtype.Timestamp{
+			Time:  startDate,
+			Valid: true,
+		},
+		StartDate_2: pgtype.Timestamp{
+			Time:  endDate,
+			Valid: true,
+		},
+	}
+
+	dbTasks, err := r.queries.GetTasksStartingBetween(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(dbTasks))
+	for _, dbTask := range dbTasks {
+		tasks = append(tasks, r.convertDBTaskToDomain(dbTask))
+	}
+
+	return tasks, nil
+}
+
 func (r *taskRepository) Delete(ctx context.Context, id string) error {
+	// Подзадачи удаляются каскадно, надгробия нужны и для них
+	ids, err := r.queries.GetTaskDescendantIDs(ctx, pgtype.Text{String: id, Valid: true})
+	if err != nil {
+		return err
+	}
+	if err := r.addTombstones(ctx, append(ids, id)); err != nil {
+		return err
+	}
+
 	return r.queries.DeleteTask(ctx, id)
 }
 
+func (r *taskRepository) DeleteAll(ctx context.Context) error {
+	ids, err := r.queries.GetAllTaskIDs(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.addTombstones(ctx, ids); err != nil {
+		return err
+	}
+
+	return r.queries.DeleteAllTasks(ctx)
+}
+
+func (r *taskRepository) addTombstones(ctx context.Context, ids []string) error {
+	clock := r.clock.Now()
+	now := time.Now()
+	for _, id := range ids {
+		err := r.queries.AddTombstone(ctx, db.AddTombstoneParams{
+			TaskID:    id,
+			Clock:     clock.String(),
+			DeletedAt: timestamp(now),
+			Dirty:     true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *taskRepository) WithTx(ctx context.Context, fn func(repo domain.TaskRepository) error) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
@@ -122,6 +313,7 @@ func (r *taskRepository) WithTx(ctx context.Context, fn func(repo domain.TaskRep
 	txRepo := &taskRepository{
 		queries: r.queries.WithTx(tx),
 		pool:      r.pool,
+		clock:   r.clock,
 	}
 
 	if err := fn(txRepo); err != nil {
@@ -131,6 +323,62 @@ func (r *taskRepository) WithTx(ctx context.Context, fn func(repo domain.TaskRep
 	return tx.Commit(ctx)
 }
 
+func (r *taskRepository) Outbox() domain.OutboxRepository {
+	return &outboxRepository{queries: r.queries}
+}
+
+func (r *taskRepository) Sync() domain.SyncRepository {
+	return &syncRepository{queries: r.queries, tasks: r}
+}
+
+func (r *taskRepository) TimeEntries() domain.TimeEntryRepository {
+	return &timeEntryRepository{queries: r.queries}
+}
+
+func (r *taskRepository) FocusSessions() domain.FocusSessionRepository {
+	return &focusSessionRepository{queries: r.queries}
+}
+
+func (r *taskRepository) Dependencies() domain.DependencyRepository {
+	return &dependencyRepository{queries: r.queries}
+}
+
+func (r *taskRepository) Workflow() domain.WorkflowRepository {
+	return &workflowRepository{queries: r.queries}
+}
+
+func (r *taskRepository) Board() domain.BoardRepository {
+	return &boardRepository{queries: r.queries}
+}
+
+func (r *taskRepository) Archive() domain.ArchiveRepository {
+	return &archiveRepository{queries: r.queries, toDomain: r.convertDBTaskToDomain}
+}
+
+func (r *taskRepository) Checklist() domain.ChecklistRepository {
+	return &checklistRepository{queries: r.queries}
+}
+
+func (r *taskRepository) Attachments() domain.AttachmentRepository {
+	return &attachmentRepository{queries: r.queries}
+}
+
+func (r *taskRepository) Comments() domain.CommentRepository {
+	return &commentRepository{queries: r.queries}
+}
+
+func (r *taskRepository) Activity() domain.ActivityRepository {
+	return &activityRepository{queries: r.queries}
+}
+
+func (r *taskRepository) CustomFields() domain.CustomFieldRepository {
+	return &customFieldRepository{queries: r.queries, toDomain: r.convertDBTaskToDomain}
+}
+
+func (r *taskRepository) Webhooks() domain.WebhookRepository {
+	return &webhookRepository{queries: r.queries}
+}
+
 func (r *taskRepository) convertDBTaskToDomain(dbTask db.Task) *domain.Task {
 	task := &domain.Task{
 		ID:        dbTask.ID,
@@ -138,11 +386,39 @@ func (r *taskRepository) convertDBTaskToDomain(dbTask db.Task) *domain.Task {
 		Status:    domain.TaskStatus(dbTask.Status),
 		Priority:  domain.Priority(dbTask.Priority),
 		CreatedAt: dbTask.CreatedAt.Time,
+		Project:   dbTask.Project,
+		Tags:      dbTask.Tags,
+		Version:   dbTask.Version,
+		UpdatedAt: dbTask.UpdatedAt.Time,
+
+		TrackedTime:     time.Duration(dbTask.TrackedSeconds) * time.Second,
+		EstimateMinutes: int(dbTask.EstimateMinutes),
+		EstimatePoints:  int(dbTask.EstimatePoints),
+		ChecklistTotal:  int(dbTask.ChecklistTotal),
+		ChecklistDone:   int(dbTask.ChecklistDone),
 	}
 
 	if dbTask.DueDate.Valid {
 		task.DueDate = &dbTask.DueDate.Time
 	}
 
+	if dbTask.CompletedAt.Valid {
+		task.CompletedAt = &dbTask.CompletedAt.Time
+	}
+
+	if dbTask.ArchivedAt.Valid {
+		task.ArchivedAt = &dbTask.ArchivedAt.Time
+	}
+
+	if dbTask.StartDate.Valid {
+		task.StartDate = &dbTask.StartDate.Time
+	}
+
+	if dbTask.ParentID.Valid {
+		task.ParentID = &dbTask.ParentID.String
+	}
+
+	task.CustomFields = decodeCustomValues(dbTask.CustomFields)
+
 	return task
 }
\ No newline at end of file
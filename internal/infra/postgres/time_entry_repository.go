@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type timeEntryRepository struct {
+	queries *db.Queries
+}
+
+func (r *timeEntryRepository) Save(ctx context.Context, entry *domain.TimeEntry) error {
+	// Запись могли перенести на другую задачу - пересчитываем обе
+	previous, err := r.queries.GetTimeEntryByID(ctx, entry.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	now := time.Now()
+	params := db.SaveTimeEntryParams{
+		ID:        entry.ID,
+		TaskID:    entry.TaskID,
+		StartedAt: timestamp(entry.StartedAt),
+		Note:      entry.Note,
+		CreatedAt: timestamp(entry.CreatedAt),
+		UpdatedAt: timestamp(now),
+	}
+	if entry.EndedAt != nil {
+		params.EndedAt = timestamp(*entry.EndedAt)
+	}
+
+	if err := r.queries.SaveTimeEntry(ctx, params); err != nil {
+		return err
+	}
+	entry.UpdatedAt = now
+
+	if previous.TaskID != "" && previous.TaskID != entry.TaskID {
+		if err := r.queries.RefreshTaskTrackedTime(ctx, previous.TaskID); err != nil {
+			return err
+		}
+	}
+	return r.queries.RefreshTaskTrackedTime(ctx, entry.TaskID)
+}
+
+func (r *timeEntryRepository) GetByID(ctx context.Context, id string) (*domain.TimeEntry, error) {
+	row, err := r.queries.GetTimeEntryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTimeEntryNotFound
+		}
+		return nil, err
+	}
+	return convertDBTimeEntry(row), nil
+}
+
+func (r *timeEntryRepository) GetRunning(ctx context.Context) (*domain.TimeEntry, error) {
+	row, err := r.queries.GetRunningTimeEntry(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNoRunningTimer
+		}
+		return nil, err
+	}
+	return convertDBTimeEntry(row), nil
+}
+
+func (r *timeEntryRepository) ListByTask(ctx context.Context, taskID string) ([]*domain.TimeEntry, error) {
+	rows, err := r.queries.ListTimeEntriesByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return convertDBTimeEntries(rows), nil
+}
+
+func (r *timeEntryRepository) ListBetween(ctx context.Context, from, to time.Time) ([]*domain.TimeEntry, error) {
+	rows, err := r.queries.ListTimeEntriesBetween(ctx, db.ListTimeEntriesBetweenParams{
+		StartTime: timestamp(from),
+		EndTime:   timestamp(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertDBTimeEntries(rows), nil
+}
+
+func (r *timeEntryRepository) GetAll(ctx context.Context) ([]*domain.TimeEntry, error) {
+	rows, err := r.queries.ListAllTimeEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertDBTimeEntries(rows), nil
+}
+
+func (r *timeEntryRepository) Delete(ctx context.Context, id string) error {
+	entry, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.queries.DeleteTimeEntry(ctx, id); err != nil {
+		return err
+	}
+	return r.queries.RefreshTaskTrackedTime(ctx, entry.TaskID)
+}
+
+func convertDBTimeEntries(rows []db.TimeEntry) []*domain.TimeEntry {
+	entries := make([]*domain.TimeEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, convertDBTimeEntry(row))
+	}
+	return entries
+}
+
+func convertDBTimeEntry(row db.TimeEntry) *domain.TimeEntry {
+	entry := &domain.TimeEntry{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		StartedAt: row.StartedAt.Time,
+		Note:      row.Note,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.EndedAt.Valid {
+		entry.EndedAt = &row.EndedAt.Time
+	}
+	return entry
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type webhookRepository struct {
+	queries *db.Queries
+}
+
+func NewWebhookRepository(queries *db.Queries) domain.WebhookRepository {
+	return &webhookRepository{queries: queries}
+}
+
+func (r *webhookRepository) Save(ctx context.Context, hook *domain.Webhook) error {
+	now := time.Now()
+	params := db.SaveWebhookParams{
+		ID:         hook.ID,
+		Url:        hook.URL,
+		EventTypes: hook.EventTypes,
+		Filter:     hook.Filter,
+		Secret:     hook.Secret,
+		Enabled:    hook.Enabled,
+		CreatedAt:  timestamp(hook.CreatedAt),
+		UpdatedAt:  timestamp(now),
+	}
+
+	// NULL в event_types запрещен
+	if params.EventTypes == nil {
+		params.EventTypes = []string{}
+	}
+
+	if err := r.queries.SaveWebhook(ctx, params); err != nil {
+		return err
+	}
+
+	hook.UpdatedAt = now
+	return nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	row, err := r.queries.GetWebhookByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return convertDBWebhook(row), nil
+}
+
+func (r *webhookRepository) GetAll(ctx context.Context) ([]*domain.Webhook, error) {
+	rows, err := r.queries.GetAllWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := make([]*domain.Webhook, 0, len(rows))
+	for _, row := range rows {
+		hooks = append(hooks, convertDBWebhook(row))
+	}
+	return hooks, nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	n, err := r.queries.DeleteWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) AddDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	id, err := r.queries.AddWebhookDelivery(ctx, db.AddWebhookDeliveryParams{
+		WebhookID:  d.WebhookID,
+		EventID:    d.EventID,
+		EventType:  d.EventType,
+		StatusCode: int32(d.StatusCode),
+		Error:      d.Error,
+		DurationMs: d.Duration.Milliseconds(),
+		CreatedAt:  timestamp(d.CreatedAt),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.ID = id
+	return nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.queries.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		WebhookID: webhookID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			ID:         row.ID,
+			WebhookID:  row.WebhookID,
+			EventID:    row.EventID,
+			EventType:  row.EventType,
+			StatusCode: int(row.StatusCode),
+			Error:      row.Error,
+			Duration:   time.Duration(row.DurationMs) * time.Millisecond,
+			CreatedAt:  row.CreatedAt.Time,
+		})
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) Delivered(ctx context.Context, webhookID, eventID string) (bool, error) {
+	return r.queries.WebhookEventDelivered(ctx, db.WebhookEventDeliveredParams{
+		WebhookID: webhookID,
+		EventID:   eventID,
+	})
+}
+
+func convertDBWebhook(row db.Webhook) *domain.Webhook {
+	return &domain.Webhook{
+		ID:         row.ID,
+		URL:        row.Url,
+		EventTypes: row.EventTypes,
+		Filter:     row.Filter,
+		Secret:     row.Secret,
+		Enabled:    row.Enabled,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+type workflowRepository struct {
+	queries *db.Queries
+}
+
+func (r *workflowRepository) Get(ctx context.Context) (domain.Workflow, error) {
+	statuses, err := r.queries.ListWorkflowStatuses(ctx)
+	if err != nil {
+		return domain.Workflow{}, err
+	}
+	transitions, err := r.queries.ListWorkflowTransitions(ctx)
+	if err != nil {
+		return domain.Workflow{}, err
+	}
+
+	w := domain.Workflow{
+		Statuses:    make([]domain.WorkflowStatus, 0, len(statuses)),
+		Transitions: make([]domain.WorkflowTransition, 0, len(transitions)),
+	}
+	for _, s := range statuses {
+		w.Statuses = append(w.Statuses, domain.WorkflowStatus{
+			Key:      domain.TaskStatus(s.Key),
+			Name:     s.Name,
+			Category: domain.StatusCategory(s.Category),
+			Position: int(s.Position),
+			Initial:  s.IsInitial,
+		})
+	}
+	for _, t := range transitions {
+		w.Transitions = append(w.Transitions, domain.WorkflowTransition{
+			From: domain.TaskStatus(t.FromStatus),
+			To:   domain.TaskStatus(t.ToStatus),
+		})
+	}
+	return w, nil
+}
+
+func (r *workflowRepository) SaveStatus(ctx context.Context, status domain.WorkflowStatus) error {
+	return r.queries.SaveWorkflowStatus(ctx, db.SaveWorkflowStatusParams{
+		Key:       string(status.Key),
+		Name:      status.Name,
+		Category:  string(status.Category),
+		Position:  int32(status.Position),
+		IsInitial: status.Initial,
+	})
+}
+
+func (r *workflowRepository) DeleteStatus(ctx context.Context, key domain.TaskStatus) error {
+	n, err := r.queries.CountTasksWithStatus(ctx, string(key))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return domain.ErrStatusInUse
+	}
+
+	_, err = r.queries.DeleteWorkflowStatus(ctx, string(key))
+	return err
+}
+
+func (r *workflowRepository) SetTransitions(ctx context.Context, transitions []domain.WorkflowTransition) error {
+	if err := r.queries.DeleteWorkflowTransitions(ctx); err != nil {
+		return err
+	}
+	for _, t := range transitions {
+		err := r.queries.AddWorkflowTransition(ctx, db.AddWorkflowTransitionParams{
+			FromStatus: string(t.From),
+			ToStatus:   string(t.To),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *workflowRepository) Lock(ctx context.Context) error {
+	return r.queries.LockWorkflow(ctx)
+}
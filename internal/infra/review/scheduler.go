@@ -0,0 +1,137 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/util"
+)
+
+const (
+	filePrefix = "review-"
+	dateLayout = "2006-01-02"
+)
+
+// Scheduler раз в неделю в заданный день и время пишет обзор недели в директорию.
+// Файл называется по началу недели, повторная запись за ту же неделю его перезаписывает.
+type Scheduler struct {
+	generate app.GenerateReview
+	dir      string
+	weekday  time.Weekday
+	at       time.Duration // смещение от начала дня
+	formats  []string      // расширения файлов: md, html
+}
+
+func NewScheduler(generate app.GenerateReview, cfg util.ReviewConfig) (*Scheduler, error) {
+	s := &Scheduler{generate: generate, dir: cfg.Dir}
+
+	weekday, ok := weekdays[strings.ToLower(cfg.Weekday)]
+	if !ok {
+		return nil, fmt.Errorf("review: unknown weekday %q", cfg.Weekday)
+	}
+	s.weekday = weekday
+
+	at, err := time.Parse("15:04", cfg.At)
+	if err != nil {
+		return nil, fmt.Errorf("review: invalid time %q: %w", cfg.At, err)
+	}
+	s.at = time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute
+
+	switch strings.ToLower(cfg.Format) {
+	case "markdown", "md", "":
+		s.formats = []string{"md"}
+	case "html":
+		s.formats = []string{"html"}
+	case "both":
+		s.formats = []string{"md", "html"}
+	default:
+		return nil, fmt.Errorf("review: unknown format %q", cfg.Format)
+	}
+
+	return s, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+func (s *Scheduler) Enabled() bool {
+	return s.dir != ""
+}
+
+// Run блокируется до отмены ctx
+func (s *Scheduler) Run(ctx context.Context) {
+	if !s.Enabled() {
+		return
+	}
+
+	for {
+		timer := time.NewTimer(time.Until(s.next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if _, err := s.WriteNow(ctx); err != nil {
+				log.Printf("review: %v", err)
+			}
+		}
+	}
+}
+
+// next - ближайший запуск строго после now
+func (s *Scheduler) next(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days := (int(s.weekday) - int(now.Weekday()) + 7) % 7
+	run := today.AddDate(0, 0, days).Add(s.at)
+	if !run.After(now) {
+		run = run.AddDate(0, 0, 7)
+	}
+	return run
+}
+
+// WriteNow пишет обзор текущей недели и возвращает пути к созданным файлам
+func (s *Scheduler) WriteNow(ctx context.Context) ([]string, error) {
+	if s.dir == "" {
+		return nil, fmt.Errorf("review dir is not configured")
+	}
+
+	review, err := s.generate.Execute(ctx, app.GenerateReviewInput{})
+	if err != nil {
+		return nil, fmt.Errorf("generate: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create dir: %w", err)
+	}
+
+	var paths []string
+	for _, ext := range s.formats {
+		content := review.Markdown
+		if ext == "html" {
+			content = review.HTML
+		}
+
+		path := filepath.Join(s.dir, filePrefix+review.WeekStart.Format(dateLayout)+"."+ext)
+
+		// Как и бэкап: временный файл и переименование, чтобы не оставить обрезанный обзор
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+			return paths, fmt.Errorf("write file: %w", err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			os.Remove(tmp)
+			return paths, fmt.Errorf("rename file: %w", err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
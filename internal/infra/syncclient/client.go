@@ -0,0 +1,72 @@
+package syncclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// ExchangePath - адрес обмена на сервере синхронизации
+const ExchangePath = "/sync/v1/exchange"
+
+const exchangeTimeout = 60 * time.Second
+
+// Client - HTTP транспорт синхронизации
+type Client struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewClient(serverURL, token string) *Client {
+	return &Client{
+		url:    strings.TrimRight(serverURL, "/") + ExchangePath,
+		token:  token,
+		client: &http.Client{Timeout: exchangeTimeout},
+	}
+}
+
+func (c *Client) Exchange(ctx context.Context, in app.SyncRequest) (app.SyncResponse, error) {
+	var out app.SyncResponse
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return out, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return out, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return out, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return out, domain.ErrSyncUnauthorized
+	case resp.StatusCode != http.StatusOK:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return out, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return out, fmt.Errorf("decode response: %w", err)
+	}
+	return out, nil
+}
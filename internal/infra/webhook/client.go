@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/outbox"
+)
+
+const (
+	sendTimeout = 10 * time.Second
+	userAgent   = "dekstop-todo-app-webhook/1"
+
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+)
+
+// Client отправляет payload вебхуку.
+// Подпись: X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, timestamp + "." + body)),
+// timestamp (unix-секунды) передается в X-Webhook-Timestamp для защиты от повторов.
+type Client struct {
+	http *http.Client
+}
+
+func NewClient() *Client {
+	return &Client{http: &http.Client{Timeout: sendTimeout}}
+}
+
+func (c *Client) Post(ctx context.Context, hook *domain.Webhook, eventID, eventType string, payload []byte) *domain.WebhookDelivery {
+	started := time.Now()
+	delivery := &domain.WebhookDelivery{
+		WebhookID: hook.ID,
+		EventID:   eventID,
+		EventType: eventType,
+		CreatedAt: started,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	ts := strconv.FormatInt(started.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Idempotency-Key", eventID)
+	req.Header.Set("X-Event-Type", eventType)
+	req.Header.Set("X-Webhook-ID", hook.ID)
+	req.Header.Set(HeaderTimestamp, ts)
+	if hook.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(hook.Secret, ts, payload))
+	}
+
+	resp, err := c.http.Do(req)
+	delivery.Duration = time.Since(started)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	delivery.StatusCode = resp.StatusCode
+	if err := outbox.CheckStatus(resp.StatusCode); err != nil {
+		delivery.Error = err.Error()
+	}
+	return delivery
+}
+
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+func TestSignKnownVector(t *testing.T) {
+	got := Sign("secret", "1700000000", []byte(`{"id":"evt_1"}`))
+	want := "sha256=af784f27423c462e20039559cd4264140f7b7ed4c9090e26fd663faa5eeb8dda"
+	if got != want {
+		t.Fatalf("Sign = %s, want %s", got, want)
+	}
+}
+
+// verify - проверка подписи так, как ее делает получатель
+func verify(secret string, r *http.Request, body []byte) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(r.Header.Get(HeaderTimestamp) + "."))
+	mac.Write(body)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(want), []byte(r.Header.Get(HeaderSignature)))
+}
+
+func TestClientPostSignsPayload(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"task.created"}`)
+
+	var (
+		signed    bool
+		signature string
+		timestamp int64
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signed = verify("s3cret", r, body)
+		signature = r.Header.Get(HeaderSignature)
+		timestamp, _ = strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	hook := &domain.Webhook{ID: "wh_1", URL: srv.URL, Secret: "s3cret"}
+	delivery := NewClient().Post(context.Background(), hook, "evt_1", domain.EventTaskCreated, payload)
+
+	if !delivery.Succeeded() || delivery.StatusCode != http.StatusNoContent {
+		t.Fatalf("delivery = %+v, want success", delivery)
+	}
+	if !signed {
+		t.Fatalf("signature %q does not verify", signature)
+	}
+	if age := time.Since(time.Unix(timestamp, 0)); age < 0 || age > time.Minute {
+		t.Fatalf("timestamp is %v old", age)
+	}
+
+	// Подпись другим секретом не проходит проверку
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, signature)
+	if verify("other", req, payload) {
+		t.Fatal("signature verified with a wrong secret")
+	}
+}
+
+func TestClientPostWithoutSecret(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	hook := &domain.Webhook{ID: "wh_1", URL: srv.URL}
+	delivery := NewClient().Post(context.Background(), hook, "evt_1", domain.EventTaskCreated, []byte(`{}`))
+
+	if header.Get(HeaderSignature) != "" {
+		t.Fatalf("unsigned hook sent %s", header.Get(HeaderSignature))
+	}
+	if header.Get("Idempotency-Key") != "evt_1" || header.Get("X-Webhook-ID") != "wh_1" {
+		t.Fatalf("headers = %v", header)
+	}
+	if delivery.Succeeded() || delivery.StatusCode != http.StatusInternalServerError || delivery.Error == "" {
+		t.Fatalf("delivery = %+v, want failed 500", delivery)
+	}
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/outbox"
+)
+
+// Dispatcher - получатель outbox, рассылающий событие подходящим вебхукам.
+// Вебхуки, уже успешно получившие событие, при повторе пропускаются.
+type Dispatcher struct {
+	repo      domain.WebhookRepository
+	client    domain.WebhookClient
+	workflows domain.WorkflowRepository
+}
+
+// workflows нужен фильтрам category и overdue
+func NewDispatcher(repo domain.WebhookRepository, client domain.WebhookClient, workflows domain.WorkflowRepository) *Dispatcher {
+	return &Dispatcher{repo: repo, client: client, workflows: workflows}
+}
+
+func (d *Dispatcher) Send(ctx context.Context, entry *domain.OutboxEntry) error {
+	hooks, err := d.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get webhooks: %w", err)
+	}
+
+	var msg app.EventMessage
+	if err := json.Unmarshal(entry.Payload, &msg); err != nil {
+		return fmt.Errorf("%w: decode event: %v", outbox.ErrPermanent, err)
+	}
+	workflow, err := d.workflows.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("get workflow: %w", err)
+	}
+	subject := domain.FilterSubject{
+		Event:    entry.EventType,
+		Task:     msg.TaskValue(),
+		Now:      time.Now(),
+		Workflow: workflow,
+	}
+
+	var errs []error
+	for _, hook := range hooks {
+		if !hook.Matches(subject) {
+			continue
+		}
+
+		delivered, err := d.repo.Delivered(ctx, hook.ID, entry.IdempotencyKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", hook.ID, err))
+			continue
+		}
+		if delivered {
+			continue
+		}
+
+		delivery := d.client.Post(ctx, hook, entry.IdempotencyKey, entry.EventType, entry.Payload)
+		if err := d.repo.AddDelivery(ctx, delivery); err != nil {
+			log.Printf("webhook %s: log delivery: %v", hook.ID, err)
+		}
+
+		if !delivery.Succeeded() {
+			err := errors.New(delivery.Error)
+			if delivery.StatusCode != 0 {
+				err = outbox.CheckStatus(delivery.StatusCode)
+			}
+			errs = append(errs, fmt.Errorf("webhook %s: %w", hook.ID, err))
+		}
+	}
+
+	return outbox.JoinErrors(errs)
+}
@@ -0,0 +1,655 @@
+// Package testutil - общие фейки для тестов use cases и адаптеров
+package testutil
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
+)
+
+// Repo - репозиторий задач в памяти. Методы, которые тесты не вызывают, остаются
+// у встроенных nil-интерфейсов и упадут при обращении.
+type Repo struct {
+	domain.TaskRepository
+
+	mu    sync.Mutex
+	tasks map[string]*domain.Task
+
+	Deps   []domain.Dependency
+	Fields []domain.CustomField
+	// Flow - текущий workflow (имя Workflow занято методом репозитория)
+	Flow          domain.Workflow
+	OutboxEntries []*domain.OutboxEntry
+	Entries       []*domain.TimeEntry
+
+	sessions  []*domain.FocusSession
+	items     []*domain.ChecklistItem
+	files     []*domain.Attachment
+	comments  []*domain.Comment
+	history   []*domain.ActivityEntry
+	positions map[domain.BoardGrouping]map[string]int
+	limits    map[domain.BoardGrouping]map[string]int
+}
+
+func NewRepo() *Repo {
+	return &Repo{tasks: map[string]*domain.Task{}, Flow: domain.DefaultWorkflow()}
+}
+
+// Put кладет задачу как есть, без новой версии
+func (r *Repo) Put(tasks ...*domain.Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, task := range tasks {
+		stored := *task
+		r.tasks[task.ID] = &stored
+	}
+}
+
+func (r *Repo) Save(_ context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task.Version++
+	task.UpdatedAt = time.Now()
+	if task.CompletedAt == nil {
+		task.ArchivedAt = nil // как в SaveTask: открытая задача уходит из архива
+	}
+	stored := *task
+	r.tasks[task.ID] = &stored
+	return nil
+}
+
+func (r *Repo) GetByID(_ context.Context, id string) (*domain.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	copied := *task
+	return &copied, nil
+}
+
+func (r *Repo) GetForUpdate(ctx context.Context, id string) (*domain.Task, error) {
+	return r.GetByID(ctx, id)
+}
+
+// GetAll, как и в postgres, не видит архив
+func (r *Repo) GetAll(_ context.Context) ([]*domain.Task, error) {
+	return r.filter(func(task *domain.Task) bool { return task.ArchivedAt == nil }), nil
+}
+
+// GetByStatus тоже без архива
+func (r *Repo) GetByStatus(_ context.Context, statuses ...domain.TaskStatus) ([]*domain.Task, error) {
+	return r.filter(func(task *domain.Task) bool {
+		return task.ArchivedAt == nil && slices.Contains(statuses, task.Status)
+	}), nil
+}
+
+// GetCompletedBetween, как и запрос, видит и архив
+func (r *Repo) GetCompletedBetween(_ context.Context, startDate, endDate time.Time) ([]*domain.Task, error) {
+	tasks := r.filter(func(task *domain.Task) bool {
+		return task.CompletedAt != nil && !task.CompletedAt.Before(startDate) && task.CompletedAt.Before(endDate)
+	})
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].CompletedAt.Before(*tasks[j].CompletedAt) })
+	return tasks, nil
+}
+
+// GetStartingBetween - отложенные задачи с датой начала в (startDate, endDate], без архива
+func (r *Repo) GetStartingBetween(_ context.Context, startDate, endDate time.Time) ([]*domain.Task, error) {
+	tasks := r.filter(func(task *domain.Task) bool {
+		return task.ArchivedAt == nil && task.StartDate != nil && task.StartDate.After(startDate) && !task.StartDate.After(endDate)
+	})
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].StartDate.Before(*tasks[j].StartDate) })
+	return tasks, nil
+}
+
+func (r *Repo) filter(keep func(task *domain.Task) bool) []*domain.Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var tasks []*domain.Task
+	for _, task := range r.tasks {
+		if keep(task) {
+			copied := *task
+			tasks = append(tasks, &copied)
+		}
+	}
+	return tasks
+}
+
+func (r *Repo) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tasks, id)
+	return nil
+}
+
+func (r *Repo) WithTx(_ context.Context, fn func(repo domain.TaskRepository) error) error {
+	return fn(r)
+}
+
+func (r *Repo) Outbox() domain.OutboxRepository              { return outbox{r: r} }
+func (r *Repo) Activity() domain.ActivityRepository          { return activity{r: r} }
+func (r *Repo) Comments() domain.CommentRepository           { return comments{r: r} }
+func (r *Repo) Workflow() domain.WorkflowRepository          { return workflow{r: r} }
+func (r *Repo) Archive() domain.ArchiveRepository            { return archive{r: r} }
+func (r *Repo) CustomFields() domain.CustomFieldRepository   { return customFields{r: r} }
+func (r *Repo) Dependencies() domain.DependencyRepository    { return dependencies{r: r} }
+func (r *Repo) Checklist() domain.ChecklistRepository        { return checklist{r: r} }
+func (r *Repo) Attachments() domain.AttachmentRepository     { return attachments{r: r} }
+func (r *Repo) TimeEntries() domain.TimeEntryRepository      { return timeEntries{r: r} }
+func (r *Repo) FocusSessions() domain.FocusSessionRepository { return focusSessions{r: r} }
+func (r *Repo) Board() domain.BoardRepository                { return board{r: r} }
+
+// LoggedSessions - копия журнала фокуса; раннер помидоров пишет в него из фона
+func (r *Repo) LoggedSessions() []domain.FocusSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sessions := make([]domain.FocusSession, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		sessions = append(sessions, *session)
+	}
+	return sessions
+}
+
+type outbox struct {
+	domain.OutboxRepository
+	r *Repo
+}
+
+// Add, как уникальный индекс outbox, отклоняет повторный ключ идемпотентности
+func (o outbox) Add(_ context.Context, entry *domain.OutboxEntry) error {
+	o.r.mu.Lock()
+	defer o.r.mu.Unlock()
+	for _, e := range o.r.OutboxEntries {
+		if e.IdempotencyKey == entry.IdempotencyKey {
+			return domain.ErrDuplicateEvent
+		}
+	}
+	o.r.OutboxEntries = append(o.r.OutboxEntries, entry)
+	return nil
+}
+
+type activity struct {
+	domain.ActivityRepository
+	r *Repo
+}
+
+// Add, как AddTaskActivity, не пишет task.updated со снапшотом, равным последнему
+func (a activity) Add(_ context.Context, entry *domain.ActivityEntry) error {
+	a.r.mu.Lock()
+	defer a.r.mu.Unlock()
+	if entry.EventType == domain.EventTaskUpdated {
+		var last *domain.ActivityEntry
+		for _, e := range a.r.history {
+			if e.TaskID == entry.TaskID && (last == nil || !e.OccurredAt.Before(last.OccurredAt)) {
+				last = e
+			}
+		}
+		if last != nil && bytes.Equal(last.Snapshot, entry.Snapshot) {
+			return nil
+		}
+	}
+	stored := *entry
+	stored.ID = int64(len(a.r.history) + 1)
+	a.r.history = append(a.r.history, &stored)
+	return nil
+}
+
+// Timeline повторяет ListTaskTimeline: Previous считается по всей истории задачи до LIMIT/OFFSET
+func (a activity) Timeline(_ context.Context, taskID string, limit, offset int) ([]domain.TimelineEntry, error) {
+	a.r.mu.Lock()
+	defer a.r.mu.Unlock()
+
+	var history []*domain.ActivityEntry
+	for _, e := range a.r.history {
+		if e.TaskID == taskID {
+			history = append(history, e)
+		}
+	}
+	slices.SortStableFunc(history, func(x, y *domain.ActivityEntry) int {
+		return cmp.Or(x.OccurredAt.Compare(y.OccurredAt), cmp.Compare(x.ID, y.ID))
+	})
+
+	var timeline []domain.TimelineEntry
+	for i, e := range history {
+		entry := domain.TimelineEntry{Activity: e}
+		if i > 0 {
+			entry.Previous = history[i-1].Snapshot
+		}
+		timeline = append(timeline, entry)
+	}
+	for _, c := range a.r.comments {
+		if c.TaskID == taskID {
+			copied := *c
+			timeline = append(timeline, domain.TimelineEntry{Comment: &copied})
+		}
+	}
+	// ORDER BY at DESC, seq DESC: при равном времени активность выше комментария
+	slices.SortStableFunc(timeline, func(x, y domain.TimelineEntry) int {
+		return cmp.Or(y.At().Compare(x.At()), cmp.Compare(timelineSeq(y), timelineSeq(x)))
+	})
+
+	offset = min(offset, len(timeline))
+	return timeline[offset:min(offset+limit, len(timeline))], nil
+}
+
+func timelineSeq(e domain.TimelineEntry) int64 {
+	if e.Activity != nil {
+		return e.Activity.ID
+	}
+	return 0
+}
+
+func (a activity) CountTimeline(_ context.Context, taskID string) (int, error) {
+	a.r.mu.Lock()
+	defer a.r.mu.Unlock()
+	n := 0
+	for _, e := range a.r.history {
+		if e.TaskID == taskID {
+			n++
+		}
+	}
+	for _, c := range a.r.comments {
+		if c.TaskID == taskID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type comments struct {
+	domain.CommentRepository
+	r *Repo
+}
+
+func (c comments) Save(_ context.Context, comment *domain.Comment) error {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	stored := *comment
+	for i, existing := range c.r.comments {
+		if existing.ID == comment.ID {
+			c.r.comments[i] = &stored
+			return nil
+		}
+	}
+	c.r.comments = append(c.r.comments, &stored)
+	return nil
+}
+
+func (c comments) GetByID(_ context.Context, id string) (*domain.Comment, error) {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	for _, comment := range c.r.comments {
+		if comment.ID == id {
+			copied := *comment
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrCommentNotFound
+}
+
+type workflow struct {
+	domain.WorkflowRepository
+	r *Repo
+}
+
+func (w workflow) Get(context.Context) (domain.Workflow, error) {
+	return domain.Workflow{
+		Statuses:    slices.Clone(w.r.Flow.Statuses),
+		Transitions: slices.Clone(w.r.Flow.Transitions),
+	}, nil
+}
+
+func (w workflow) Lock(context.Context) error { return nil }
+
+func (w workflow) SaveStatus(_ context.Context, status domain.WorkflowStatus) error {
+	statuses := w.r.Flow.Statuses
+	for i := range statuses {
+		if statuses[i].Key == status.Key {
+			statuses[i] = status
+			return nil
+		}
+	}
+	w.r.Flow.Statuses = append(statuses, status)
+	return nil
+}
+
+// DeleteStatus считает и архивные задачи, как CountTasksWithStatus
+func (w workflow) DeleteStatus(_ context.Context, key domain.TaskStatus) error {
+	if len(w.r.filter(func(task *domain.Task) bool { return task.Status == key })) > 0 {
+		return domain.ErrStatusInUse
+	}
+	w.r.Flow.Statuses = slices.DeleteFunc(w.r.Flow.Statuses, func(s domain.WorkflowStatus) bool { return s.Key == key })
+	return nil
+}
+
+func (w workflow) SetTransitions(_ context.Context, transitions []domain.WorkflowTransition) error {
+	w.r.Flow.Transitions = transitions
+	return nil
+}
+
+type archive struct {
+	domain.ArchiveRepository
+	r *Repo
+}
+
+func (a archive) GetByStatus(_ context.Context, status domain.TaskStatus) ([]*domain.Task, error) {
+	return a.r.filter(func(task *domain.Task) bool { return task.ArchivedAt != nil && task.Status == status }), nil
+}
+
+type customFields struct {
+	domain.CustomFieldRepository
+	r *Repo
+}
+
+func (f customFields) List(context.Context) ([]domain.CustomField, error) { return f.r.Fields, nil }
+
+type dependencies struct {
+	domain.DependencyRepository
+	r *Repo
+}
+
+func (d dependencies) GetBlockers(_ context.Context, taskID string) ([]string, error) {
+	var ids []string
+	for _, dep := range d.r.Deps {
+		if dep.TaskID == taskID {
+			ids = append(ids, dep.BlockedByID)
+		}
+	}
+	return ids, nil
+}
+
+func (d dependencies) GetAll(context.Context) ([]domain.Dependency, error) { return d.r.Deps, nil }
+
+// checklist, как и postgres, после каждого изменения пересчитывает счетчики задачи
+type checklist struct {
+	domain.ChecklistRepository
+	r *Repo
+}
+
+func (c checklist) Save(_ context.Context, item *domain.ChecklistItem) error {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	stored := *item
+	if i := slices.IndexFunc(c.r.items, func(it *domain.ChecklistItem) bool { return it.ID == item.ID }); i >= 0 {
+		c.r.items[i] = &stored
+	} else {
+		c.r.items = append(c.r.items, &stored)
+	}
+	c.refreshLocked(item.TaskID)
+	return nil
+}
+
+func (c checklist) GetByID(_ context.Context, id string) (*domain.ChecklistItem, error) {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	for _, item := range c.r.items {
+		if item.ID == id {
+			copied := *item
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrChecklistItemNotFound
+}
+
+func (c checklist) ListByTask(_ context.Context, taskID string) ([]*domain.ChecklistItem, error) {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	var items []*domain.ChecklistItem
+	for _, item := range c.r.items {
+		if item.TaskID == taskID {
+			copied := *item
+			items = append(items, &copied)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+	return items, nil
+}
+
+func (c checklist) SetPositions(_ context.Context, taskID string, itemIDs []string) error {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	for _, item := range c.r.items {
+		if i := slices.Index(itemIDs, item.ID); i >= 0 && item.TaskID == taskID {
+			item.Position = i
+		}
+	}
+	return nil
+}
+
+func (c checklist) CheckAll(_ context.Context, taskID string, at time.Time) (int, error) {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	n := 0
+	for _, item := range c.r.items {
+		if item.TaskID == taskID && !item.Checked {
+			item.SetChecked(true, at)
+			n++
+		}
+	}
+	c.refreshLocked(taskID)
+	return n, nil
+}
+
+func (c checklist) Delete(_ context.Context, id string) error {
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	i := slices.IndexFunc(c.r.items, func(it *domain.ChecklistItem) bool { return it.ID == id })
+	if i < 0 {
+		return domain.ErrChecklistItemNotFound
+	}
+	taskID := c.r.items[i].TaskID
+	c.r.items = slices.Delete(c.r.items, i, i+1)
+	c.refreshLocked(taskID)
+	return nil
+}
+
+func (c checklist) refreshLocked(taskID string) {
+	task, ok := c.r.tasks[taskID]
+	if !ok {
+		return
+	}
+	task.ChecklistTotal, task.ChecklistDone = 0, 0
+	for _, item := range c.r.items {
+		if item.TaskID == taskID {
+			task.ChecklistTotal++
+			if item.Checked {
+				task.ChecklistDone++
+			}
+		}
+	}
+}
+
+type attachments struct {
+	domain.AttachmentRepository
+	r *Repo
+}
+
+func (a attachments) Save(_ context.Context, attachment *domain.Attachment) error {
+	a.r.mu.Lock()
+	defer a.r.mu.Unlock()
+	if !slices.ContainsFunc(a.r.files, func(f *domain.Attachment) bool { return f.ID == attachment.ID }) {
+		stored := *attachment
+		a.r.files = append(a.r.files, &stored)
+	}
+	return nil
+}
+
+func (a attachments) ListByTask(_ context.Context, taskID string) ([]*domain.Attachment, error) {
+	a.r.mu.Lock()
+	defer a.r.mu.Unlock()
+	var files []*domain.Attachment
+	for _, f := range a.r.files {
+		if f.TaskID == taskID {
+			copied := *f
+			files = append(files, &copied)
+		}
+	}
+	return files, nil
+}
+
+func (a attachments) ReferencedHashes(context.Context) (map[string]bool, error) {
+	a.r.mu.Lock()
+	defer a.r.mu.Unlock()
+	hashes := make(map[string]bool, len(a.r.files))
+	for _, f := range a.r.files {
+		hashes[f.Hash] = true
+	}
+	return hashes, nil
+}
+
+func (a attachments) Delete(_ context.Context, id string) error {
+	a.r.mu.Lock()
+	defer a.r.mu.Unlock()
+	i := slices.IndexFunc(a.r.files, func(f *domain.Attachment) bool { return f.ID == id })
+	if i < 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	a.r.files = slices.Delete(a.r.files, i, i+1)
+	return nil
+}
+
+type timeEntries struct {
+	domain.TimeEntryRepository
+	r *Repo
+}
+
+// Save держит правило уникального индекса: запущенный таймер только один
+func (e timeEntries) Save(_ context.Context, entry *domain.TimeEntry) error {
+	e.r.mu.Lock()
+	defer e.r.mu.Unlock()
+	stored := *entry
+	for i, existing := range e.r.Entries {
+		if existing.ID == entry.ID {
+			e.r.Entries[i] = &stored
+			return nil
+		}
+		if existing.Running() && entry.Running() {
+			return errors.New("duplicate running timer")
+		}
+	}
+	e.r.Entries = append(e.r.Entries, &stored)
+	return nil
+}
+
+func (e timeEntries) GetByID(_ context.Context, id string) (*domain.TimeEntry, error) {
+	if found := e.find(func(entry *domain.TimeEntry) bool { return entry.ID == id }); len(found) > 0 {
+		return found[0], nil
+	}
+	return nil, domain.ErrTimeEntryNotFound
+}
+
+func (e timeEntries) GetRunning(context.Context) (*domain.TimeEntry, error) {
+	if found := e.find((*domain.TimeEntry).Running); len(found) > 0 {
+		return found[0], nil
+	}
+	return nil, domain.ErrNoRunningTimer
+}
+
+func (e timeEntries) ListByTask(_ context.Context, taskID string) ([]*domain.TimeEntry, error) {
+	found := e.find(func(entry *domain.TimeEntry) bool { return entry.TaskID == taskID })
+	slices.Reverse(found)
+	return found, nil
+}
+
+func (e timeEntries) Delete(_ context.Context, id string) error {
+	e.r.mu.Lock()
+	defer e.r.mu.Unlock()
+	e.r.Entries = slices.DeleteFunc(e.r.Entries, func(entry *domain.TimeEntry) bool { return entry.ID == id })
+	return nil
+}
+
+func (e timeEntries) find(keep func(entry *domain.TimeEntry) bool) []*domain.TimeEntry {
+	e.r.mu.Lock()
+	defer e.r.mu.Unlock()
+	var found []*domain.TimeEntry
+	for _, entry := range e.r.Entries {
+		if keep(entry) {
+			copied := *entry
+			found = append(found, &copied)
+		}
+	}
+	return found
+}
+
+type focusSessions struct {
+	domain.FocusSessionRepository
+	r *Repo
+}
+
+func (f focusSessions) Add(_ context.Context, session *domain.FocusSession) error {
+	f.r.mu.Lock()
+	defer f.r.mu.Unlock()
+	for _, existing := range f.r.sessions {
+		if existing.ID == session.ID {
+			return nil
+		}
+	}
+	stored := *session
+	f.r.sessions = append(f.r.sessions, &stored)
+	return nil
+}
+
+type board struct {
+	domain.BoardRepository
+	r *Repo
+}
+
+func (b board) Lock(context.Context) error { return nil }
+
+func (b board) Positions(_ context.Context, grouping domain.BoardGrouping) (map[string]int, error) {
+	b.r.mu.Lock()
+	defer b.r.mu.Unlock()
+	return maps.Clone(b.r.positions[grouping]), nil
+}
+
+func (b board) SetPositions(_ context.Context, grouping domain.BoardGrouping, taskIDs []string) error {
+	b.r.mu.Lock()
+	defer b.r.mu.Unlock()
+	if b.r.positions == nil {
+		b.r.positions = make(map[domain.BoardGrouping]map[string]int)
+	}
+	if b.r.positions[grouping] == nil {
+		b.r.positions[grouping] = make(map[string]int)
+	}
+	for i, id := range taskIDs {
+		b.r.positions[grouping][id] = i
+	}
+	return nil
+}
+
+func (b board) WIPLimits(_ context.Context, grouping domain.BoardGrouping) (map[string]int, error) {
+	b.r.mu.Lock()
+	defer b.r.mu.Unlock()
+	return maps.Clone(b.r.limits[grouping]), nil
+}
+
+func (b board) SetWIPLimit(_ context.Context, grouping domain.BoardGrouping, column string, limit int) error {
+	b.r.mu.Lock()
+	defer b.r.mu.Unlock()
+	if b.r.limits == nil {
+		b.r.limits = make(map[domain.BoardGrouping]map[string]int)
+	}
+	if b.r.limits[grouping] == nil {
+		b.r.limits[grouping] = make(map[string]int)
+	}
+	if limit == 0 {
+		delete(b.r.limits[grouping], column)
+	} else {
+		b.r.limits[grouping][column] = limit
+	}
+	return nil
+}
+
+// NopPublisher отбрасывает события
+type NopPublisher struct{}
+
+func (NopPublisher) Publish(context.Context, ...domain.Event) {}
@@ -3,6 +3,9 @@ package util
 import (
 	"github.com/ilyakaznacheev/cleanenv"
 	"os"
+	"os/user"
+	"strings"
+	"time"
 )
 
 type ConfigLoader interface {
@@ -15,7 +18,19 @@ type DBConfig interface {
 }
 
 type Config struct {
-	Database DatabaseConfig `yaml:"database"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Backup      BackupConfig      `yaml:"backup"`
+	CalDAV      CalDAVConfig      `yaml:"caldav"`
+	Outbox      OutboxConfig      `yaml:"outbox"`
+	Sync        SyncConfig        `yaml:"sync"`
+	Pomodoro    PomodoroConfig    `yaml:"pomodoro"`
+	Forecast    ForecastConfig    `yaml:"forecast"`
+	Review      ReviewConfig      `yaml:"review"`
+	Archive     ArchiveConfig     `yaml:"archive"`
+	Snooze      SnoozeConfig      `yaml:"snooze"`
+	Checklist   ChecklistConfig   `yaml:"checklist"`
+	Attachments AttachmentsConfig `yaml:"attachments"`
+	Comments    CommentsConfig    `yaml:"comments"`
 }
 
 type DatabaseConfig struct {
@@ -28,6 +43,104 @@ type DatabaseConfig struct {
 	SSLMODE  string `yaml:"sslmode,omitempty"`
 }
 
+// BackupConfig - автоматические бэкапы; пустой Dir отключает планировщик.
+// Keep не задан - хранятся 7 последних файлов, keep: 0 отключает ротацию.
+type BackupConfig struct {
+	Dir      string        `yaml:"dir,omitempty"`
+	Interval time.Duration `yaml:"interval,omitempty" env-default:"24h"`
+	Keep     *int          `yaml:"keep,omitempty"`
+}
+
+// CalDAVConfig - встроенный CalDAV сервер, по умолчанию выключен
+type CalDAVConfig struct {
+	Enabled  bool   `yaml:"enabled,omitempty"`
+	Addr     string `yaml:"addr,omitempty" env-default:"127.0.0.1:5232"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// OutboxConfig - доставка событий из outbox вебхукам и на Endpoint, если он задан
+type OutboxConfig struct {
+	Endpoint    string        `yaml:"endpoint,omitempty"`
+	Interval    time.Duration `yaml:"interval,omitempty" env-default:"5s"`
+	BatchSize   int           `yaml:"batch_size,omitempty" env-default:"50"`
+	MaxAttempts int           `yaml:"max_attempts,omitempty" env-default:"10"`
+	BaseDelay   time.Duration `yaml:"base_delay,omitempty" env-default:"2s"`
+	MaxDelay    time.Duration `yaml:"max_delay,omitempty" env-default:"1h"`
+	Retention   time.Duration `yaml:"retention,omitempty" env-default:"168h"` // сколько хранить записи outbox
+	// OverdueCheck - как часто искать просроченные задачи для события task.overdue
+	OverdueCheck time.Duration `yaml:"overdue_check,omitempty" env-default:"1m"`
+}
+
+// SyncConfig - синхронизация между устройствами; пустой ServerURL отключает клиента.
+// ListenAddr используется, когда приложение запущено с флагом -sync-server.
+type SyncConfig struct {
+	ServerURL  string        `yaml:"server_url,omitempty"`
+	Token      string        `yaml:"token,omitempty"`
+	Interval   time.Duration `yaml:"interval,omitempty" env-default:"1m"`
+	NodeID     string        `yaml:"node_id,omitempty"` // по умолчанию генерируется и хранится в БД
+	ListenAddr string        `yaml:"listen_addr,omitempty" env-default:"127.0.0.1:8765"`
+}
+
+// PomodoroConfig - длительности фаз помидора
+type PomodoroConfig struct {
+	Work           time.Duration `yaml:"work,omitempty" env-default:"25m"`
+	ShortBreak     time.Duration `yaml:"short_break,omitempty" env-default:"5m"`
+	LongBreak      time.Duration `yaml:"long_break,omitempty" env-default:"15m"`
+	LongBreakEvery int           `yaml:"long_break_every,omitempty" env-default:"4"`
+}
+
+// ForecastConfig - дневная емкость для прогноза загрузки; story point переводится в PointDuration
+type ForecastConfig struct {
+	DailyCapacity   time.Duration `yaml:"daily_capacity,omitempty" env-default:"6h"`
+	PointDuration   time.Duration `yaml:"point_duration,omitempty" env-default:"1h"`
+	DefaultEstimate time.Duration `yaml:"default_estimate,omitempty" env-default:"30m"`
+	Days            int           `yaml:"days,omitempty" env-default:"14"`
+}
+
+// ReviewConfig - еженедельный обзор; пустой Dir отключает запись по расписанию.
+// Format - "markdown", "html" или "both".
+type ReviewConfig struct {
+	Dir       string `yaml:"dir,omitempty"`
+	Weekday   string `yaml:"weekday,omitempty" env-default:"friday"`
+	At        string `yaml:"at,omitempty" env-default:"17:00"`
+	Format    string `yaml:"format,omitempty" env-default:"markdown"`
+	StaleDays int    `yaml:"stale_days,omitempty" env-default:"14"`
+}
+
+// ArchiveConfig - автоархив задач, закрытых больше AfterDays дней назад;
+// не задан - 30 дней, after_days: 0 отключает
+type ArchiveConfig struct {
+	AfterDays *int          `yaml:"after_days,omitempty"`
+	Interval  time.Duration `yaml:"interval,omitempty" env-default:"1h"`
+}
+
+// SnoozeConfig - отложенные задачи: Morning - "утро" для вариантов tomorrow и next_week
+// (смещение от начала дня), WakeCheck - как часто искать проснувшиеся задачи
+type SnoozeConfig struct {
+	Morning   time.Duration `yaml:"morning,omitempty" env-default:"9h"`
+	WakeCheck time.Duration `yaml:"wake_check,omitempty" env-default:"1m"`
+}
+
+// ChecklistConfig - CheckOnComplete отмечает все пункты чек-листа при завершении задачи;
+// не задан - включено, check_on_complete: false отключает
+type ChecklistConfig struct {
+	CheckOnComplete *bool `yaml:"check_on_complete,omitempty"`
+}
+
+// AttachmentsConfig - локальное хранилище вложений: лимит размера файла
+// и как часто удалять содержимое, на которое не осталось ссылок
+type AttachmentsConfig struct {
+	Dir        string        `yaml:"dir,omitempty" env-default:"./attachments"`
+	MaxSizeMB  int           `yaml:"max_size_mb,omitempty" env-default:"25"`
+	GCInterval time.Duration `yaml:"gc_interval,omitempty" env-default:"24h"`
+}
+
+// CommentsConfig - Author подписывает комментарии; пусто - имя пользователя ОС
+type CommentsConfig struct {
+	Author string `yaml:"author,omitempty"`
+}
+
 // ------ easy connect ---------
 
 func (d DatabaseConfig) DriverName() string {
@@ -38,6 +151,35 @@ func (d DatabaseConfig) DSN() string {
 	return d.Driver + "://" + d.User + ":" + d.Password + "@" + d.Host + ":" + d.Port + "/" + d.Name + "?sslmode=" + d.SSLMODE
 }
 
+func (b BackupConfig) KeepFiles() int {
+	if b.Keep == nil {
+		return 7
+	}
+	return *b.Keep
+}
+
+func (a ArchiveConfig) ArchiveAfterDays() int {
+	if a.AfterDays == nil {
+		return 30
+	}
+	return *a.AfterDays
+}
+
+func (c ChecklistConfig) CheckItemsOnComplete() bool {
+	return c.CheckOnComplete == nil || *c.CheckOnComplete
+}
+
+func (c CommentsConfig) AuthorName() string {
+	if c.Author != "" {
+		return c.Author
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		// В Windows имя приходит как DOMAIN\user
+		return u.Username[strings.LastIndex(u.Username, "\\")+1:]
+	}
+	return "me"
+}
+
 // --------- CleanENV ------------
 
 type CleanenvLoader struct{}
@@ -3,11 +3,27 @@ package main
 import (
 	"context"
 	"embed"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
 
+	"github.com/w0ikid/dekstop-todo-app/internal/adapters/caldav"
+	"github.com/w0ikid/dekstop-todo-app/internal/adapters/syncapi"
 	adapter "github.com/w0ikid/dekstop-todo-app/internal/adapters/wails"
 	"github.com/w0ikid/dekstop-todo-app/internal/app"
+	"github.com/w0ikid/dekstop-todo-app/internal/domain"
 	db "github.com/w0ikid/dekstop-todo-app/internal/db/sqlc"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/backup"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/blobstore"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/eventbus"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/jobs"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/outbox"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/pomodoro"
 	"github.com/w0ikid/dekstop-todo-app/internal/infra/postgres"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/review"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/syncclient"
+	"github.com/w0ikid/dekstop-todo-app/internal/infra/webhook"
 	"github.com/w0ikid/dekstop-todo-app/internal/util"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -30,6 +46,9 @@ func NewApp() *App {
 }
 
 func main() {
+	syncServer := flag.Bool("sync-server", false, "run headless sync server instead of the UI")
+	flag.Parse()
+
 	// Load .env
 	if err := godotenv.Load(); err != nil {
 		panic("Error loading .env file")
@@ -48,17 +67,111 @@ func main() {
 	// Queries
 	queries := db.New(conn)
 
+	// Часы синхронизации: ID узла различает устройства при равных метках
+	nodeID := cfg.Sync.NodeID
+	if nodeID == "" {
+		nodeID, err = postgres.LoadNodeID(context.Background(), queries)
+		if err != nil {
+			panic("cannot load sync node id: " + err.Error())
+		}
+	}
+	clock := domain.NewClock(nodeID)
+
 	// Repository
-	taskRepo := postgres.NewTaskRepository(queries, conn)
+	taskRepo := postgres.NewTaskRepository(queries, conn, clock)
+
+	if *syncServer {
+		runSyncServer(app.NewServeSync(taskRepo, clock), cfg.Sync)
+		return
+	}
+
+	webhookRepo := postgres.NewWebhookRepository(queries)
+	webhookClient := webhook.NewClient()
+
+	// Шина доменных событий
+	eventBus := eventbus.NewBus()
+	defer eventBus.Close()
 
 	// Use cases
-	createTask := app.NewCreateTask(taskRepo)
-	updateTask := app.NewUpdateTask(taskRepo)
-	completeTask := app.NewCompleteTask(taskRepo)
+	createTask := app.NewCreateTask(taskRepo, eventBus)
+	updateTask := app.NewUpdateTask(taskRepo, eventBus)
+	completeTask := app.NewCompleteTask(taskRepo, eventBus)
 	getTask := app.NewGetTask(taskRepo)
 	listTasks := app.NewListTasks(taskRepo)
-	getDashboard := app.NewGetDashboard(taskRepo)
-	deleteTask := app.NewDeleteTask(taskRepo)
+	forecastSettings := app.ForecastSettings{
+		DailyCapacity:   cfg.Forecast.DailyCapacity,
+		PointDuration:   cfg.Forecast.PointDuration,
+		DefaultEstimate: cfg.Forecast.DefaultEstimate,
+		Days:            cfg.Forecast.Days,
+	}
+	getDashboard := app.NewGetDashboard(taskRepo, forecastSettings)
+	deleteTask := app.NewDeleteTask(taskRepo, eventBus)
+	exportBackup := app.NewExportBackup(taskRepo)
+	importBackup := app.NewImportBackup(taskRepo)
+	exportCSV := app.NewExportCSV(taskRepo)
+	importCSV := app.NewImportCSV(taskRepo)
+	exportMarkdown := app.NewExportMarkdown(taskRepo)
+	importMarkdown := app.NewImportMarkdown(taskRepo)
+	importTaskwarrior := app.NewImportTaskwarrior(taskRepo)
+	importTodoist := app.NewImportTodoist(taskRepo)
+	listOutbox := app.NewListOutbox(taskRepo)
+	retryOutboxEntry := app.NewRetryOutboxEntry(taskRepo)
+	detectOverdue := app.NewDetectOverdue(taskRepo, eventBus, cfg.Outbox.Retention)
+	saveWebhook := app.NewSaveWebhook(webhookRepo)
+	deleteWebhook := app.NewDeleteWebhook(webhookRepo)
+	listWebhooks := app.NewListWebhooks(webhookRepo)
+	listWebhookDeliveries := app.NewListWebhookDeliveries(webhookRepo)
+	sendTestWebhook := app.NewSendTestWebhook(webhookRepo, webhookClient)
+
+	var syncTransport app.SyncTransport
+	if cfg.Sync.ServerURL != "" {
+		syncTransport = syncclient.NewClient(cfg.Sync.ServerURL, cfg.Sync.Token)
+	}
+	syncNow := app.NewSyncNow(taskRepo, clock, syncTransport)
+	startTimer := app.NewStartTimer(taskRepo)
+	stopTimer := app.NewStopTimer(taskRepo)
+	getRunningTimer := app.NewGetRunningTimer(taskRepo)
+	addTimeEntry := app.NewAddTimeEntry(taskRepo)
+	updateTimeEntry := app.NewUpdateTimeEntry(taskRepo)
+	deleteTimeEntry := app.NewDeleteTimeEntry(taskRepo)
+	listTimeEntries := app.NewListTimeEntries(taskRepo)
+	getTimeReport := app.NewGetTimeReport(taskRepo)
+	logFocusSession := app.NewLogFocusSession(taskRepo)
+	addDependency := app.NewAddDependency(taskRepo)
+	removeDependency := app.NewRemoveDependency(taskRepo)
+	getDependencyGraph := app.NewGetDependencyGraph(taskRepo)
+	getForecast := app.NewGetForecast(taskRepo, forecastSettings)
+	getWorkflow := app.NewGetWorkflow(taskRepo)
+	saveWorkflow := app.NewSaveWorkflow(taskRepo, eventBus)
+	getBoard := app.NewGetBoard(taskRepo)
+	moveCard := app.NewMoveCard(taskRepo, eventBus)
+	setWIPLimit := app.NewSetWIPLimit(taskRepo)
+	getStats := app.NewGetStats(taskRepo)
+	getBurndown := app.NewGetBurndown(taskRepo)
+	generateReview := app.NewGenerateReview(taskRepo, cfg.Review.StaleDays)
+	archiveTasks := app.NewArchiveTasks(taskRepo, cfg.Archive.ArchiveAfterDays())
+	listArchive := app.NewListArchive(taskRepo)
+	snoozeTask := app.NewSnoozeTask(taskRepo, eventBus, cfg.Snooze.Morning)
+	detectWakeups := app.NewDetectWakeups(taskRepo, eventBus, cfg.Outbox.Retention)
+	addChecklistItem := app.NewAddChecklistItem(taskRepo)
+	renameChecklistItem := app.NewRenameChecklistItem(taskRepo)
+	checkChecklistItem := app.NewCheckChecklistItem(taskRepo)
+	moveChecklistItem := app.NewMoveChecklistItem(taskRepo)
+	deleteChecklistItem := app.NewDeleteChecklistItem(taskRepo)
+	listChecklist := app.NewListChecklist(taskRepo)
+	blobStore := blobstore.NewStore(cfg.Attachments)
+	addAttachment := app.NewAddAttachment(taskRepo, blobStore)
+	listAttachments := app.NewListAttachments(taskRepo)
+	getAttachmentPath := app.NewGetAttachmentPath(taskRepo, blobStore)
+	deleteAttachment := app.NewDeleteAttachment(taskRepo)
+	collectBlobs := app.NewCollectBlobs(taskRepo, blobStore)
+	addComment := app.NewAddComment(taskRepo, cfg.Comments.AuthorName())
+	editComment := app.NewEditComment(taskRepo)
+	deleteComment := app.NewDeleteComment(taskRepo)
+	getTaskActivity := app.NewGetTaskActivity(taskRepo)
+	listCustomFields := app.NewListCustomFields(taskRepo)
+	saveCustomField := app.NewSaveCustomField(taskRepo)
+	deleteCustomField := app.NewDeleteCustomField(taskRepo, eventBus)
 
 	// TaskHandler
 	taskHandler := adapter.NewTaskHandler(
@@ -66,6 +179,116 @@ func main() {
 		getTask, listTasks, getDashboard, deleteTask,
 	)
 
+	// Автоматические бэкапы
+	backupScheduler := backup.NewScheduler(exportBackup, cfg.Backup)
+
+	backupHandler := adapter.NewBackupHandler(exportBackup, importBackup, backupScheduler)
+
+	// Еженедельный обзор в директорию
+	reviewScheduler, err := review.NewScheduler(generateReview, cfg.Review)
+	if err != nil {
+		panic(err.Error())
+	}
+	reviewHandler := adapter.NewReviewHandler(generateReview, reviewScheduler)
+	importExportHandler := adapter.NewImportExportHandler(
+		exportCSV, importCSV,
+		exportMarkdown, importMarkdown,
+		importTaskwarrior, importTodoist,
+	)
+	outboxHandler := adapter.NewOutboxHandler(listOutbox, retryOutboxEntry)
+	webhookHandler := adapter.NewWebhookHandler(
+		saveWebhook, deleteWebhook, listWebhooks,
+		listWebhookDeliveries, sendTestWebhook,
+	)
+	syncHandler := adapter.NewSyncHandler(syncNow)
+	dependencyHandler := adapter.NewDependencyHandler(addDependency, removeDependency, getDependencyGraph)
+	forecastHandler := adapter.NewForecastHandler(updateTask, getForecast)
+	workflowHandler := adapter.NewWorkflowHandler(getWorkflow, saveWorkflow)
+	boardHandler := adapter.NewBoardHandler(getBoard, moveCard, setWIPLimit)
+	statsHandler := adapter.NewStatsHandler(getStats, getBurndown)
+	archiveHandler := adapter.NewArchiveHandler(listArchive, archiveTasks)
+	snoozeHandler := adapter.NewSnoozeHandler(snoozeTask, updateTask)
+	checklistHandler := adapter.NewChecklistHandler(
+		addChecklistItem, renameChecklistItem, checkChecklistItem,
+		moveChecklistItem, deleteChecklistItem, listChecklist,
+	)
+	attachmentHandler := adapter.NewAttachmentHandler(addAttachment, listAttachments, getAttachmentPath, deleteAttachment)
+	commentHandler := adapter.NewCommentHandler(addComment, editComment, deleteComment, getTaskActivity)
+	customFieldHandler := adapter.NewCustomFieldHandler(
+		listCustomFields, saveCustomField, deleteCustomField,
+		createTask, updateTask, listTasks,
+	)
+
+	// Помидоры живут в памяти, в БД попадают только рабочие сессии
+	pomodoroRunner := pomodoro.NewRunner(getTask, logFocusSession, cfg.Pomodoro)
+	defer pomodoroRunner.Close()
+	pomodoroHandler := adapter.NewPomodoroHandler(pomodoroRunner)
+	timeTrackingHandler := adapter.NewTimeTrackingHandler(
+		startTimer, stopTimer, getRunningTimer,
+		addTimeEntry, updateTimeEntry, deleteTimeEntry,
+		listTimeEntries, getTimeReport,
+	)
+
+	// Доставка событий из outbox вебхукам и во внешнюю систему
+	outboxSenders := outbox.MultiSender{webhook.NewDispatcher(webhookRepo, webhookClient, taskRepo.Workflow())}
+	if cfg.Outbox.Endpoint != "" {
+		outboxSenders = append(outboxSenders, outbox.NewHTTPSender(cfg.Outbox.Endpoint))
+	}
+	outboxRelay := outbox.NewRelay(taskRepo.Outbox(), outboxSenders, cfg.Outbox)
+	eventBus.SubscribeAsync("outbox relay", outboxRelay.Wake)
+	if cfg.Checklist.CheckItemsOnComplete() {
+		// Синхронно: завершение задачи возвращается уже с отмеченным чек-листом
+		eventBus.Subscribe("checklist on complete", app.NewCheckChecklistOnComplete(taskRepo).Handle, domain.EventTaskCompleted)
+	}
+
+	// Фоновые задачи живут до закрытия окна
+	bgCtx, cancelBg := context.WithCancel(context.Background())
+	defer cancelBg()
+
+	go backupScheduler.Run(bgCtx)
+	go reviewScheduler.Run(bgCtx)
+	go outboxRelay.Run(bgCtx)
+	go jobs.Every(bgCtx, "overdue check", cfg.Outbox.OverdueCheck, func(ctx context.Context) error {
+		_, err := detectOverdue.Execute(ctx)
+		return err
+	})
+	go jobs.Every(bgCtx, "wake check", cfg.Snooze.WakeCheck, func(ctx context.Context) error {
+		_, err := detectWakeups.Execute(ctx)
+		return err
+	})
+	go jobs.Every(bgCtx, "blob gc", cfg.Attachments.GCInterval, func(ctx context.Context) error {
+		_, err := collectBlobs.Execute(ctx)
+		return err
+	})
+	if archiveTasks.Enabled() {
+		go jobs.Every(bgCtx, "archive", cfg.Archive.Interval, func(ctx context.Context) error {
+			_, err := archiveTasks.Execute(ctx)
+			return err
+		})
+	}
+	if syncTransport != nil {
+		go jobs.Every(bgCtx, "sync", cfg.Sync.Interval, func(ctx context.Context) error {
+			_, err := syncNow.Execute(ctx)
+			return err
+		})
+	}
+
+	// CalDAV сервер для календарных клиентов
+	if cfg.CalDAV.Enabled {
+		caldavServer := caldav.NewServer(
+			createTask, updateTask,
+			getTask, listTasks, deleteTask,
+			getWorkflow, cfg.CalDAV,
+		)
+		go func() {
+			if err := caldavServer.ListenAndServe(bgCtx, cfg.CalDAV.Addr); err != nil {
+				println("CalDAV error:", err.Error())
+			}
+		}()
+	}
+
+	changeListener := postgres.NewChangeListener(conn)
+
 	appInstance := NewApp()
 
 	// Run Wails
@@ -79,9 +302,36 @@ func main() {
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup: func(ctx context.Context) {
 			appInstance.ctx = ctx
+
+			// Изменения от других экземпляров приложения и CLI
+			go changeListener.Listen(bgCtx, adapter.ChangeFeed(ctx))
+			pomodoroRunner.SetNotifier(adapter.PomodoroFeed(ctx))
+			eventBus.Subscribe("wake feed", adapter.WakeFeed(ctx), domain.EventTaskWoke)
+		},
+		OnShutdown: func(ctx context.Context) {
+			cancelBg()
 		},
 		Bind: []interface{}{
 			taskHandler, // биндим TaskHandler напрямую, чтобы фронтенд видел методы
+			backupHandler,
+			importExportHandler,
+			outboxHandler,
+			webhookHandler,
+			syncHandler,
+			timeTrackingHandler,
+			pomodoroHandler,
+			dependencyHandler,
+			forecastHandler,
+			workflowHandler,
+			boardHandler,
+			statsHandler,
+			reviewHandler,
+			archiveHandler,
+			snoozeHandler,
+			checklistHandler,
+			attachmentHandler,
+			commentHandler,
+			customFieldHandler,
 		},
 	})
 
@@ -89,3 +339,14 @@ func main() {
 		println("Error:", err.Error())
 	}
 }
+
+// runSyncServer - режим без UI: только сервер синхронизации, до SIGINT
+func runSyncServer(serveSync app.ServeSync, cfg util.SyncConfig) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Printf("sync server listening on %s", cfg.ListenAddr)
+	if err := syncapi.NewServer(serveSync, cfg.Token).ListenAndServe(ctx, cfg.ListenAddr); err != nil {
+		log.Printf("sync server: %v", err)
+	}
+}